@@ -6,6 +6,8 @@ import (
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
 	"github.com/olympus-protocol/ogen/cmd/ogen/initialization"
 	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/keystore"
 	"github.com/olympus-protocol/ogen/internal/server"
 	"github.com/olympus-protocol/ogen/pkg/logger"
 	"github.com/olympus-protocol/ogen/pkg/params"
@@ -26,6 +28,21 @@ var (
 	Dashboard     bool
 	DashboardPort string
 
+	StaticPeers  []string
+	TrustedPeers []string
+	NoDiscovery  bool
+	PSKFile      string
+	DevMode      bool
+
+	PeerMsgRate    int
+	PeerByteRate   int
+	GlobalByteRate int
+
+	RemoteSigner          string
+	RemoteSignerTokenFile string
+
+	DoppelgangerEpochs uint64
+
 	HTTPHost       string
 	HTTPPort       int
 	HTTPPathPrefix string
@@ -49,7 +66,20 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&DataPath, "datadir", "", "Directory to store the chain data.")
 
 	rootCmd.Flags().StringVar(&NetName, "network", "testnet", "String of the network to connect.")
-	rootCmd.Flags().StringVar(&Port, "port", "24126", "Default port for p2p connections listener.")
+	rootCmd.Flags().StringVar(&Port, "port", "", "Port for the p2p connections listener, defaults to the network port.")
+	rootCmd.Flags().StringSliceVar(&StaticPeers, "static_peers", []string{}, "Multiaddresses of peers to keep always connected.")
+	rootCmd.Flags().StringSliceVar(&TrustedPeers, "trusted_peers", []string{}, "IDs or multiaddresses of peers exempt from bans and connection limits.")
+	rootCmd.Flags().BoolVar(&NoDiscovery, "no_discovery", false, "Disable peer discovery and only connect to the static peers.")
+	rootCmd.Flags().StringVar(&PSKFile, "psk_file", "", "Swarm key file to join a private network.")
+	rootCmd.Flags().BoolVar(&DevMode, "dev", false, "Listen on local addresses and find peers on the local network with mDNS.")
+	rootCmd.Flags().IntVar(&PeerMsgRate, "p2p_peer_msg_rate", 50, "Messages per second of each type accepted from a peer, 0 disables the limit.")
+	rootCmd.Flags().IntVar(&PeerByteRate, "p2p_peer_byte_rate", 4*1024*1024, "Bytes per second accepted from a peer, 0 disables the limit.")
+	rootCmd.Flags().IntVar(&GlobalByteRate, "p2p_global_byte_rate", 32*1024*1024, "Bytes per second accepted from all the peers, 0 disables the limit.")
+
+	rootCmd.Flags().StringVar(&RemoteSigner, "remote_signer", "", "URL of a remote signer to sign the validator duties instead of the local keystore.")
+	rootCmd.Flags().StringVar(&RemoteSignerTokenFile, "remote_signer_token_file", "", "File with the token to authenticate with the remote signer.")
+
+	rootCmd.Flags().Uint64Var(&DoppelgangerEpochs, "doppelganger_epochs", 2, "Epochs to look for the validator keys voting from another node before starting the duties, 0 disables the check.")
 
 	rootCmd.Flags().StringVar(&DashboardPort, "dashboard_port", "8080", "Port to expose node dashboard.")
 	rootCmd.Flags().BoolVar(&Dashboard, "dashboard", false, "Expose node dashboard.")
@@ -60,10 +90,12 @@ func init() {
 	rootCmd.Flags().StringVar(&HTTPHost, "http_host", "localhost", "")
 	rootCmd.Flags().IntVar(&HTTPPort, "http_port", 9090, "")
 	rootCmd.Flags().StringVar(&HTTPPathPrefix, "http_prefix", "", "")
+	rootCmd.Flags().StringSliceVar(&HTTPModules, "http_modules", []string{}, "RPC namespaces served over HTTP, the public ones when empty. The validator namespace must be listed to serve validator clients.")
 
 	rootCmd.Flags().StringVar(&WSHost, "ws_host", "localhost", "")
 	rootCmd.Flags().IntVar(&WSPort, "ws_port", 9091, "")
 	rootCmd.Flags().StringVar(&WSPathPrefix, "ws_prefix", "", "")
+	rootCmd.Flags().StringSliceVar(&WSModules, "ws_modules", []string{}, "RPC namespaces served over websockets, the public ones when empty. The validator namespace must be listed to serve validator clients.")
 
 	err := viper.BindPFlags(rootCmd.PersistentFlags())
 	if err != nil {
@@ -121,19 +153,32 @@ func initConfig() {
 	}
 
 	config.GlobalFlags = &config.Flags{
-		DataPath:       DataPath,
-		NetworkName:    NetName,
-		Port:           Port,
-		Debug:          Debug,
-		LogFile:        LogFile,
-		DashboardPort:  DashboardPort,
-		Dashboard:      Dashboard,
-		HTTPPort:       HTTPPort,
-		HTTPHost:       HTTPHost,
-		HTTPPathPrefix: HTTPPathPrefix,
-		WSPort:         WSPort,
-		WSHost:         WSHost,
-		WSPathPrefix:   WSPathPrefix,
+		DataPath:              DataPath,
+		NetworkName:           NetName,
+		Port:                  Port,
+		Debug:                 Debug,
+		LogFile:               LogFile,
+		DashboardPort:         DashboardPort,
+		Dashboard:             Dashboard,
+		StaticPeers:           StaticPeers,
+		TrustedPeers:          TrustedPeers,
+		NoDiscovery:           NoDiscovery,
+		PSKFile:               PSKFile,
+		DevMode:               DevMode,
+		PeerMsgRate:           PeerMsgRate,
+		PeerByteRate:          PeerByteRate,
+		GlobalByteRate:        GlobalByteRate,
+		RemoteSigner:          RemoteSigner,
+		RemoteSignerTokenFile: RemoteSignerTokenFile,
+		DoppelgangerEpochs:    DoppelgangerEpochs,
+		HTTPPort:              HTTPPort,
+		HTTPHost:              HTTPHost,
+		HTTPPathPrefix:        HTTPPathPrefix,
+		HTTPModules:           HTTPModules,
+		WSPort:                WSPort,
+		WSHost:                WSHost,
+		WSPathPrefix:          WSPathPrefix,
+		WSModules:             WSModules,
 	}
 
 	var log logger.Logger
@@ -194,6 +239,18 @@ func initConfig() {
 	}
 }
 
+// setGenesisHash sets the genesis hash of the chain stored in the database on the network params. The signing
+// domains commit to it.
+func setGenesisHash(db blockdb.Database) error {
+	genesisHash, err := chain.LoadGenesisHash(db)
+	if err != nil {
+		return err
+	}
+	config.GlobalParams.NetParams.GenesisHash = genesisHash
+	config.GlobalParams.Logger.Infof("using genesis hash %s", genesisHash)
+	return nil
+}
+
 var rootCmd = &cobra.Command{
 	Use:   "ogen",
 	Short: "Ogen is a Go Olympus implementation",
@@ -206,12 +263,26 @@ var rootCmd = &cobra.Command{
 
 		config.InterruptListener()
 
+		// The duties are signed by the remote signer, the local keystore is left closed.
+		ks := keystore.NewKeystore()
+		if config.GlobalFlags.RemoteSigner == "" {
+			var err error
+			ks, err = openKeystore()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		db, err := blockdb.NewLevelDB()
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		s, err := server.NewServer(db)
+		if err := setGenesisHash(db); err != nil {
+			log.Fatal(err)
+		}
+
+		s, err := server.NewServer(db, ks)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/chainarchive"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/spf13/cobra"
+)
+
+var exportHeight uint64
+
+var exportChainCmd = &cobra.Command{
+	Use:   "export-chain <file>",
+	Short: "Exports the blocks from genesis to a chain archive",
+	Long:  `Exports the blocks of the main chain from genesis up to the tip, or the specified height, to a portable chain archive`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		db, err := blockdb.NewLevelDB()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		tip, err := db.GetTip()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tipRow, err := db.GetBlockRow(tip)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		height := tipRow.Height
+		if exportHeight != 0 {
+			if exportHeight > tipRow.Height {
+				log.Fatalf("requested height %d is above the chain height %d", exportHeight, tipRow.Height)
+			}
+			height = exportHeight
+		}
+
+		// Walk back from the tip to genesis to find the hashes of the main chain.
+		hashes := make([]chainhash.Hash, tipRow.Height+1)
+		row := tipRow
+		for {
+			hashes[row.Height] = row.Hash
+			if row.Height == 0 {
+				break
+			}
+			row, err = db.GetBlockRow(row.Parent)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		genesisTime, err := db.GetGenesisTime()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		genesisHash, err := chain.ComputeGenesisHash(genesisTime)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		f, err := os.Create(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		w, err := chainarchive.NewWriter(f, &chainarchive.Header{
+			Version:     chainarchive.Version,
+			NetworkName: []byte(config.GlobalParams.NetParams.Name),
+			GenesisHash: genesisHash,
+			GenesisTime: uint64(genesisTime.Unix()),
+			Height:      height,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for h := uint64(1); h <= height; h++ {
+			b, err := db.GetRawBlock(hashes[h])
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := w.WriteRawBlock(b); err != nil {
+				log.Fatal(err)
+			}
+			if h%1000 == 0 {
+				log.Infof("exported %d/%d blocks", h, height)
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Infof("exported %d blocks to %s", height, args[0])
+	},
+}
+
+func init() {
+	exportChainCmd.Flags().Uint64Var(&exportHeight, "height", 0, "height of the last block to export, defaults to the chain tip")
+
+	rootCmd.AddCommand(exportChainCmd)
+}
@@ -51,9 +51,14 @@ var genParamsCmd = &cobra.Command{
 
 		dirPath := "./cmd/ogen/initialization/"
 
+		pass, err := readPassphrase(KeystorePassFile, "Passphrase to encrypt the new keystore: ", true)
+		if err != nil {
+			panic(err)
+		}
+
 		ks := keystore.NewKeystore()
 
-		err = ks.CreateKeystore()
+		err = ks.CreateKeystore(pass)
 		if err != nil {
 			panic(err)
 		}
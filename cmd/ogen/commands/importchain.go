@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/chainarchive"
+	"github.com/spf13/cobra"
+)
+
+var importTrusted bool
+
+var importChainCmd = &cobra.Command{
+	Use:   "import-chain <file>",
+	Short: "Imports the blocks from a chain archive",
+	Long:  `Imports the blocks from a chain archive created with export-chain. Blocks are fully validated unless the archive is marked as trusted, which skips the proposer and RANDAO signatures`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		config.InterruptListener()
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		r, err := chainarchive.NewReader(f)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		header := r.Header()
+
+		if string(header.NetworkName) != config.GlobalParams.NetParams.Name {
+			log.Fatalf("archive is for network %s but the node is running %s", header.NetworkName, config.GlobalParams.NetParams.Name)
+		}
+
+		// The genesis hash commits to the genesis state and time, archives of other chains of the same network are
+		// rejected before importing any block.
+		genesisTime := time.Unix(int64(header.GenesisTime), 0)
+		genesisHash, err := chain.ComputeGenesisHash(genesisTime)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if genesisHash != header.GenesisHash {
+			log.Fatalf("archive genesis hash %x does not match the chain genesis hash %s", header.GenesisHash, genesisHash)
+		}
+
+		db, err := blockdb.NewLevelDB()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		// The genesis time is not part of the blocks, so a fresh datadir must use the
+		// one from the archive for the block timestamps to be valid.
+		if dbGenesisTime, err := db.GetGenesisTime(); err != nil {
+			if err := db.SetGenesisTime(genesisTime); err != nil {
+				log.Fatal(err)
+			}
+		} else if dbGenesisTime.Unix() != genesisTime.Unix() {
+			log.Fatalf("archive genesis time %d does not match database genesis time %d", genesisTime.Unix(), dbGenesisTime.Unix())
+		}
+
+		config.GlobalParams.NetParams.GenesisHash = genesisHash
+
+		ch, err := chain.NewBlockchain(db, genesisHash)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if importTrusted {
+			log.Warn("importing trusted archive, the proposer and RANDAO signatures will not be verified")
+		}
+
+		var imported, skipped uint64
+		start := time.Now()
+		lastReport := start
+
+		for {
+			select {
+			case <-config.GlobalParams.Context.Done():
+				log.Infof("import interrupted after %d blocks", imported)
+				return
+			default:
+			}
+
+			block, err := r.ReadBlock()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if ch.State().Index().Have(block.Hash()) {
+				skipped++
+				continue
+			}
+
+			if importTrusted {
+				err = ch.ProcessTrustedBlock(block)
+			} else {
+				err = ch.ProcessBlock(block)
+			}
+			if err != nil {
+				log.Fatalf("unable to import block at slot %d: %s", block.Header.Slot, err)
+			}
+			imported++
+
+			if time.Since(lastReport) > 10*time.Second {
+				done := imported + skipped
+				log.Infof("imported %d/%d blocks (%.2f%%)", done, header.Height, float64(done)/float64(header.Height)*100)
+				lastReport = time.Now()
+			}
+		}
+
+		log.Infof("imported %d blocks, skipped %d already known, in %s", imported, skipped, time.Since(start))
+	},
+}
+
+func init() {
+	importChainCmd.Flags().BoolVar(&importTrusted, "trusted", false, "skip the proposer and RANDAO signature verification for archives from a trusted source, the signatures of the block contents are always verified")
+
+	rootCmd.AddCommand(importChainCmd)
+}
@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	// ErrorNoPassphrase returns when the passphrase can't be prompted and no password file is specified.
+	ErrorNoPassphrase = errors.New("no terminal to prompt the keystore passphrase, use --keystore_pass_file")
+
+	// ErrorPassphraseMismatch returns when the passphrase confirmation doesn't match.
+	ErrorPassphraseMismatch = errors.New("the passphrases don't match")
+)
+
+var (
+	KeystorePassFile string
+	newPassFile      string
+	jsonPassFile     string
+)
+
+var keystoreCmd = &cobra.Command{
+	Use:   "keystore",
+	Short: "Manages the validators keystore",
+	Long:  `Manages the encryption of the validators keystore and imports and exports validator keys`,
+}
+
+var keystoreEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypts a plaintext keystore",
+	Long:  `Encrypts the mnemonic and the keys of a keystore created by a version of ogen that stored them in plaintext`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		pass, err := readPassphrase(KeystorePassFile, "New keystore passphrase: ", true)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ks := keystore.NewKeystore()
+		if err := ks.EncryptKeystore(pass); err != nil {
+			log.Fatal(err)
+		}
+		_ = ks.Close()
+
+		log.Info("keystore encrypted")
+	},
+}
+
+var keystoreChangePassCmd = &cobra.Command{
+	Use:   "change-passphrase",
+	Short: "Changes the keystore passphrase",
+	Long:  `Encrypts the mnemonic and the keys of the keystore with a new passphrase`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		pass, err := readPassphrase(KeystorePassFile, "Current keystore passphrase: ", false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ks := keystore.NewKeystore()
+		if err := ks.OpenKeystore(pass); err != nil {
+			log.Fatal(err)
+		}
+		defer ks.Close()
+
+		newPass, err := readPassphrase(newPassFile, "New keystore passphrase: ", true)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := ks.ChangePassphrase(newPass); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Info("keystore passphrase changed")
+	},
+}
+
+var keystoreExportCmd = &cobra.Command{
+	Use:   "export <pubkey> <file>",
+	Short: "Exports a validator key to a JSON keystore file",
+	Long:  `Exports a validator key to an EIP-2335 JSON keystore file encrypted with a new passphrase`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		pubBytes, err := hex.DecodeString(args[0])
+		if err != nil || len(pubBytes) != 48 {
+			log.Fatalf("invalid public key %s", args[0])
+		}
+		var pub [48]byte
+		copy(pub[:], pubBytes)
+
+		pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ks := keystore.NewKeystore()
+		if err := ks.OpenKeystore(pass); err != nil {
+			log.Fatal(err)
+		}
+		defer ks.Close()
+
+		jsonPass, err := readPassphrase(jsonPassFile, "Passphrase to encrypt the JSON keystore: ", true)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		jks, err := ks.ExportKey(pub, jsonPass)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		b, err := keystore.MarshalJSONKeystore(jks)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(args[1], b, 0600); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Infof("exported key %s to %s", args[0], args[1])
+	},
+}
+
+var keystoreImportCmd = &cobra.Command{
+	Use:   "import <dir>",
+	Short: "Imports the JSON keystore files of a directory",
+	Long:  `Imports the validator keys of the EIP-2335 JSON keystore files of a directory, all the files must use the same passphrase and the keys already on the keystore are skipped`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		files, err := ioutil.ReadDir(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ks := keystore.NewKeystore()
+		if err := ks.OpenKeystore(pass); err != nil {
+			log.Fatal(err)
+		}
+		defer ks.Close()
+
+		jsonPass, err := readPassphrase(jsonPassFile, "JSON keystores passphrase: ", false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		imported, skipped := 0, 0
+		for _, f := range files {
+			if f.IsDir() || path.Ext(f.Name()) != ".json" {
+				continue
+			}
+
+			b, err := ioutil.ReadFile(path.Join(args[0], f.Name()))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			jks, err := keystore.UnmarshalJSONKeystore(b)
+			if err != nil {
+				log.Warnf("skipping %s: %s", f.Name(), err)
+				skipped++
+				continue
+			}
+
+			key, err := ks.ImportKey(jks, jsonPass)
+			if err == keystore.ErrorKeyExists {
+				log.Infof("key %s is already on the keystore", jks.PubKey)
+				skipped++
+				continue
+			}
+			if err != nil {
+				log.Fatalf("unable to import %s: %s", f.Name(), err)
+			}
+
+			log.Infof("imported key %x", key.Secret.PublicKey().Marshal())
+			imported++
+		}
+
+		log.Infof("imported %d keys, skipped %d files", imported, skipped)
+	},
+}
+
+// readPassphrase reads the passphrase from the file or prompts it on the terminal. The trailing line break of the
+// file is ignored.
+func readPassphrase(file string, prompt string, confirm bool) (string, error) {
+	if file != "" {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", ErrorNoPassphrase
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	pass, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat the passphrase: ")
+		repeat, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(repeat) != string(pass) {
+			return "", ErrorPassphraseMismatch
+		}
+	}
+
+	return string(pass), nil
+}
+
+// openKeystore unlocks the node keystore, a new keystore is created if it doesn't exist.
+func openKeystore() (keystore.Keystore, error) {
+	ks := keystore.NewKeystore()
+
+	if _, err := os.Stat(path.Join(config.GlobalFlags.DataPath, "keystore.db")); os.IsNotExist(err) {
+		pass, err := readPassphrase(KeystorePassFile, "Passphrase to encrypt the new keystore: ", true)
+		if err != nil {
+			return nil, err
+		}
+		return ks, ks.CreateKeystore(pass)
+	}
+
+	pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+	if err != nil {
+		return nil, err
+	}
+	return ks, ks.OpenKeystore(pass)
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&KeystorePassFile, "keystore_pass_file", "", "File with the keystore passphrase, prompted when not specified.")
+
+	keystoreChangePassCmd.Flags().StringVar(&newPassFile, "new_pass_file", "", "File with the new keystore passphrase, prompted when not specified.")
+
+	keystoreExportCmd.Flags().StringVar(&jsonPassFile, "json_pass_file", "", "File with the JSON keystore passphrase, prompted when not specified.")
+	keystoreImportCmd.Flags().StringVar(&jsonPassFile, "json_pass_file", "", "File with the JSON keystores passphrase, prompted when not specified.")
+
+	keystoreCmd.AddCommand(keystoreEncryptCmd, keystoreChangePassCmd, keystoreExportCmd, keystoreImportCmd)
+
+	rootCmd.AddCommand(keystoreCmd)
+}
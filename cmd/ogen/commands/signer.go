@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/spf13/cobra"
+)
+
+var (
+	signerListen      string
+	signerTokenFile   string
+	signerGenesisHash string
+)
+
+var signerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Runs a remote signer for the keystore keys",
+	Long:  `Serves the keys of the encrypted keystore with the remote signer protocol so validator nodes can sign their duties without holding the keys. Every signature is checked against the signer slashing protection database`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		config.InterruptListener()
+
+		// The signer doesn't load the chain, the genesis hash signed on the domains is the one logged by the node.
+		genesisHash, err := hex.DecodeString(signerGenesisHash)
+		if err != nil || len(genesisHash) != 32 {
+			log.Fatal("a valid --signer_genesis_hash is required")
+		}
+		copy(config.GlobalParams.NetParams.GenesisHash[:], genesisHash)
+
+		token, err := signer.LoadToken(signerTokenFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if token == "" {
+			log.Warn("the remote signer is running without a token, every client able to connect can request signatures")
+		}
+
+		pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ks := keystore.NewKeystore()
+		if err := ks.OpenKeystore(pass); err != nil {
+			log.Fatal(err)
+		}
+		defer ks.Close()
+
+		protection, err := slashingprotection.Open(config.GlobalFlags.DataPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer protection.Close()
+
+		srv := &http.Server{
+			Addr:         signerListen,
+			Handler:      signer.NewServer(log, ks, protection, config.GlobalParams.NetParams, token).Handler(),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			log.Infof("remote signer listening on %s", signerListen)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatal(err)
+			}
+		}()
+
+		<-config.GlobalParams.Context.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	},
+}
+
+func init() {
+	signerCmd.Flags().StringVar(&signerListen, "signer_listen", "127.0.0.1:9092", "Address to listen for signing requests.")
+	signerCmd.Flags().StringVar(&signerTokenFile, "signer_token_file", "", "File with the token the clients must send to request signatures.")
+	signerCmd.Flags().StringVar(&signerGenesisHash, "signer_genesis_hash", "", "Genesis hash of the chain to sign for, as logged by the node on startup.")
+
+	rootCmd.AddCommand(signerCmd)
+}
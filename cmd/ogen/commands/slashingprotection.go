@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/spf13/cobra"
+)
+
+var exportPubKeys []string
+
+var slashingProtectionCmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Exports and imports the validators signing history",
+	Long:  `Exports and imports the signing history of the validator keys to move them safely between nodes`,
+}
+
+var slashingProtectionExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Exports the signing history of the keystore keys",
+	Long:  `Exports the signing history of all the keystore keys, or the keys specified with --pubkeys, to an interchange file`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		var pubs [][48]byte
+		if len(exportPubKeys) > 0 {
+			for _, s := range exportPubKeys {
+				b, err := hex.DecodeString(s)
+				if err != nil || len(b) != 48 {
+					log.Fatalf("invalid public key %s", s)
+				}
+				var pub [48]byte
+				copy(pub[:], b)
+				pubs = append(pubs, pub)
+			}
+		} else {
+			pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ks := keystore.NewKeystore()
+			if err := ks.OpenKeystore(pass); err != nil {
+				log.Fatal(err)
+			}
+			keys, err := ks.GetValidatorKeys()
+			_ = ks.Close()
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, k := range keys {
+				var pub [48]byte
+				copy(pub[:], k.Secret.PublicKey().Marshal())
+				pubs = append(pubs, pub)
+			}
+			if len(pubs) == 0 {
+				log.Fatal("the keystore has no keys to export")
+			}
+		}
+
+		genesisHash, err := loadGenesisHash()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		db, err := slashingprotection.Open(config.GlobalFlags.DataPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		interchange, err := db.Export(genesisHash, pubs)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		b, err := json.MarshalIndent(interchange, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(args[0], b, 0600); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Infof("exported the signing history of %d keys to %s", len(interchange.Data), args[0])
+	},
+}
+
+var slashingProtectionImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Imports the signing history from an interchange file",
+	Long:  `Imports the signing history from an interchange file merging it with the local history, the history already known is always kept`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		b, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		interchange := new(slashingprotection.Interchange)
+		if err := json.Unmarshal(b, interchange); err != nil {
+			log.Fatal(err)
+		}
+
+		genesisHash, err := loadGenesisHash()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		db, err := slashingprotection.Open(config.GlobalFlags.DataPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		if err := db.Import(genesisHash, interchange); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Infof("imported the signing history of %d keys from %s", len(interchange.Data), args[0])
+	},
+}
+
+// loadGenesisHash returns the genesis hash of the chain stored in the data folder. It is the hash the signing
+// history is bound to, so the node must have been started at least once.
+func loadGenesisHash() (chainhash.Hash, error) {
+	db, err := blockdb.NewLevelDB()
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	defer db.Close()
+
+	genesisTime, err := db.GetGenesisTime()
+	if err != nil {
+		return chainhash.Hash{}, fmt.Errorf("unable to read the genesis time of the chain, start the node at least once: %s", err)
+	}
+
+	return chain.ComputeGenesisHash(genesisTime)
+}
+
+func init() {
+	slashingProtectionExportCmd.Flags().StringSliceVar(&exportPubKeys, "pubkeys", nil, "hex encoded public keys to export, defaults to all the keystore keys")
+
+	slashingProtectionCmd.AddCommand(slashingProtectionExportCmd, slashingProtectionImportCmd)
+
+	rootCmd.AddCommand(slashingProtectionCmd)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/internal/validator"
+	"github.com/spf13/cobra"
+)
+
+var validatorNodes []string
+
+var validatorCmd = &cobra.Command{
+	Use:   "validator",
+	Short: "Runs the validator duties against the RPC API of one or more nodes",
+	Long:  `Proposes and votes with the keystore keys, or the keys of a remote signer, using the validator RPC API of the nodes. The nodes are used in order, when a node is unreachable or not synced the next one is used. The keys never need to be on the nodes connected to the p2p network. The nodes must serve the validator namespace, listed with --http_modules or --ws_modules`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		if len(validatorNodes) == 0 {
+			log.Fatal("at least one node must be specified with --validator_nodes")
+		}
+
+		config.InterruptListener()
+
+		var sig signer.Signer
+		if config.GlobalFlags.RemoteSigner != "" {
+			token, err := signer.LoadToken(config.GlobalFlags.RemoteSignerTokenFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Infof("using remote signer at %s", config.GlobalFlags.RemoteSigner)
+			sig = signer.NewRemoteSigner(config.GlobalFlags.RemoteSigner, token, config.GlobalParams.NetParams)
+		} else {
+			pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			ks := keystore.NewKeystore()
+			if err := ks.OpenKeystore(pass); err != nil {
+				log.Fatal(err)
+			}
+			defer ks.Close()
+
+			sig = signer.NewLocalSigner(ks, config.GlobalParams.NetParams)
+		}
+
+		protection, err := slashingprotection.Open(config.GlobalFlags.DataPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer protection.Close()
+
+		client := validator.NewClient(log, validatorNodes)
+		defer client.Close()
+
+		v := validator.NewValidator(client, sig, protection)
+		if err := v.Start(); err != nil {
+			log.Fatal(err)
+		}
+
+		<-config.GlobalParams.Context.Done()
+
+		v.Stop()
+	},
+}
+
+func init() {
+	validatorCmd.PersistentFlags().StringSliceVar(&validatorNodes, "validator_nodes", []string{}, "RPC URLs of the nodes to use, in order of preference.")
+	validatorCmd.PersistentFlags().StringVar(&NetName, "network", "testnet", "String of the network to validate.")
+	validatorCmd.Flags().StringVar(&RemoteSigner, "remote_signer", "", "URL of a remote signer to sign the validator duties instead of the local keystore.")
+	validatorCmd.Flags().StringVar(&RemoteSignerTokenFile, "remote_signer_token_file", "", "File with the token to authenticate with the remote signer.")
+	validatorCmd.Flags().Uint64Var(&DoppelgangerEpochs, "doppelganger_epochs", 2, "Epochs to look for the validator keys voting from another node before starting the duties, 0 disables the check.")
+
+	rootCmd.AddCommand(validatorCmd)
+}
@@ -0,0 +1,469 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/duties"
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/internal/validator"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/spf13/cobra"
+)
+
+var (
+	withdrawKeyIndex  uint32
+	withdrawKeystore  string
+	operationsOutput  string
+	depositNewKeys    uint64
+	partialExitAmount uint64
+
+	offlineGenesisTime int64
+	offlineGenesisHash string
+)
+
+// validatorOperations are the signed deposits, exits and partial exits written to a file for offline submission. The
+// operations are hex encoded as on the validator RPC API.
+type validatorOperations struct {
+	Network      string   `json:"network"`
+	GenesisHash  string   `json:"genesis_hash"`
+	Slot         uint64   `json:"slot"`
+	Deposits     []string `json:"deposits,omitempty"`
+	Exits        []string `json:"exits,omitempty"`
+	PartialExits []string `json:"partial_exits,omitempty"`
+}
+
+var validatorDepositCmd = &cobra.Command{
+	Use:   "deposit [pubkey...]",
+	Short: "Deposits the keystore keys to register them as validators",
+	Long:  `Signs a deposit for each keystore key, funded by the withdraw key which also receives the validator balance when it exits. The deposits are validated against the tip state of the nodes and submitted, or written to the --output file. Operations written to a file can be signed offline without --validator_nodes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+		netParams := config.GlobalParams.NetParams
+
+		if len(args) == 0 && depositNewKeys == 0 {
+			log.Fatal("specify the public keys to deposit or the amount of --new_keys to generate")
+		}
+
+		ks := openOperationsKeystore()
+		defer ks.Close()
+
+		withdrawKey := loadWithdrawKey(ks)
+
+		client, ops := prepareOperations()
+		if client != nil {
+			defer client.Close()
+		}
+
+		keys := make([]common.SecretKey, 0, len(args))
+		for _, arg := range args {
+			pub, err := validator.DecodePubKey(arg)
+			if err != nil {
+				log.Fatalf("invalid public key %s: %s", arg, err)
+			}
+			key, ok := ks.GetValidatorKey(pub)
+			if !ok {
+				log.Fatalf("key %s is not on the keystore", arg)
+			}
+			keys = append(keys, key.Secret)
+		}
+
+		// The new keys are only saved once their deposits are signed and valid.
+		newKeys, err := ks.DeriveNewValidatorKeys(depositNewKeys)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, key := range newKeys {
+			keys = append(keys, key.Secret)
+		}
+
+		for _, key := range keys {
+			deposit, err := validator.NewDeposit(netParams, key, withdrawKey, ops.Slot)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if client != nil {
+				if err := client.CheckDeposit(deposit); err != nil {
+					log.Fatalf("deposit of validator %x is not valid: %s", deposit.Data.PublicKey, err)
+				}
+			}
+			raw, err := validator.Encode(deposit)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ops.Deposits = append(ops.Deposits, raw)
+		}
+
+		if len(newKeys) > 0 {
+			if err := ks.SaveValidatorKeys(newKeys); err != nil {
+				log.Fatal(err)
+			}
+			for _, key := range newKeys {
+				log.Infof("generated key %x", key.Secret.PublicKey().Marshal())
+			}
+		}
+
+		submitOrWriteOperations(client, ops)
+	},
+}
+
+var validatorExitCmd = &cobra.Command{
+	Use:   "exit <pubkey...>",
+	Short: "Exits validators from the registry",
+	Long:  `Signs an exit for each validator with the withdraw key. The exits are validated against the tip state of the nodes and submitted, or written to the --output file. Operations written to a file can be signed offline without --validator_nodes`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+		netParams := config.GlobalParams.NetParams
+
+		pubs := parseValidatorPubKeys(args)
+
+		withdrawKey := openWithdrawKey()
+
+		client, ops := prepareOperations()
+		if client != nil {
+			defer client.Close()
+		}
+
+		for _, pub := range pubs {
+			exit := validator.NewExit(netParams, pub, withdrawKey, ops.Slot)
+			if client != nil {
+				if err := client.CheckExit(exit); err != nil {
+					log.Fatalf("exit of validator %x is not valid: %s", pub, err)
+				}
+			}
+			raw, err := validator.Encode(exit)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ops.Exits = append(ops.Exits, raw)
+		}
+
+		submitOrWriteOperations(client, ops)
+	},
+}
+
+var validatorPartialExitCmd = &cobra.Command{
+	Use:   "partial-exit <pubkey...>",
+	Short: "Withdraws part of the balance of validators",
+	Long:  `Signs a partial exit of --amount coins for each validator with the withdraw key, the validators keep on the registry. The partial exits are validated against the tip state of the nodes and submitted, or written to the --output file. Operations written to a file can be signed offline without --validator_nodes`,
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+		netParams := config.GlobalParams.NetParams
+
+		if partialExitAmount == 0 {
+			log.Fatal("specify the --amount of coins to withdraw")
+		}
+
+		pubs := parseValidatorPubKeys(args)
+
+		withdrawKey := openWithdrawKey()
+
+		client, ops := prepareOperations()
+		if client != nil {
+			defer client.Close()
+		}
+
+		for _, pub := range pubs {
+			partialExit := validator.NewPartialExit(netParams, pub, withdrawKey, partialExitAmount*netParams.UnitsPerCoin, ops.Slot)
+			if client != nil {
+				if err := client.CheckPartialExit(partialExit); err != nil {
+					log.Fatalf("partial exit of validator %x is not valid: %s", pub, err)
+				}
+			}
+			raw, err := validator.Encode(partialExit)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ops.PartialExits = append(ops.PartialExits, raw)
+		}
+
+		submitOrWriteOperations(client, ops)
+	},
+}
+
+var validatorSubmitCmd = &cobra.Command{
+	Use:   "submit <file>",
+	Short: "Submits the operations of a file written by the deposit, exit and partial-exit commands",
+	Long:  `Submits the signed deposits, exits and partial exits of a file to the nodes. The nodes must follow the chain the operations were signed for`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		b, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ops := new(validatorOperations)
+		if err := json.Unmarshal(b, ops); err != nil {
+			log.Fatalf("invalid operations file: %s", err)
+		}
+
+		client, node := connectValidatorNodes()
+		defer client.Close()
+
+		if ops.Network != node.Network || ops.GenesisHash != node.GenesisHash {
+			log.Fatalf("operations are signed for network %s with genesis hash %s, the nodes follow %s with genesis hash %s", ops.Network, ops.GenesisHash, node.Network, node.GenesisHash)
+		}
+
+		if err := submitOperations(client, ops); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// openWithdrawKey loads the withdraw key from the JSON keystore file or derives it from the keystore mnemonic. The
+// keystore is only opened when the key is derived from it.
+func openWithdrawKey() common.SecretKey {
+	if withdrawKeystore != "" {
+		return loadWithdrawKey(nil)
+	}
+
+	ks := openOperationsKeystore()
+	defer ks.Close()
+
+	return loadWithdrawKey(ks)
+}
+
+// openOperationsKeystore opens the keystore with the passphrase.
+func openOperationsKeystore() keystore.Keystore {
+	log := config.GlobalParams.Logger
+
+	pass, err := readPassphrase(KeystorePassFile, "Keystore passphrase: ", false)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ks := keystore.NewKeystore()
+	if err := ks.OpenKeystore(pass); err != nil {
+		log.Fatal(err)
+	}
+	return ks
+}
+
+// loadWithdrawKey loads the withdraw key from the JSON keystore file or derives it from the mnemonic of the open
+// keystore.
+func loadWithdrawKey(ks keystore.Keystore) common.SecretKey {
+	log := config.GlobalParams.Logger
+
+	var withdrawKey common.SecretKey
+	if withdrawKeystore != "" {
+		b, err := ioutil.ReadFile(withdrawKeystore)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		jks, err := keystore.UnmarshalJSONKeystore(b)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		jsonPass, err := readPassphrase(jsonPassFile, "Withdraw key JSON keystore passphrase: ", false)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		key, err := keystore.DecryptJSONKeystore(jks, jsonPass)
+		if err != nil {
+			log.Fatal(err)
+		}
+		withdrawKey = key.Secret
+	} else {
+		var err error
+		withdrawKey, err = ks.GetWithdrawKey(withdrawKeyIndex)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	withdrawAddress, err := withdrawKey.PublicKey().Hash()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Infof("using withdraw key %x with address %x", withdrawKey.PublicKey().Marshal(), withdrawAddress)
+
+	return withdrawKey
+}
+
+// prepareOperations returns the operations to sign on top of the tip of the nodes. Without nodes the operations
+// written to the --output file are signed offline for the current slot of the local chain or of the chain specified
+// with --genesis_time and --genesis_hash, the client is nil and they are validated when submitted.
+func prepareOperations() (*validator.Client, *validatorOperations) {
+	log := config.GlobalParams.Logger
+	netParams := config.GlobalParams.NetParams
+
+	if len(validatorNodes) > 0 || operationsOutput == "" {
+		return connectValidatorNodes()
+	}
+
+	genesisTime, genesisHash, err := loadOfflineGenesis()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if offlineGenesisHash != "" && offlineGenesisHash != genesisHash.String() {
+		log.Fatalf("the genesis hash %s doesn't match the chain genesis hash %s", offlineGenesisHash, genesisHash)
+	}
+	netParams.GenesisHash = genesisHash
+
+	ops := &validatorOperations{
+		Network:     netParams.Name,
+		GenesisHash: genesisHash.String(),
+		Slot:        duties.NewClock(genesisTime, netParams).CurrentSlot(),
+	}
+
+	log.Warnf("signing offline for network %s with genesis hash %s at slot %d, the operations are validated when submitted", ops.Network, ops.GenesisHash, ops.Slot)
+
+	return nil, ops
+}
+
+// loadOfflineGenesis returns the genesis time and hash of the chain stored in the data folder, or of the chain
+// started at --genesis_time. Without a local chain the genesis time and hash must be specified, the initialization
+// parameters don't have the genesis time of chains started now or imported from an archive.
+func loadOfflineGenesis() (time.Time, chainhash.Hash, error) {
+	if offlineGenesisTime != 0 {
+		if offlineGenesisHash == "" {
+			return time.Time{}, chainhash.Hash{}, errors.New("specify the --genesis_hash of the chain started at --genesis_time")
+		}
+		genesisTime := time.Unix(offlineGenesisTime, 0)
+		genesisHash, err := chain.ComputeGenesisHash(genesisTime)
+		return genesisTime, genesisHash, err
+	}
+
+	db, err := blockdb.NewLevelDB()
+	if err != nil {
+		return time.Time{}, chainhash.Hash{}, err
+	}
+	defer db.Close()
+
+	genesisTime, err := db.GetGenesisTime()
+	if err != nil {
+		return time.Time{}, chainhash.Hash{}, errors.New("there is no local chain to sign offline, specify its --genesis_time and --genesis_hash")
+	}
+
+	genesisHash, err := chain.LoadGenesisHash(db)
+	return genesisTime, genesisHash, err
+}
+
+// connectValidatorNodes connects to the nodes and returns the operations to sign on top of their tip.
+func connectValidatorNodes() (*validator.Client, *validatorOperations) {
+	log := config.GlobalParams.Logger
+
+	if len(validatorNodes) == 0 {
+		log.Fatal("at least one node must be specified with --validator_nodes")
+	}
+
+	client := validator.NewClient(log, validatorNodes)
+
+	info, err := client.UseChain(config.GlobalParams.NetParams)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !info.Synced {
+		log.Fatal(validator.ErrorNotSynced)
+	}
+
+	return client, &validatorOperations{
+		Network:     info.Network,
+		GenesisHash: info.GenesisHash,
+		Slot:        info.TipSlot,
+	}
+}
+
+// submitOrWriteOperations writes the operations to the --output file or submits them to the nodes.
+func submitOrWriteOperations(client *validator.Client, ops *validatorOperations) {
+	log := config.GlobalParams.Logger
+
+	if operationsOutput == "" {
+		if err := submitOperations(client, ops); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	b, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(operationsOutput, b, 0600); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Infof("wrote %d deposits, %d exits and %d partial exits to %s", len(ops.Deposits), len(ops.Exits), len(ops.PartialExits), operationsOutput)
+}
+
+func submitOperations(client *validator.Client, ops *validatorOperations) error {
+	log := config.GlobalParams.Logger
+
+	for _, raw := range ops.Deposits {
+		deposit := new(primitives.Deposit)
+		if err := validator.Decode(raw, deposit); err != nil {
+			return fmt.Errorf("invalid deposit: %s", err)
+		}
+		if err := client.SubmitDeposit(deposit); err != nil {
+			return fmt.Errorf("unable to submit deposit of validator %x: %s", deposit.Data.PublicKey, err)
+		}
+		log.Infof("submitted deposit of validator %x", deposit.Data.PublicKey)
+	}
+
+	for _, raw := range ops.Exits {
+		exit := new(primitives.Exit)
+		if err := validator.Decode(raw, exit); err != nil {
+			return fmt.Errorf("invalid exit: %s", err)
+		}
+		if err := client.SubmitExit(exit); err != nil {
+			return fmt.Errorf("unable to submit exit of validator %x: %s", exit.ValidatorPubkey, err)
+		}
+		log.Infof("submitted exit of validator %x", exit.ValidatorPubkey)
+	}
+
+	for _, raw := range ops.PartialExits {
+		partialExit := new(primitives.PartialExit)
+		if err := validator.Decode(raw, partialExit); err != nil {
+			return fmt.Errorf("invalid partial exit: %s", err)
+		}
+		if err := client.SubmitPartialExit(partialExit); err != nil {
+			return fmt.Errorf("unable to submit partial exit of validator %x: %s", partialExit.ValidatorPubkey, err)
+		}
+		log.Infof("submitted partial exit of validator %x", partialExit.ValidatorPubkey)
+	}
+
+	return nil
+}
+
+func parseValidatorPubKeys(args []string) [][48]byte {
+	pubs := make([][48]byte, len(args))
+	for i, arg := range args {
+		pub, err := validator.DecodePubKey(arg)
+		if err != nil {
+			config.GlobalParams.Logger.Fatalf("invalid public key %s: %s", arg, err)
+		}
+		pubs[i] = pub
+	}
+	return pubs
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{validatorDepositCmd, validatorExitCmd, validatorPartialExitCmd} {
+		cmd.Flags().Uint32Var(&withdrawKeyIndex, "withdraw_key_index", 0, "Index of the withdraw key derived from the keystore mnemonic.")
+		cmd.Flags().StringVar(&withdrawKeystore, "withdraw_keystore", "", "JSON keystore file with the withdraw key to use instead of the one derived from the keystore mnemonic.")
+		cmd.Flags().StringVar(&jsonPassFile, "json_pass_file", "", "File with the withdraw key JSON keystore passphrase, prompted when not specified.")
+		cmd.Flags().StringVar(&operationsOutput, "output", "", "File to write the signed operations for offline submission instead of submitting them. Without --validator_nodes the operations are signed offline.")
+		cmd.Flags().Int64Var(&offlineGenesisTime, "genesis_time", 0, "Genesis time of the chain to sign offline for instead of the chain on the data folder, requires --genesis_hash.")
+		cmd.Flags().StringVar(&offlineGenesisHash, "genesis_hash", "", "Genesis hash of the chain to sign offline for, the operations are not signed if it doesn't match.")
+	}
+
+	validatorDepositCmd.Flags().Uint64Var(&depositNewKeys, "new_keys", 0, "Amount of new keys to generate on the keystore and deposit.")
+	validatorPartialExitCmd.Flags().Uint64Var(&partialExitAmount, "amount", 0, "Coins to withdraw from each validator.")
+
+	validatorCmd.AddCommand(validatorDepositCmd, validatorExitCmd, validatorPartialExitCmd, validatorSubmitCmd)
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/spf13/cobra"
+)
+
+var verifyTruncate bool
+
+var verifyDBCmd = &cobra.Command{
+	Use:   "verify-db",
+	Short: "Verifies the integrity of the chain database",
+	Long:  `Walks the chain database from genesis checking the block rows, block hashes and merkle roots, replays the state transitions and compares them with the stored finalized and justified states`,
+	Run: func(cmd *cobra.Command, args []string) {
+		log := config.GlobalParams.Logger
+
+		db, err := blockdb.NewLevelDB()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		if err := setGenesisHash(db); err != nil {
+			log.Fatal(err)
+		}
+
+		log.Info("verifying chain database...")
+
+		err = chain.VerifyDatabase(db)
+		if err == nil {
+			log.Info("chain database is consistent")
+			return
+		}
+
+		var inc *chain.DatabaseInconsistency
+		if !errors.As(err, &inc) {
+			log.Errorf("unable to verify chain database: %s", err)
+			return
+		}
+
+		log.Errorf("found %s", inc)
+
+		if !verifyTruncate {
+			if inc.CanTruncate() {
+				log.Infof("run verify-db with --truncate to truncate the chain to the last good block %s", inc.LastGood)
+			}
+			return
+		}
+
+		if err := chain.TruncateDatabase(db, inc); err != nil {
+			log.Errorf("unable to truncate chain database: %s", err)
+			return
+		}
+
+		log.Infof("chain database truncated to block %s", inc.LastGood)
+	},
+}
+
+func init() {
+	verifyDBCmd.Flags().BoolVar(&verifyTruncate, "truncate", false, "truncate the chain to the last good block when an inconsistency is found")
+
+	rootCmd.AddCommand(verifyDBCmd)
+}
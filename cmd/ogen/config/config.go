@@ -25,6 +25,21 @@ type Flags struct {
 	Dashboard     bool
 	DashboardPort string
 
+	StaticPeers  []string
+	TrustedPeers []string
+	NoDiscovery  bool
+	PSKFile      string
+	DevMode      bool
+
+	PeerMsgRate    int
+	PeerByteRate   int
+	GlobalByteRate int
+
+	RemoteSigner          string
+	RemoteSignerTokenFile string
+
+	DoppelgangerEpochs uint64
+
 	HTTPHost         string
 	HTTPPort         int
 	HTTPCors         []string
@@ -108,9 +123,9 @@ func testInitParams(netParams *params.ChainParams) *initialization.Initializatio
 	var validatorsKeys []common.SecretKey
 	var validators []*primitives.Validator
 
-	addr := testdata.PremineAddr.PublicKey().ToAccount(&netParams.AccountPrefixes)
+	addr := ""
 
-	addrHash, _ := testdata.PremineAddr.PublicKey().Hash()
+	var addrHash [20]byte
 	for i := 0; i < 100; i++ {
 		key, _ := bls.RandKey()
 		validatorsKeys = append(validatorsKeys, key)
@@ -15,6 +15,7 @@ require (
 	github.com/golang/snappy v0.0.4
 	github.com/google/go-cmp v0.5.6 // indirect
 	github.com/google/gofuzz v1.2.0
+	github.com/google/uuid v1.2.0
 	github.com/herumi/bls-eth-go-binary v0.0.0-20210520070601-31246bfa8ac4
 	github.com/ipfs/go-ds-leveldb v0.4.2
 	github.com/kilic/bls12-381 v0.1.0
@@ -39,6 +40,8 @@ require (
 	github.com/wealdtech/go-bytesutil v1.1.1
 	go.etcd.io/bbolt v1.3.6
 	golang.org/x/crypto v0.0.0-20210711020723-a769d52b0f97
+	golang.org/x/term v0.0.0-20210503060354-a79de5458b56
+	golang.org/x/text v0.3.6
 	golang.org/x/tools v0.1.4 // indirect
 	google.golang.org/grpc v1.39.0 // indirect
 	google.golang.org/protobuf v1.27.1 // indirect
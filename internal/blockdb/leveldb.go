@@ -44,6 +44,11 @@ type Database interface {
 	GetFinalizedHead() (chainhash.Hash, error)
 	SetGenesisTime(t time.Time) error
 	GetGenesisTime() (time.Time, error)
+	SetEpochReceipts(epoch uint64, receipts []*primitives.EpochReceipt) error
+	GetEpochReceipts(epoch uint64) ([]*primitives.EpochReceipt, error)
+	GetValidatorReceipts(validator uint64, fromEpoch uint64, toEpoch uint64) ([]*primitives.EpochReceipt, error)
+	SetBlockReceipts(hash chainhash.Hash, receipts []*primitives.EpochReceipt) error
+	GetBlockReceipts(hash chainhash.Hash) ([]*primitives.EpochReceipt, error)
 }
 
 var _ Database = &levelDB{}
@@ -79,6 +84,11 @@ func NewLevelDB() (Database, error) {
 		log: log,
 	}
 
+	if err := blockdb.migrate(datapath); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
 	return blockdb, nil
 }
 
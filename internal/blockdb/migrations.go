@@ -0,0 +1,176 @@
+package blockdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// SchemaVersion is the version of the database layout supported by this binary.
+const SchemaVersion = 1
+
+var schemaVersionKey = []byte("schema_version")
+
+// ErrorNewerSchema returned when the database was created by a newer version of the software.
+var ErrorNewerSchema = errors.New("the chain database was created by a newer version of ogen, please upgrade")
+
+// migration upgrades the database from the previous schema version to version. The migration and the new schema
+// version are committed in a single transaction.
+type migration struct {
+	version     uint64
+	description string
+	migrate     func(tx *leveldb.Transaction) error
+}
+
+// migrations must be sorted by version. Databases created before versioning have schema version 0.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "add schema version",
+		migrate:     func(tx *leveldb.Transaction) error { return nil },
+	},
+}
+
+func (db *levelDB) getSchemaVersion() (uint64, error) {
+	b, err := db.db.Get(schemaVersionKey, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid schema version length %d", len(b))
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (db *levelDB) setSchemaVersion(version uint64) error {
+	return db.db.Put(schemaVersionKey, encodeSchemaVersion(version), nil)
+}
+
+func encodeSchemaVersion(version uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], version)
+	return buf[:]
+}
+
+// isEmpty returns true if nothing has been stored in the database yet.
+func (db *levelDB) isEmpty() bool {
+	iter := db.db.NewIterator(nil, nil)
+	defer iter.Release()
+	return !iter.Next()
+}
+
+// migrate runs the pending migrations on the database. A copy of the database is stored
+// next to it before any migration is applied.
+func (db *levelDB) migrate(datapath string) error {
+	if db.isEmpty() {
+		return db.setSchemaVersion(SchemaVersion)
+	}
+
+	version, err := db.getSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if version > SchemaVersion {
+		return fmt.Errorf("%w: database schema version %d, supported up to %d", ErrorNewerSchema, version, SchemaVersion)
+	}
+
+	if version == SchemaVersion {
+		return nil
+	}
+
+	backup := fmt.Sprintf("%s/chain.backup-v%d", datapath, version)
+	if err := db.backup(backup); err != nil {
+		return fmt.Errorf("unable to backup database before migration: %s", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+
+		db.log.Infof("migrating chain database to version %d: %s", m.version, m.description)
+
+		if err := db.runMigration(m); err != nil {
+			return fmt.Errorf("database migration to version %d (%s) failed, a backup is available at %s: %s", m.version, m.description, backup, err)
+		}
+	}
+
+	return nil
+}
+
+// runMigration applies a migration and sets the new schema version in a single transaction, so a failed migration
+// leaves the database at the previous version.
+func (db *levelDB) runMigration(m migration) error {
+	tx, err := db.db.OpenTransaction()
+	if err != nil {
+		return err
+	}
+
+	if err := m.migrate(tx); err != nil {
+		tx.Discard()
+		return err
+	}
+
+	if err := tx.Put(schemaVersionKey, encodeSchemaVersion(m.version), nil); err != nil {
+		tx.Discard()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// backup copies all the database entries to a new database at path. An existing backup is kept
+// untouched, since it may be the only copy from before a failed migration.
+func (db *levelDB) backup(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		db.log.Warnf("database backup %s already exists, skipping backup", path)
+		return nil
+	}
+
+	db.log.Infof("backing up chain database to %s", path)
+
+	backup, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return err
+	}
+
+	snap, err := db.db.GetSnapshot()
+	if err != nil {
+		_ = backup.Close()
+		return err
+	}
+	defer snap.Release()
+
+	iter := snap.NewIterator(nil, nil)
+	defer iter.Release()
+
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		if batch.Len() >= 1000 {
+			if err := backup.Write(batch, nil); err != nil {
+				_ = backup.Close()
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		_ = backup.Close()
+		return err
+	}
+
+	if err := backup.Write(batch, nil); err != nil {
+		_ = backup.Close()
+		return err
+	}
+
+	return backup.Close()
+}
@@ -0,0 +1,112 @@
+package blockdb
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func setTestDataPath(t *testing.T) string {
+	params, flags := config.GlobalParams, config.GlobalFlags
+	t.Cleanup(func() {
+		config.GlobalParams, config.GlobalFlags = params, flags
+	})
+
+	dir := t.TempDir()
+	config.GlobalParams = &config.Params{Logger: logger.New(os.Stdout)}
+	config.GlobalFlags = &config.Flags{DataPath: dir}
+	return dir
+}
+
+// openUnversioned opens the chain database of the data path and removes its schema version to simulate a database
+// created before versioning.
+func openUnversioned(t *testing.T) {
+	db, err := NewLevelDB()
+	assert.NoError(t, err)
+	assert.NoError(t, db.(*levelDB).db.Put([]byte("test-key"), []byte("test-value"), nil))
+	assert.NoError(t, db.(*levelDB).db.Delete(schemaVersionKey, nil))
+	assert.NoError(t, db.Close())
+}
+
+func schemaVersion(t *testing.T, dir string) uint64 {
+	ldb, err := leveldb.OpenFile(dir+"/chain", nil)
+	assert.NoError(t, err)
+	defer ldb.Close()
+	db := &levelDB{db: ldb}
+	version, err := db.getSchemaVersion()
+	assert.NoError(t, err)
+	return version
+}
+
+func Test_Migrations(t *testing.T) {
+	dir := setTestDataPath(t)
+
+	db, err := NewLevelDB()
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+	assert.Equal(t, uint64(SchemaVersion), schemaVersion(t, dir))
+
+	openUnversioned(t)
+	assert.Equal(t, uint64(0), schemaVersion(t, dir))
+
+	db, err = NewLevelDB()
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+	assert.Equal(t, uint64(SchemaVersion), schemaVersion(t, dir))
+
+	backup, err := leveldb.OpenFile(dir+"/chain.backup-v0", nil)
+	assert.NoError(t, err)
+	v, err := backup.Get([]byte("test-key"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test-value"), v)
+	assert.NoError(t, backup.Close())
+
+	ldb, err := leveldb.OpenFile(dir+"/chain", nil)
+	assert.NoError(t, err)
+	assert.NoError(t, ldb.Put(schemaVersionKey, encodeSchemaVersion(SchemaVersion+1), nil))
+	assert.NoError(t, ldb.Close())
+
+	_, err = NewLevelDB()
+	assert.ErrorIs(t, err, ErrorNewerSchema)
+}
+
+func Test_MigrationFailed(t *testing.T) {
+	dir := setTestDataPath(t)
+
+	openUnversioned(t)
+
+	current := migrations
+	defer func() {
+		migrations = current
+	}()
+	migrations = []migration{
+		{
+			version:     1,
+			description: "failed migration",
+			migrate: func(tx *leveldb.Transaction) error {
+				if err := tx.Put([]byte("test-key"), []byte("migrated-value"), nil); err != nil {
+					return err
+				}
+				return errors.New("migration failed")
+			},
+		},
+	}
+
+	_, err := NewLevelDB()
+	assert.Error(t, err)
+
+	// The changes of the failed migration must be discarded along with the new schema version.
+	assert.Equal(t, uint64(0), schemaVersion(t, dir))
+
+	ldb, err := leveldb.OpenFile(dir+"/chain", nil)
+	assert.NoError(t, err)
+	v, err := ldb.Get([]byte("test-key"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test-value"), v)
+	assert.NoError(t, ldb.Close())
+}
@@ -0,0 +1,135 @@
+package blockdb
+
+import (
+	"encoding/binary"
+
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	epochReceiptsPrefix     = []byte("receipts-epoch-")
+	validatorReceiptsPrefix = []byte("receipts-validator-")
+	blockReceiptsPrefix     = []byte("receipts-block-")
+)
+
+func blockReceiptsKey(hash chainhash.Hash) []byte {
+	return append(append([]byte{}, blockReceiptsPrefix...), hash[:]...)
+}
+
+func epochReceiptsKey(epoch uint64) []byte {
+	key := make([]byte, len(epochReceiptsPrefix)+8)
+	copy(key, epochReceiptsPrefix)
+	binary.BigEndian.PutUint64(key[len(epochReceiptsPrefix):], epoch)
+	return key
+}
+
+// validatorReceiptsKey uses big endian numbers so that the keys of a validator are sorted by epoch.
+func validatorReceiptsKey(validator uint64, epoch uint64) []byte {
+	key := make([]byte, len(validatorReceiptsPrefix)+16)
+	copy(key, validatorReceiptsPrefix)
+	binary.BigEndian.PutUint64(key[len(validatorReceiptsPrefix):], validator)
+	binary.BigEndian.PutUint64(key[len(validatorReceiptsPrefix)+8:], epoch)
+	return key
+}
+
+// SetEpochReceipts stores the receipts of an epoch transition indexed by epoch and by validator.
+// Receipts previously stored for the same epoch are replaced.
+func (db *levelDB) SetEpochReceipts(epoch uint64, receipts []*primitives.EpochReceipt) error {
+	db.canClose.Add(1)
+	defer db.canClose.Done()
+
+	batch := new(leveldb.Batch)
+
+	previous, err := db.db.Get(epochReceiptsKey(epoch), nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return err
+	}
+	if err == nil {
+		old, err := primitives.UnmarshalEpochReceipts(previous)
+		if err != nil {
+			return err
+		}
+		for _, r := range old {
+			batch.Delete(validatorReceiptsKey(r.Validator, epoch))
+		}
+	}
+
+	byValidator := make(map[uint64][]*primitives.EpochReceipt)
+	for _, r := range receipts {
+		byValidator[r.Validator] = append(byValidator[r.Validator], r)
+	}
+
+	for validator, validatorReceipts := range byValidator {
+		batch.Put(validatorReceiptsKey(validator, epoch), primitives.MarshalEpochReceipts(validatorReceipts))
+	}
+
+	batch.Put(epochReceiptsKey(epoch), primitives.MarshalEpochReceipts(receipts))
+
+	return db.db.Write(batch, nil)
+}
+
+// GetEpochReceipts returns the receipts stored for an epoch.
+func (db *levelDB) GetEpochReceipts(epoch uint64) ([]*primitives.EpochReceipt, error) {
+	b, err := db.get(epochReceiptsKey(epoch))
+	if err == leveldb.ErrNotFound {
+		return []*primitives.EpochReceipt{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return primitives.UnmarshalEpochReceipts(b)
+}
+
+// GetValidatorReceipts returns the receipts of a validator from fromEpoch to toEpoch, both included.
+func (db *levelDB) GetValidatorReceipts(validator uint64, fromEpoch uint64, toEpoch uint64) ([]*primitives.EpochReceipt, error) {
+	db.canClose.Add(1)
+	defer db.canClose.Done()
+
+	receipts := make([]*primitives.EpochReceipt, 0)
+	if fromEpoch > toEpoch {
+		return receipts, nil
+	}
+
+	r := &util.Range{Start: validatorReceiptsKey(validator, fromEpoch)}
+	if toEpoch < ^uint64(0) {
+		r.Limit = validatorReceiptsKey(validator, toEpoch+1)
+	} else {
+		r.Limit = validatorReceiptsKey(validator+1, 0)
+	}
+
+	iter := db.db.NewIterator(r, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		epochReceipts, err := primitives.UnmarshalEpochReceipts(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, epochReceipts...)
+	}
+
+	return receipts, iter.Error()
+}
+
+// SetBlockReceipts stores the receipts of the epoch transitions applied to process a block.
+func (db *levelDB) SetBlockReceipts(hash chainhash.Hash, receipts []*primitives.EpochReceipt) error {
+	db.canClose.Add(1)
+	defer db.canClose.Done()
+
+	return db.db.Put(blockReceiptsKey(hash), primitives.MarshalEpochReceipts(receipts), nil)
+}
+
+// GetBlockReceipts returns the receipts of the epoch transitions applied to process a block.
+func (db *levelDB) GetBlockReceipts(hash chainhash.Hash) ([]*primitives.EpochReceipt, error) {
+	b, err := db.get(blockReceiptsKey(hash))
+	if err == leveldb.ErrNotFound {
+		return []*primitives.EpochReceipt{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return primitives.UnmarshalEpochReceipts(b)
+}
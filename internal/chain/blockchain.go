@@ -1,11 +1,14 @@
 package chain
 
 import (
+	"encoding/binary"
+	"fmt"
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
 	"sync"
 	"time"
 
 	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/state"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
 	"github.com/olympus-protocol/ogen/pkg/logger"
 	"github.com/olympus-protocol/ogen/pkg/params"
@@ -18,12 +21,17 @@ type Blockchain interface {
 	Stop()
 	State() StateService
 	GenesisTime() time.Time
+	GenesisHash() chainhash.Hash
 	GetBlock(h chainhash.Hash) (block *primitives.Block, err error)
 	GetRawBlock(h chainhash.Hash) (block []byte, err error)
 	Notify(n BlockchainNotifee)
 	Unnotify(n BlockchainNotifee)
 	UpdateChainHead(possible chainhash.Hash) error
 	ProcessBlock(block *primitives.Block) error
+	ProcessTrustedBlock(block *primitives.Block) error
+	GetEpochReceipts(epoch uint64) ([]*primitives.EpochReceipt, error)
+	GetValidatorReceipts(validator uint64, fromEpoch uint64, toEpoch uint64) ([]*primitives.EpochReceipt, error)
+	GetValidatorReceiptsSummary(validator uint64, fromEpoch uint64, toEpoch uint64) (*ReceiptsSummary, error)
 }
 
 var _ Blockchain = &blockchain{}
@@ -31,6 +39,7 @@ var _ Blockchain = &blockchain{}
 type blockchain struct {
 	log         logger.Logger
 	genesisTime time.Time
+	genesisHash chainhash.Hash
 	netParams   *params.ChainParams
 
 	// DB
@@ -60,6 +69,12 @@ func (ch *blockchain) GenesisTime() time.Time {
 	return ch.genesisTime
 }
 
+// GenesisHash returns the hash that identifies the chain. It commits to the genesis block, the genesis
+// state and the genesis time, so nodes started with different initialization parameters get a different hash.
+func (ch *blockchain) GenesisHash() chainhash.Hash {
+	return ch.genesisHash
+}
+
 // GetBlock gets a block from the database.
 func (ch *blockchain) GetBlock(h chainhash.Hash) (block *primitives.Block, err error) {
 	return ch.db.GetBlock(h)
@@ -70,8 +85,9 @@ func (ch *blockchain) GetRawBlock(h chainhash.Hash) (block []byte, err error) {
 	return ch.db.GetRawBlock(h)
 }
 
-// NewBlockchain constructs a new blockchain.
-func NewBlockchain(db blockdb.Database) (Blockchain, error) {
+// NewBlockchain constructs a new blockchain. The genesis hash is the one the network params sign with, as returned by
+// LoadGenesisHash, and must match the chain stored in the database.
+func NewBlockchain(db blockdb.Database, genesisHash chainhash.Hash) (Blockchain, error) {
 
 	log := config.GlobalParams.Logger
 	ip := config.GlobalParams.InitParams
@@ -100,6 +116,53 @@ func NewBlockchain(db blockdb.Database) (Blockchain, error) {
 		state:       s,
 		notifees:    make(map[BlockchainNotifee]struct{}),
 		genesisTime: genesisTime,
+		genesisHash: chainGenesisHash(s.Chain().Genesis().Hash, s.GenesisStateHash(), genesisTime),
+	}
+
+	if ch.genesisHash != genesisHash {
+		return nil, fmt.Errorf("chain database genesis hash %s doesn't match genesis hash %s", ch.genesisHash, genesisHash)
 	}
+
 	return ch, ch.UpdateChainHead(s.Tip().Hash)
 }
+
+// LoadGenesisHash returns the genesis hash of the chain stored in the database, or of the chain started from the
+// initialization parameters when the database is empty. The signing domains commit to it, so it must be set on the
+// network params before any signature is made or verified.
+func LoadGenesisHash(db blockdb.Database) (chainhash.Hash, error) {
+	genesisTime, err := db.GetGenesisTime()
+	if err != nil {
+		genesisTime = config.GlobalParams.InitParams.GenesisTime
+	}
+	return ComputeGenesisHash(genesisTime)
+}
+
+// ComputeGenesisHash returns the genesis hash of the chain started from the initialization parameters at the genesis
+// time without loading the chain.
+func ComputeGenesisHash(genesisTime time.Time) (chainhash.Hash, error) {
+	ip := config.GlobalParams.InitParams
+	netParams := config.GlobalParams.NetParams
+
+	genesisBlock := primitives.GetGenesisBlock()
+	genesisHash := genesisBlock.Hash()
+
+	genesisState, err := state.GetGenesisStateWithInitializationParameters(genesisHash, ip, netParams)
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	genesisStateBytes, err := genesisState.Marshal()
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+
+	return chainGenesisHash(genesisHash, chainhash.HashH(genesisStateBytes), genesisTime), nil
+}
+
+func chainGenesisHash(block chainhash.Hash, state chainhash.Hash, genesisTime time.Time) chainhash.Hash {
+	buf := make([]byte, 72)
+	copy(buf[0:32], block[:])
+	copy(buf[32:64], state[:])
+	binary.LittleEndian.PutUint64(buf[64:], uint64(genesisTime.Unix()))
+	return chainhash.HashH(buf)
+}
@@ -0,0 +1,26 @@
+package chain_test
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewBlockchainGenesisHash(t *testing.T) {
+	ch := chaintest.NewChain(t)
+
+	genesisHash, err := chain.LoadGenesisHash(ch.DB)
+	assert.NoError(t, err)
+	assert.Equal(t, ch.GenesisHash(), genesisHash)
+	assert.Equal(t, genesisHash, config.GlobalParams.NetParams.GenesisHash)
+
+	// The genesis hash of another chain is rejected and the network params are left untouched.
+	other := chainhash.HashH([]byte("other chain"))
+	_, err = chain.NewBlockchain(ch.DB, other)
+	assert.Error(t, err)
+	assert.Equal(t, genesisHash, config.GlobalParams.NetParams.GenesisHash)
+}
@@ -0,0 +1,275 @@
+// Package chaintest builds chains of a test network signed by known genesis validators.
+package chaintest
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/cmd/ogen/initialization"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/pkg/bitfield"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/olympus-protocol/ogen/test"
+)
+
+// Validators is the amount of genesis validators of the test chains.
+const Validators = 20
+
+// premineAddress is the premine address of the test chains.
+const premineAddress = "tlpub1wa3kk77yd96tzr3j93fjn4ecudjpz0p6r9vqkp"
+
+// Chain is a blockchain of a test network with the keys of its genesis validators.
+type Chain struct {
+	chain.Blockchain
+
+	DB   blockdb.Database
+	Keys map[[48]byte]common.SecretKey
+}
+
+// SetParams sets the global params of a test network on a temporary data path and returns the keys of its genesis
+// validators. The genesis is an hour in the past so the chains can be extended for 600 slots.
+func SetParams(t testing.TB) map[[48]byte]common.SecretKey {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = devNull.Close()
+	})
+
+	netParams := testdata.TestParams
+
+	ip := &initialization.InitializationParameters{
+		GenesisTime:    time.Unix(time.Now().Add(-time.Hour).Unix(), 0),
+		PremineAddress: premineAddress,
+	}
+
+	keys := make(map[[48]byte]common.SecretKey, Validators)
+	for i := 0; i < Validators; i++ {
+		key, err := bls.RandKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var pub [48]byte
+		copy(pub[:], key.PublicKey().Marshal())
+		keys[pub] = key
+
+		payee, err := key.PublicKey().Hash()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ip.InitialValidators = append(ip.InitialValidators, initialization.ValidatorInitialization{
+			PubKey:       hex.EncodeToString(pub[:]),
+			PayeeAddress: "0x" + hex.EncodeToString(payee[:]),
+		})
+	}
+
+	config.GlobalParams = &config.Params{
+		Logger:     logger.New(devNull),
+		NetParams:  &netParams,
+		InitParams: ip,
+		Context:    context.Background(),
+	}
+	config.GlobalFlags = &config.Flags{
+		DataPath: t.TempDir(),
+	}
+
+	return keys
+}
+
+// NewChain sets the params of a test network and opens its chain. The database is closed when the test finishes.
+func NewChain(t testing.TB) *Chain {
+	keys := SetParams(t)
+
+	db, err := blockdb.NewLevelDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return Open(t, db, keys)
+}
+
+// Open loads the chain of a test network database.
+func Open(t testing.TB, db blockdb.Database, keys map[[48]byte]common.SecretKey) *Chain {
+	genesisHash, err := chain.LoadGenesisHash(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.GlobalParams.NetParams.GenesisHash = genesisHash
+
+	ch, err := chain.NewBlockchain(db, genesisHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Chain{
+		Blockchain: ch,
+		DB:         db,
+		Keys:       keys,
+	}
+}
+
+// Block returns the block of a slot on top of a parent signed by its proposer. The block includes the votes of the
+// committee of the previous slot.
+func (c *Chain) Block(t testing.TB, parent chainhash.Hash, slot uint64) *primitives.Block {
+	netParams := config.GlobalParams.NetParams
+
+	view, err := c.State().GetSubView(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockState, _, err := c.State().GetStateForHashAtSlot(parent, slot, &view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slotIndex := (slot + netParams.EpochLength - 1) % netParams.EpochLength
+	proposer := blockState.GetValidatorRegistry()[blockState.GetProposerQueue()[slotIndex]]
+
+	block := &primitives.Block{
+		Header: &primitives.BlockHeader{
+			PrevBlockHash: parent,
+			Timestamp:     uint64(time.Now().Unix()),
+			Slot:          slot,
+			FeeAddress:    proposer.PayeeAddress,
+		},
+	}
+
+	if slot > 1 {
+		block.Votes = []*primitives.MultiValidatorVote{c.Vote(t, parent, slot-1)}
+	}
+
+	block.Header.VoteMerkleRoot = block.VotesMerkleRoot()
+	block.Header.DepositMerkleRoot = block.DepositMerkleRoot()
+	block.Header.ExitMerkleRoot = block.ExitMerkleRoot()
+	block.Header.PartialExitMerkleRoot = block.PartialExitsMerkleRoot()
+	block.Header.TxsMerkleRoot = block.TxsMerkleRoot()
+	block.Header.VoteSlashingMerkleRoot = block.VoteSlashingRoot()
+	block.Header.ProposerSlashingMerkleRoot = block.ProposerSlashingsRoot()
+	block.Header.RANDAOSlashingMerkleRoot = block.RANDAOSlashingsRoot()
+
+	c.Sign(t, block)
+
+	return block
+}
+
+// Sign signs a block with the key of the proposer of its slot.
+func (c *Chain) Sign(t testing.TB, block *primitives.Block) {
+	netParams := config.GlobalParams.NetParams
+
+	view, err := c.State().GetSubView(block.Header.PrevBlockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blockState, _, err := c.State().GetStateForHashAtSlot(block.Header.PrevBlockHash, block.Header.Slot, &view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slotIndex := (block.Header.Slot + netParams.EpochLength - 1) % netParams.EpochLength
+	key := c.Keys[blockState.GetValidatorRegistry()[blockState.GetProposerQueue()[slotIndex]].PubKey]
+
+	msg := block.Header.SigningMessage(netParams)
+	copy(block.Signature[:], key.Sign(msg[:]).Marshal())
+
+	randao := primitives.RANDAOMessage(netParams, block.Header.Slot)
+	copy(block.RandaoSignature[:], key.Sign(randao[:]).Marshal())
+}
+
+// Vote returns the vote of the whole committee of a slot on top of the block.
+func (c *Chain) Vote(t testing.TB, tip chainhash.Hash, slot uint64) *primitives.MultiValidatorVote {
+	netParams := config.GlobalParams.NetParams
+
+	view, err := c.State().GetSubView(tip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	voteState, _, err := c.State().GetStateForHashAtSlot(tip, slot, &view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	beaconBlock, err := view.GetHashBySlot(slot - 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toEpoch := (slot - 1) / netParams.EpochLength
+
+	data := &primitives.VoteData{
+		Slot:            slot,
+		FromEpoch:       voteState.GetJustifiedEpoch(),
+		FromHash:        voteState.GetJustifiedEpochHash(),
+		ToEpoch:         toEpoch,
+		ToHash:          voteState.GetRecentBlockHash(toEpoch*netParams.EpochLength - 1),
+		BeaconBlockHash: beaconBlock,
+	}
+
+	committee, err := voteState.GetVoteCommittee(slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := data.SigningMessage(netParams)
+	registry := voteState.GetValidatorRegistry()
+
+	participation := bitfield.NewBitlist(uint64(len(committee)))
+	signatures := make([]common.Signature, len(committee))
+	for i, index := range committee {
+		signatures[i] = c.Keys[registry[index].PubKey].Sign(msg[:])
+		participation.Set(uint(i))
+	}
+
+	vote := &primitives.MultiValidatorVote{
+		Data:                  data,
+		ParticipationBitfield: participation,
+	}
+	copy(vote.Sig[:], bls.AggregateSignatures(signatures).Marshal())
+
+	return vote
+}
+
+// Extend processes a block on top of the tip for each slot up to slot and returns them.
+func (c *Chain) Extend(t testing.TB, slot uint64) []*primitives.Block {
+	var blocks []*primitives.Block
+	for s := c.State().Tip().Slot + 1; s <= slot; s++ {
+		block := c.Block(t, c.State().Tip().Hash, s)
+		if err := c.ProcessBlock(block); err != nil {
+			t.Fatalf("unable to process block at slot %d: %s", s, err)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// Empty opens a chain of the same test network with only the genesis block on a new data path.
+func (c *Chain) Empty(t testing.TB) *Chain {
+	config.GlobalFlags.DataPath = t.TempDir()
+
+	db, err := blockdb.NewLevelDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return Open(t, db, c.Keys)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/olympus-protocol/ogen/internal/chainindex"
+	"github.com/olympus-protocol/ogen/internal/state"
 	"github.com/olympus-protocol/ogen/pkg/bls"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
 	"github.com/olympus-protocol/ogen/pkg/primitives"
@@ -56,6 +57,8 @@ func (ch *blockchain) UpdateChainHead(possible chainhash.Hash) error {
 		children := head.Children()
 		if len(children) == 0 {
 			if head.Hash.IsEqual(&possible) {
+				oldTip := ch.state.Tip()
+
 				ch.state.Chain().SetTip(head)
 
 				ch.log.Infof("setting head to %s", head.Hash)
@@ -65,6 +68,10 @@ func (ch *blockchain) UpdateChainHead(possible chainhash.Hash) error {
 					return err
 				}
 
+				if err := ch.updateReceipts(oldTip, head); err != nil {
+					return err
+				}
+
 			}
 			return nil
 		}
@@ -100,6 +107,16 @@ func (ch *blockchain) getLatestAttestationTarget(validator uint64) (row *chainin
 
 // ProcessBlock processes an incoming block from a peer or the miner.
 func (ch *blockchain) ProcessBlock(block *primitives.Block) error {
+	return ch.processBlock(block, true)
+}
+
+// ProcessTrustedBlock processes a block from a trusted source, like a chain archive, skipping the
+// proposer and RANDAO signature checks. The signatures of the block contents are still verified.
+func (ch *blockchain) ProcessTrustedBlock(block *primitives.Block) error {
+	return ch.processBlock(block, false)
+}
+
+func (ch *blockchain) processBlock(block *primitives.Block, checkSignature bool) error {
 	// 1. first verify basic block properties
 	// b. get parent block
 	blockTime := ch.genesisTime.Add(time.Second * time.Duration(ch.netParams.SlotDuration*block.Header.Slot))
@@ -172,7 +189,14 @@ func (ch *blockchain) ProcessBlock(block *primitives.Block) error {
 
 	// 2. verify block against previous block's state
 
-	newState, receipts, err := ch.State().Add(block)
+	var newState state.State
+	var receipts []*primitives.EpochReceipt
+	var err error
+	if checkSignature {
+		newState, receipts, err = ch.State().Add(block)
+	} else {
+		newState, receipts, err = ch.State().AddTrusted(block)
+	}
 	if err != nil {
 		ch.log.Warn(err)
 		return err
@@ -232,6 +256,13 @@ func (ch *blockchain) ProcessBlock(block *primitives.Block) error {
 		ch.state.SetLatestVotesIfNeeded(validators, a)
 	}
 
+	// The receipts are stored by block, the epoch receipts are updated when the block becomes part of the main chain.
+	if len(receipts) > 0 {
+		if err := ch.db.SetBlockReceipts(blockHash, receipts); err != nil {
+			return err
+		}
+	}
+
 	if err := ch.UpdateChainHead(blockHash); err != nil {
 		return err
 	}
@@ -0,0 +1,125 @@
+package chain
+
+import (
+	"github.com/olympus-protocol/ogen/internal/chainindex"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// ReceiptsSummary contains the totals of a list of epoch receipts.
+type ReceiptsSummary struct {
+	Rewards   uint64           `json:"rewards"`
+	Penalties uint64           `json:"penalties"`
+	Net       int64            `json:"net"`
+	ByType    map[string]int64 `json:"by_type"`
+}
+
+// NewReceiptsSummary adds up the rewards and penalties of the receipts.
+func NewReceiptsSummary(receipts []*primitives.EpochReceipt) *ReceiptsSummary {
+	s := &ReceiptsSummary{
+		ByType: make(map[string]int64),
+	}
+	for _, r := range receipts {
+		if r.Amount > 0 {
+			s.Rewards += uint64(r.Amount)
+		} else {
+			s.Penalties += uint64(-r.Amount)
+		}
+		s.Net += r.Amount
+		s.ByType[r.TypeString()] += r.Amount
+	}
+	return s
+}
+
+// GetEpochReceipts returns the receipts of the epoch transition of an epoch on the main chain.
+func (ch *blockchain) GetEpochReceipts(epoch uint64) ([]*primitives.EpochReceipt, error) {
+	return ch.db.GetEpochReceipts(epoch)
+}
+
+// GetValidatorReceipts returns the rewards and penalties of a validator from fromEpoch to toEpoch, both included.
+func (ch *blockchain) GetValidatorReceipts(validator uint64, fromEpoch uint64, toEpoch uint64) ([]*primitives.EpochReceipt, error) {
+	return ch.db.GetValidatorReceipts(validator, fromEpoch, toEpoch)
+}
+
+// GetValidatorReceiptsSummary returns the reward and penalty totals of a validator from fromEpoch to toEpoch, both included.
+func (ch *blockchain) GetValidatorReceiptsSummary(validator uint64, fromEpoch uint64, toEpoch uint64) (*ReceiptsSummary, error) {
+	receipts, err := ch.db.GetValidatorReceipts(validator, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return NewReceiptsSummary(receipts), nil
+}
+
+// updateReceipts replaces the epoch receipts of the blocks of the previous tip that are no longer on the main chain
+// with the receipts of the blocks leading to the new tip.
+func (ch *blockchain) updateReceipts(oldTip *chainindex.BlockRow, newTip *chainindex.BlockRow) error {
+	var removed, added []*chainindex.BlockRow
+	if oldTip == nil {
+		added = append(added, newTip)
+	} else {
+		for oldTip.Height > newTip.Height {
+			removed = append(removed, oldTip)
+			oldTip = oldTip.Parent
+		}
+		for newTip.Height > oldTip.Height {
+			added = append(added, newTip)
+			newTip = newTip.Parent
+		}
+		for oldTip != nil && newTip != nil && !oldTip.Hash.IsEqual(&newTip.Hash) {
+			removed = append(removed, oldTip)
+			added = append(added, newTip)
+			oldTip, newTip = oldTip.Parent, newTip.Parent
+		}
+	}
+
+	removedEpochs := make(map[uint64]struct{})
+	for _, row := range removed {
+		receipts, err := ch.db.GetBlockReceipts(row.Hash)
+		if err != nil {
+			return err
+		}
+		for _, r := range receipts {
+			removedEpochs[r.Epoch] = struct{}{}
+		}
+	}
+
+	var receipts []*primitives.EpochReceipt
+	for i := len(added) - 1; i >= 0; i-- {
+		blockReceipts, err := ch.db.GetBlockReceipts(added[i].Hash)
+		if err != nil {
+			return err
+		}
+		receipts = append(receipts, blockReceipts...)
+	}
+	for _, r := range receipts {
+		delete(removedEpochs, r.Epoch)
+	}
+
+	for epoch := range removedEpochs {
+		if err := ch.db.SetEpochReceipts(epoch, []*primitives.EpochReceipt{}); err != nil {
+			return err
+		}
+	}
+
+	return ch.storeReceipts(receipts)
+}
+
+// storeReceipts stores the receipts of the epoch transitions applied to reach a new tip. Receipts of
+// an epoch already stored from a different fork are replaced.
+func (ch *blockchain) storeReceipts(receipts []*primitives.EpochReceipt) error {
+	byEpoch := make(map[uint64][]*primitives.EpochReceipt)
+	var epochs []uint64
+	for _, r := range receipts {
+		if _, ok := byEpoch[r.Epoch]; !ok {
+			epochs = append(epochs, r.Epoch)
+		}
+		byEpoch[r.Epoch] = append(byEpoch[r.Epoch], r)
+	}
+
+	for _, epoch := range epochs {
+		if err := ch.db.SetEpochReceipts(epoch, byEpoch[epoch]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package chain_test
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReceiptsReorg(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	blocks := ch.Extend(t, 12)
+
+	mainReceipts := make(map[uint64][]*primitives.EpochReceipt)
+	for _, b := range blocks[8:] {
+		receipts, err := ch.DB.GetBlockReceipts(b.Hash())
+		assert.NoError(t, err)
+		for _, r := range receipts {
+			mainReceipts[r.Epoch] = append(mainReceipts[r.Epoch], r)
+		}
+	}
+	assert.NotEmpty(t, mainReceipts)
+	for epoch, receipts := range mainReceipts {
+		stored, err := ch.GetEpochReceipts(epoch)
+		assert.NoError(t, err)
+		assert.Equal(t, receipts, stored)
+	}
+
+	// Fork the chain after the block at slot 8, the fork skips slots 9 to 12 so its epoch transition differs. The fork
+	// blocks are processed as a side chain until the justified head moves to the fork.
+	parent := blocks[7].Hash()
+	var fork []*primitives.Block
+	for slot := uint64(13); slot <= 30; slot++ {
+		b := ch.Block(t, parent, slot)
+		assert.NoError(t, ch.ProcessBlock(b))
+		fork = append(fork, b)
+		parent = b.Hash()
+	}
+	if !assert.Equal(t, parent, ch.State().Tip().Hash) {
+		t.FailNow()
+	}
+
+	forkReceipts := make(map[uint64][]*primitives.EpochReceipt)
+	for _, b := range fork {
+		receipts, err := ch.DB.GetBlockReceipts(b.Hash())
+		assert.NoError(t, err)
+		for _, r := range receipts {
+			forkReceipts[r.Epoch] = append(forkReceipts[r.Epoch], r)
+		}
+	}
+
+	for epoch := range mainReceipts {
+		assert.NotEqual(t, mainReceipts[epoch], forkReceipts[epoch])
+	}
+	for epoch, receipts := range forkReceipts {
+		stored, err := ch.GetEpochReceipts(epoch)
+		assert.NoError(t, err)
+		assert.Equal(t, receipts, stored)
+	}
+}
@@ -0,0 +1,433 @@
+package chain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/blockdb"
+	"github.com/olympus-protocol/ogen/internal/chainindex"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// DatabaseInconsistency is the first inconsistency found when verifying a block database.
+type DatabaseInconsistency struct {
+	// Hash is the hash of the block where the inconsistency was found.
+	Hash chainhash.Hash
+	// LastGood is the hash of the parent of the inconsistent block, which passed all checks.
+	LastGood chainhash.Hash
+	// Reason describes the inconsistency.
+	Reason string
+
+	lastGoodRow   *primitives.BlockNodeDisk
+	lastGoodState state.State
+}
+
+// Error returns the description of the inconsistency.
+func (d *DatabaseInconsistency) Error() string {
+	return fmt.Sprintf("inconsistency at block %s: %s", d.Hash, d.Reason)
+}
+
+// CanTruncate returns true if the database can be truncated to the last good block.
+func (d *DatabaseInconsistency) CanTruncate() bool {
+	return d.lastGoodRow != nil && d.lastGoodState != nil
+}
+
+type dbVerifier struct {
+	db    blockdb.Database
+	index *chainindex.BlockIndex
+
+	// states holds the post state of verified blocks that still have children to verify.
+	states  map[chainhash.Hash]state.State
+	pending map[chainhash.Hash]int
+
+	finalizedHash  chainhash.Hash
+	finalizedState state.State
+	justifiedHash  chainhash.Hash
+	justifiedState state.State
+}
+
+// VerifyDatabase walks the block database from genesis checking the block rows, the block hashes and merkle
+// roots and replaying the state transitions to compare them with the stored finalized and justified states.
+// It returns a *DatabaseInconsistency on the first inconsistency found. The genesis hash of the chain must be set on
+// the network params to verify the signatures.
+func VerifyDatabase(db blockdb.Database) error {
+	log := config.GlobalParams.Logger
+	ip := config.GlobalParams.InitParams
+	netParams := config.GlobalParams.NetParams
+
+	genesisBlock := primitives.GetGenesisBlock()
+	genesisHash := genesisBlock.Hash()
+
+	genesisState, err := state.GetGenesisStateWithInitializationParameters(genesisHash, ip, netParams)
+	if err != nil {
+		return err
+	}
+
+	index, err := chainindex.InitBlocksIndex(genesisBlock)
+	if err != nil {
+		return err
+	}
+
+	v := &dbVerifier{
+		db:      db,
+		index:   index,
+		states:  make(map[chainhash.Hash]state.State),
+		pending: make(map[chainhash.Hash]int),
+	}
+
+	if v.finalizedHash, err = db.GetFinalizedHead(); err != nil {
+		return err
+	}
+	if v.finalizedState, err = db.GetFinalizedState(); err != nil {
+		return err
+	}
+	if v.justifiedHash, err = db.GetJustifiedHead(); err != nil {
+		return err
+	}
+	if v.justifiedState, err = db.GetJustifiedState(); err != nil {
+		return err
+	}
+	tip, err := db.GetTip()
+	if err != nil {
+		return err
+	}
+
+	genesisRow, err := db.GetBlockRow(genesisHash)
+	if err != nil {
+		return &DatabaseInconsistency{Hash: genesisHash, Reason: fmt.Sprintf("unable to read genesis block row: %s", err)}
+	}
+	if genesisRow.Hash != genesisHash || genesisRow.Height != 0 || genesisRow.Slot != 0 {
+		return &DatabaseInconsistency{Hash: genesisHash, Reason: "genesis block row does not match the genesis block"}
+	}
+	if err := v.checkStoredStates(genesisHash, genesisState, nil); err != nil {
+		return err
+	}
+
+	v.states[genesisHash] = genesisState
+	v.pending[genesisHash] = len(genesisRow.Children)
+
+	visited := map[chainhash.Hash]struct{}{genesisHash: {}}
+	queue := make([]*primitives.BlockNodeDisk, 0, len(genesisRow.Children))
+	for _, c := range genesisRow.Children {
+		queue = append(queue, &primitives.BlockNodeDisk{Hash: c, Parent: genesisHash})
+	}
+
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+
+		parentRow, err := db.GetBlockRow(next.Parent)
+		if err != nil {
+			return fmt.Errorf("unable to read block row %s: %s", chainhash.Hash(next.Parent), err)
+		}
+
+		if _, ok := visited[next.Hash]; ok {
+			return v.inconsistency(next.Hash, parentRow, "block reachable from more than one parent")
+		}
+		visited[next.Hash] = struct{}{}
+
+		row, err := v.verifyBlock(next.Hash, parentRow)
+		if err != nil {
+			return err
+		}
+
+		if len(visited)%1000 == 0 {
+			log.Infof("verified %d blocks", len(visited))
+		}
+
+		for _, c := range row.Children {
+			queue = append(queue, &primitives.BlockNodeDisk{Hash: c, Parent: row.Hash})
+		}
+	}
+
+	if _, ok := visited[tip]; !ok {
+		return &DatabaseInconsistency{Hash: tip, Reason: "tip is not reachable from genesis"}
+	}
+	if _, ok := visited[v.finalizedHash]; !ok {
+		return &DatabaseInconsistency{Hash: v.finalizedHash, Reason: "finalized head is not reachable from genesis"}
+	}
+	if _, ok := visited[v.justifiedHash]; !ok {
+		return &DatabaseInconsistency{Hash: v.justifiedHash, Reason: "justified head is not reachable from genesis"}
+	}
+
+	log.Infof("verified %d blocks", len(visited))
+
+	return nil
+}
+
+func (v *dbVerifier) inconsistency(hash chainhash.Hash, parentRow *primitives.BlockNodeDisk, reason string) *DatabaseInconsistency {
+	inc := &DatabaseInconsistency{
+		Hash:   hash,
+		Reason: reason,
+	}
+	if parentRow != nil {
+		inc.LastGood = parentRow.Hash
+		inc.lastGoodRow = parentRow
+		inc.lastGoodState = v.states[parentRow.Hash]
+	}
+	return inc
+}
+
+func (v *dbVerifier) verifyBlock(hash chainhash.Hash, parentRow *primitives.BlockNodeDisk) (*primitives.BlockNodeDisk, error) {
+	row, err := v.db.GetBlockRow(hash)
+	if err != nil {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("unable to read block row: %s", err))
+	}
+	if row.Hash != hash {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block row is stored with hash %s", chainhash.Hash(row.Hash)))
+	}
+	if row.Parent != parentRow.Hash {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block row parent is %s but it is a child of %s", chainhash.Hash(row.Parent), chainhash.Hash(parentRow.Hash)))
+	}
+	if row.Height != parentRow.Height+1 {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block row height is %d but parent height is %d", row.Height, parentRow.Height))
+	}
+	if row.Slot <= parentRow.Slot {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block row slot is %d but parent slot is %d", row.Slot, parentRow.Slot))
+	}
+
+	block, err := v.db.GetBlock(hash)
+	if err != nil {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("unable to read block: %s", err))
+	}
+	if block.Hash() != hash {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block hashes to %s", block.Hash()))
+	}
+	if block.Header.PrevBlockHash != row.Parent {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block previous hash is %s but block row parent is %s", chainhash.Hash(block.Header.PrevBlockHash), chainhash.Hash(row.Parent)))
+	}
+	if block.Header.Slot != row.Slot {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("block slot is %d but block row slot is %d", block.Header.Slot, row.Slot))
+	}
+	if err := block.CheckMerkleRoots(); err != nil {
+		return nil, v.inconsistency(hash, parentRow, err.Error())
+	}
+
+	parentNode, ok := v.index.Get(parentRow.Hash)
+	if !ok {
+		return nil, v.inconsistency(hash, parentRow, "parent block is not in the index")
+	}
+	view := NewChainView(parentNode)
+
+	newState := v.states[parentRow.Hash].Copy()
+	if _, err := newState.ProcessSlots(block.Header.Slot, &view); err != nil {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("unable to process slots: %s", err))
+	}
+	if err := newState.ProcessBlock(block); err != nil {
+		return nil, v.inconsistency(hash, parentRow, fmt.Sprintf("unable to process block: %s", err))
+	}
+
+	if err := v.checkStoredStates(hash, newState, parentRow); err != nil {
+		return nil, err
+	}
+
+	if _, err := v.index.LoadBlockNode(row); err != nil {
+		return nil, v.inconsistency(hash, parentRow, err.Error())
+	}
+
+	if len(row.Children) > 0 {
+		v.states[hash] = newState
+		v.pending[hash] = len(row.Children)
+	}
+
+	v.pending[parentRow.Hash]--
+	if v.pending[parentRow.Hash] <= 0 {
+		delete(v.states, parentRow.Hash)
+		delete(v.pending, parentRow.Hash)
+	}
+
+	return row, nil
+}
+
+func (v *dbVerifier) checkStoredStates(hash chainhash.Hash, s state.State, parentRow *primitives.BlockNodeDisk) error {
+	if hash != v.finalizedHash && hash != v.justifiedHash {
+		return nil
+	}
+
+	replayed, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if hash == v.finalizedHash {
+		stored, err := v.finalizedState.Marshal()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(replayed, stored) {
+			return v.inconsistency(hash, parentRow, "replayed state does not match the stored finalized state")
+		}
+	}
+
+	if hash == v.justifiedHash {
+		stored, err := v.justifiedState.Marshal()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(replayed, stored) {
+			return v.inconsistency(hash, parentRow, "replayed state does not match the stored justified state")
+		}
+	}
+
+	return nil
+}
+
+// TruncateDatabase removes the inconsistent block from the block tree, so it and its descendants are no longer
+// loaded. The tip is moved to the last good block if it was a descendant of it. The finalized and justified heads
+// are moved to the finalized and justified blocks of the last good block state.
+func TruncateDatabase(db blockdb.Database, inc *DatabaseInconsistency) error {
+	if !inc.CanTruncate() {
+		return fmt.Errorf("unable to truncate database at block %s, it has no valid parent", inc.Hash)
+	}
+
+	netParams := config.GlobalParams.NetParams
+
+	lastGood := inc.lastGoodRow
+
+	children := make([][32]byte, 0, len(lastGood.Children))
+	for _, c := range lastGood.Children {
+		if c != inc.Hash {
+			children = append(children, c)
+		}
+	}
+	lastGood.Children = children
+
+	if err := db.SetBlockRow(lastGood); err != nil {
+		return err
+	}
+
+	// removed checks if a block descends from the inconsistent block by walking back its ancestors.
+	removed := func(h chainhash.Hash) bool {
+		for {
+			if h == inc.Hash {
+				return true
+			}
+			row, err := db.GetBlockRow(h)
+			if err != nil {
+				return true
+			}
+			if row.Height <= lastGood.Height {
+				return false
+			}
+			h = row.Parent
+		}
+	}
+
+	tip, err := db.GetTip()
+	if err != nil {
+		return err
+	}
+	if removed(tip) {
+		if err := db.SetTip(lastGood.Hash); err != nil {
+			return err
+		}
+	}
+
+	finalized, err := db.GetFinalizedHead()
+	if err != nil {
+		return err
+	}
+	if removed(finalized) {
+		// The finalized head is the last ancestor of the last good block at the finalized epoch of its state.
+		finalizedSlot := inc.lastGoodState.GetFinalizedEpoch() * netParams.EpochLength
+		finalizedRow := lastGood
+		for finalizedRow.Slot > finalizedSlot {
+			finalizedRow, err = db.GetBlockRow(finalizedRow.Parent)
+			if err != nil {
+				return fmt.Errorf("unable to read block row %s: %s", chainhash.Hash(finalizedRow.Parent), err)
+			}
+		}
+
+		finalizedState, err := replayState(db, finalizedRow.Hash)
+		if err != nil {
+			return err
+		}
+		if err := db.SetFinalizedHead(finalizedRow.Hash); err != nil {
+			return err
+		}
+		if err := db.SetFinalizedState(finalizedState); err != nil {
+			return err
+		}
+	}
+
+	justified, err := db.GetJustifiedHead()
+	if err != nil {
+		return err
+	}
+	if removed(justified) {
+		justifiedHash := inc.lastGoodState.GetJustifiedEpochHash()
+
+		justifiedState, err := replayState(db, justifiedHash)
+		if err != nil {
+			return err
+		}
+		if err := db.SetJustifiedHead(justifiedHash); err != nil {
+			return err
+		}
+		if err := db.SetJustifiedState(justifiedState); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replayState replays the state transitions of the stored blocks from genesis up to a block.
+func replayState(db blockdb.Database, hash chainhash.Hash) (state.State, error) {
+	genesisBlock := primitives.GetGenesisBlock()
+	genesisHash := genesisBlock.Hash()
+
+	var rows []*primitives.BlockNodeDisk
+	for h := hash; h != genesisHash; {
+		row, err := db.GetBlockRow(h)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read block row %s: %s", h, err)
+		}
+		if row.Height == 0 {
+			return nil, fmt.Errorf("block %s does not descend from genesis", hash)
+		}
+		rows = append(rows, row)
+		h = row.Parent
+	}
+
+	s, err := state.GetGenesisStateWithInitializationParameters(genesisHash, config.GlobalParams.InitParams, config.GlobalParams.NetParams)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := chainindex.InitBlocksIndex(genesisBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+
+		block, err := db.GetBlock(row.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read block %s: %s", chainhash.Hash(row.Hash), err)
+		}
+
+		parentNode, ok := index.Get(row.Parent)
+		if !ok {
+			return nil, fmt.Errorf("parent of block %s is not in the index", chainhash.Hash(row.Hash))
+		}
+		view := NewChainView(parentNode)
+
+		if _, err := s.ProcessSlots(block.Header.Slot, &view); err != nil {
+			return nil, err
+		}
+		if err := s.ProcessBlock(block); err != nil {
+			return nil, err
+		}
+
+		if _, err := index.LoadBlockNode(row); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
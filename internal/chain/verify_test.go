@@ -0,0 +1,83 @@
+package chain_test
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyDatabase(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	ch.Extend(t, 30)
+
+	assert.NoError(t, chain.VerifyDatabase(ch.DB))
+}
+
+func Test_VerifyDatabaseInconsistency(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	blocks := ch.Extend(t, 30)
+
+	corrupt := blocks[15].Hash()
+	row, err := ch.DB.GetBlockRow(corrupt)
+	assert.NoError(t, err)
+	row.Height += 5
+	assert.NoError(t, ch.DB.SetBlockRow(row))
+
+	err = chain.VerifyDatabase(ch.DB)
+	inc, ok := err.(*chain.DatabaseInconsistency)
+	assert.True(t, ok)
+	assert.Equal(t, corrupt, inc.Hash)
+	assert.Equal(t, blocks[14].Hash(), inc.LastGood)
+	assert.True(t, inc.CanTruncate())
+}
+
+func Test_TruncateDatabase(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	blocks := ch.Extend(t, 30)
+
+	finalized, err := ch.DB.GetFinalizedHead()
+	assert.NoError(t, err)
+	finalizedRow, err := ch.DB.GetBlockRow(finalized)
+	assert.NoError(t, err)
+
+	// Corrupt an ancestor of the finalized head, so the heads have to be moved before it.
+	corrupt := blocks[finalizedRow.Slot-3]
+	row, err := ch.DB.GetBlockRow(corrupt.Hash())
+	assert.NoError(t, err)
+	row.Slot = blocks[finalizedRow.Slot-4].Header.Slot
+	assert.NoError(t, ch.DB.SetBlockRow(row))
+
+	err = chain.VerifyDatabase(ch.DB)
+	inc, ok := err.(*chain.DatabaseInconsistency)
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	assert.Equal(t, corrupt.Hash(), inc.Hash)
+	lastGood := blocks[finalizedRow.Slot-4]
+	assert.Equal(t, lastGood.Hash(), inc.LastGood)
+
+	assert.NoError(t, chain.TruncateDatabase(ch.DB, inc))
+
+	tip, err := ch.DB.GetTip()
+	assert.NoError(t, err)
+	assert.Equal(t, lastGood.Hash(), tip)
+
+	// The finalized head must be an ancestor of the last good block on an epoch boundary, not the last good block.
+	newFinalized, err := ch.DB.GetFinalizedHead()
+	assert.NoError(t, err)
+	newFinalizedRow, err := ch.DB.GetBlockRow(newFinalized)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, newFinalizedRow.Slot, lastGood.Header.Slot)
+	assert.Zero(t, newFinalizedRow.Slot%config.GlobalParams.NetParams.EpochLength)
+
+	newJustified, err := ch.DB.GetJustifiedHead()
+	assert.NoError(t, err)
+	newJustifiedRow, err := ch.DB.GetBlockRow(newJustified)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, newJustifiedRow.Slot, lastGood.Header.Slot)
+
+	assert.NoError(t, chain.VerifyDatabase(ch.DB))
+}
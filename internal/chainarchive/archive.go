@@ -0,0 +1,179 @@
+package chainarchive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// Version is the current chain archive format version.
+const Version = 1
+
+// maxHeaderSize is the maximum size of an encoded header.
+const maxHeaderSize = 8 + 4 + 32 + 32 + 8 + 8
+
+var magic = []byte("ogenchain")
+
+var (
+	// ErrorInvalidArchive returned when the file is not a chain archive.
+	ErrorInvalidArchive = errors.New("file is not a chain archive")
+	// ErrorUnsupportedVersion returned when the archive was written by a newer format version.
+	ErrorUnsupportedVersion = errors.New("unsupported chain archive version")
+	// ErrorRecordTooLarge returned when a record exceeds the maximum size allowed.
+	ErrorRecordTooLarge = errors.New("chain archive record too large")
+)
+
+// Header is the first record of a chain archive and describes the chain the blocks belong to.
+type Header struct {
+	Version     uint64
+	NetworkName []byte `ssz-max:"32"`
+	GenesisHash [32]byte
+	GenesisTime uint64
+	Height      uint64
+}
+
+// Marshal encodes the data.
+func (h *Header) Marshal() ([]byte, error) {
+	return h.MarshalSSZ()
+}
+
+// Unmarshal decodes the data.
+func (h *Header) Unmarshal(b []byte) error {
+	return h.UnmarshalSSZ(b)
+}
+
+// Writer writes a chain archive as a stream of length-prefixed records.
+type Writer struct {
+	w *bufio.Writer
+}
+
+// NewWriter writes the archive magic and header to w and returns a writer for the blocks.
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	aw := &Writer{w: bufio.NewWriter(w)}
+
+	if _, err := aw.w.Write(magic); err != nil {
+		return nil, err
+	}
+
+	hb, err := header.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := aw.writeRecord(hb); err != nil {
+		return nil, err
+	}
+
+	return aw, nil
+}
+
+// WriteRawBlock writes a block already serialized with primitives.Block Marshal.
+func (w *Writer) WriteRawBlock(b []byte) error {
+	return w.writeRecord(b)
+}
+
+// WriteBlock serializes and writes a block.
+func (w *Writer) WriteBlock(b *primitives.Block) error {
+	bb, err := b.Marshal()
+	if err != nil {
+		return err
+	}
+	return w.writeRecord(bb)
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+func (w *Writer) writeRecord(b []byte) error {
+	var l [4]byte
+	binary.LittleEndian.PutUint32(l[:], uint32(len(b)))
+	if _, err := w.w.Write(l[:]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(b)
+	return err
+}
+
+// Reader reads the blocks of a chain archive.
+type Reader struct {
+	r      *bufio.Reader
+	header *Header
+}
+
+// NewReader reads the archive magic and header from r and returns a reader for the blocks.
+func NewReader(r io.Reader) (*Reader, error) {
+	ar := &Reader{r: bufio.NewReader(r)}
+
+	m := make([]byte, len(magic))
+	if _, err := io.ReadFull(ar.r, m); err != nil || !bytes.Equal(m, magic) {
+		return nil, ErrorInvalidArchive
+	}
+
+	hb, err := ar.readRecord(maxHeaderSize)
+	if err != nil {
+		return nil, ErrorInvalidArchive
+	}
+
+	header := new(Header)
+	if err := header.Unmarshal(hb); err != nil {
+		return nil, ErrorInvalidArchive
+	}
+
+	if header.Version > Version {
+		return nil, fmt.Errorf("%w: archive version %d, supported up to %d", ErrorUnsupportedVersion, header.Version, Version)
+	}
+
+	ar.header = header
+
+	return ar, nil
+}
+
+// Header returns the archive header.
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// ReadBlock reads the next block from the archive. It returns io.EOF when there are no more blocks.
+func (r *Reader) ReadBlock() (*primitives.Block, error) {
+	b, err := r.readRecord(uint64(snappy.MaxEncodedLen(primitives.MaxBlockSize)))
+	if err != nil {
+		return nil, err
+	}
+
+	block := new(primitives.Block)
+	if err := block.Unmarshal(b); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (r *Reader) readRecord(max uint64) ([]byte, error) {
+	var l [4]byte
+	if _, err := io.ReadFull(r.r, l[:]); err != nil {
+		return nil, err
+	}
+
+	size := binary.LittleEndian.Uint32(l[:])
+	if uint64(size) > max {
+		return nil, ErrorRecordTooLarge
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
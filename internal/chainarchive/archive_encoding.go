@@ -0,0 +1,131 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: 0c0fece61e74e96226532744e4c19faae7a344a3234923e3371b153d574e95e8
+package chainarchive
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// MarshalSSZ ssz marshals the Header object
+func (h *Header) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(h)
+}
+
+// MarshalSSZTo ssz marshals the Header object to a target array
+func (h *Header) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(60)
+
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, h.Version)
+
+	// Offset (1) 'NetworkName'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(h.NetworkName)
+
+	// Field (2) 'GenesisHash'
+	dst = append(dst, h.GenesisHash[:]...)
+
+	// Field (3) 'GenesisTime'
+	dst = ssz.MarshalUint64(dst, h.GenesisTime)
+
+	// Field (4) 'Height'
+	dst = ssz.MarshalUint64(dst, h.Height)
+
+	// Field (1) 'NetworkName'
+	if len(h.NetworkName) > 32 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	dst = append(dst, h.NetworkName...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Header object
+func (h *Header) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 60 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1 uint64
+
+	// Field (0) 'Version'
+	h.Version = ssz.UnmarshallUint64(buf[0:8])
+
+	// Offset (1) 'NetworkName'
+	if o1 = ssz.ReadOffset(buf[8:12]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 60 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'GenesisHash'
+	copy(h.GenesisHash[:], buf[12:44])
+
+	// Field (3) 'GenesisTime'
+	h.GenesisTime = ssz.UnmarshallUint64(buf[44:52])
+
+	// Field (4) 'Height'
+	h.Height = ssz.UnmarshallUint64(buf[52:60])
+
+	// Field (1) 'NetworkName'
+	{
+		buf = tail[o1:]
+		if len(buf) > 32 {
+			return ssz.ErrBytesLength
+		}
+		if cap(h.NetworkName) == 0 {
+			h.NetworkName = make([]byte, 0, len(buf))
+		}
+		h.NetworkName = append(h.NetworkName, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the Header object
+func (h *Header) SizeSSZ() (size int) {
+	size = 60
+
+	// Field (1) 'NetworkName'
+	size += len(h.NetworkName)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the Header object
+func (h *Header) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(h)
+}
+
+// HashTreeRootWith ssz hashes the Header object with a hasher
+func (h *Header) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Version'
+	hh.PutUint64(h.Version)
+
+	// Field (1) 'NetworkName'
+	if len(h.NetworkName) > 32 {
+		err = ssz.ErrBytesLength
+		return
+	}
+	hh.PutBytes(h.NetworkName)
+
+	// Field (2) 'GenesisHash'
+	hh.PutBytes(h.GenesisHash[:])
+
+	// Field (3) 'GenesisTime'
+	hh.PutUint64(h.GenesisTime)
+
+	// Field (4) 'Height'
+	hh.PutUint64(h.Height)
+
+	hh.Merkleize(indx)
+	return
+}
@@ -0,0 +1,58 @@
+package chainarchive_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/chainarchive"
+	testdata "github.com/olympus-protocol/ogen/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive(t *testing.T) {
+	header := &chainarchive.Header{
+		Version:     chainarchive.Version,
+		NetworkName: []byte("testnet"),
+		GenesisHash: [32]byte{1, 2, 3},
+		GenesisTime: 1610588600,
+		Height:      5,
+	}
+
+	blocks := testdata.FuzzBlock(5, true, true)
+
+	buf := new(bytes.Buffer)
+	w, err := chainarchive.NewWriter(buf, header)
+	assert.NoError(t, err)
+
+	for _, b := range blocks {
+		assert.NoError(t, w.WriteBlock(b))
+	}
+	assert.NoError(t, w.Flush())
+
+	r, err := chainarchive.NewReader(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, header, r.Header())
+
+	for _, b := range blocks {
+		read, err := r.ReadBlock()
+		assert.NoError(t, err)
+		assert.Equal(t, b, read)
+	}
+
+	_, err = r.ReadBlock()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestArchiveInvalid(t *testing.T) {
+	_, err := chainarchive.NewReader(bytes.NewReader([]byte("not an archive")))
+	assert.Equal(t, chainarchive.ErrorInvalidArchive, err)
+
+	buf := new(bytes.Buffer)
+	w, err := chainarchive.NewWriter(buf, &chainarchive.Header{Version: chainarchive.Version + 1})
+	assert.NoError(t, err)
+	assert.NoError(t, w.Flush())
+
+	_, err = chainarchive.NewReader(buf)
+	assert.ErrorIs(t, err, chainarchive.ErrorUnsupportedVersion)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
 	"github.com/olympus-protocol/ogen/internal/chain"
 	"github.com/olympus-protocol/ogen/internal/host"
+	"github.com/olympus-protocol/ogen/internal/keystore"
 	"github.com/olympus-protocol/ogen/internal/proposer"
 	"github.com/olympus-protocol/ogen/pkg/primitives"
 	"html/template"
@@ -57,8 +58,9 @@ func (d *Dashboard) fetchData(c *gin.Context) {
 	}
 
 	validators := d.chain.State().TipState().GetValidatorRegistry()
+	// The keystore is closed when the duties are signed by a remote signer.
 	keys, err := d.proposer.Keystore().GetValidatorKeys()
-	if err != nil {
+	if err != nil && err != keystore.ErrorNoOpen {
 		c.HTML(500, "", nil)
 		return
 	}
@@ -0,0 +1,77 @@
+package duties
+
+import (
+	"sync"
+
+	"github.com/olympus-protocol/ogen/internal/mempool"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// Doppelganger looks for votes signed by a set of validators after a slot.
+type Doppelganger struct {
+	lock sync.Mutex
+
+	// startSlot is the slot the check started, votes for earlier slots may be ours.
+	startSlot uint64
+
+	// watched maps the indices of the validators to their public keys.
+	watched map[uint64][48]byte
+
+	detected map[[48]byte]uint64
+}
+
+var _ mempool.VoteNotifee = &Doppelganger{}
+
+// NewDoppelganger returns a check for the votes of the watched validators for slots after startSlot.
+func NewDoppelganger(startSlot uint64, watched map[uint64][48]byte) *Doppelganger {
+	return &Doppelganger{
+		startSlot: startSlot,
+		watched:   watched,
+		detected:  make(map[[48]byte]uint64),
+	}
+}
+
+// NewVote implements the mempool vote notifee.
+func (d *Doppelganger) NewVote(vote *primitives.MultiValidatorVote, validators []uint64) {
+	d.Check(vote.Data.Slot, validators)
+}
+
+// CheckBlock checks the votes included on a block, s is the state after processing it.
+func (d *Doppelganger) CheckBlock(block *primitives.Block, s state.State) {
+	for _, vote := range block.Votes {
+		validators, err := mempool.VoteValidators(vote, s)
+		if err != nil {
+			continue
+		}
+		d.Check(vote.Data.Slot, validators)
+	}
+}
+
+// Check checks the validators voting on a slot.
+func (d *Doppelganger) Check(slot uint64, validators []uint64) {
+	if slot <= d.startSlot {
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for _, index := range validators {
+		if pub, ok := d.watched[index]; ok {
+			d.detected[pub] = index
+		}
+	}
+}
+
+// Detected returns the watched validators found voting.
+func (d *Doppelganger) Detected() map[[48]byte]uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	detected := make(map[[48]byte]uint64, len(d.detected))
+	for pub, index := range d.detected {
+		detected[pub] = index
+	}
+	return detected
+}
@@ -0,0 +1,123 @@
+// Package duties performs the block proposals and votes of the validators of a signer. It is shared by the
+// proposer of the nodes and the validator clients running against the RPC API of a node.
+package duties
+
+import (
+	"sync"
+
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/bitfield"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// Duties signs the blocks and votes of the keys of a signer. Every signature is checked against the slashing
+// protection database and the keys found running on another node are skipped.
+type Duties struct {
+	log        logger.Logger
+	signer     signer.Signer
+	protection *slashingprotection.DB
+
+	lock sync.Mutex
+	// disabled are the keys found running on another node.
+	disabled map[[48]byte]uint64
+}
+
+// New returns the duties of the keys of a signer.
+func New(log logger.Logger, s signer.Signer, protection *slashingprotection.DB) *Duties {
+	return &Duties{
+		log:        log,
+		signer:     s,
+		protection: protection,
+		disabled:   make(map[[48]byte]uint64),
+	}
+}
+
+// HasKey returns true if the duties of the validator should be performed.
+func (d *Duties) HasKey(pub [48]byte) bool {
+	d.lock.Lock()
+	_, disabled := d.disabled[pub]
+	d.lock.Unlock()
+
+	return !disabled && d.signer.HasKey(pub)
+}
+
+// Disable disables the duties of the validators detected running on another node.
+func (d *Duties) Disable(detected map[[48]byte]uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for pub, index := range detected {
+		d.log.Errorf("DOPPELGANGER DETECTED: validator %d (%x) is voting from another node, its duties are disabled. Stop the other node and restart this one to enable them", index, pub)
+		d.disabled[pub] = index
+	}
+
+	if len(detected) == 0 {
+		d.log.Info("no doppelgangers found")
+	}
+}
+
+// SignBlock signs a block and its RANDAO reveal with the key of the proposer. It returns false when the slashing
+// protection refuses the block.
+func (d *Duties) SignBlock(pub [48]byte, block *primitives.Block) (bool, error) {
+	slot := block.Header.Slot
+	blockHash := block.Hash()
+
+	if err := d.protection.ProtectBlock(pub, slot, blockHash); err != nil {
+		d.log.Errorf("SLASHING PROTECTION: refusing to sign block %s for slot %d with validator %x: %s", blockHash, slot, pub, err)
+		return false, nil
+	}
+
+	blockSig, err := d.signer.SignBlock(pub, block.Header)
+	if err != nil {
+		return false, err
+	}
+	randaoSig, err := d.signer.SignRANDAO(pub, slot)
+	if err != nil {
+		return false, err
+	}
+
+	copy(block.Signature[:], blockSig.Marshal())
+	copy(block.RandaoSignature[:], randaoSig.Marshal())
+
+	return true, nil
+}
+
+// SignVote signs the vote data with our keys of the committee, the public keys are in the order of the
+// participation bitfield. It returns nil when none of the keys signed.
+func (d *Duties) SignVote(data *primitives.VoteData, committee [][48]byte) *primitives.MultiValidatorVote {
+	var signatures []common.Signature
+	bitlistVotes := bitfield.NewBitlist(uint64(len(committee)))
+
+	for i, pub := range committee {
+		if !d.HasKey(pub) {
+			continue
+		}
+		if err := d.protection.ProtectVote(pub, data); err != nil {
+			d.log.Errorf("SLASHING PROTECTION: refusing to sign vote for slot %d (epochs %d-%d) with validator %x: %s", data.Slot, data.FromEpoch, data.ToEpoch, pub, err)
+			continue
+		}
+		sig, err := d.signer.SignVote(pub, data)
+		if err != nil {
+			d.log.Errorf("unable to sign vote for slot %d with validator %x: %s", data.Slot, pub, err)
+			continue
+		}
+		signatures = append(signatures, sig)
+		bitlistVotes.Set(uint(i))
+	}
+
+	if len(signatures) == 0 {
+		return nil
+	}
+
+	vote := &primitives.MultiValidatorVote{
+		Data:                  data,
+		ParticipationBitfield: bitlistVotes,
+	}
+	copy(vote.Sig[:], bls.AggregateSignatures(signatures).Marshal())
+
+	return vote
+}
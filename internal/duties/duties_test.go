@@ -0,0 +1,55 @@
+package duties_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/duties"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSigner has the keys of some validators.
+type testSigner struct {
+	signer.Signer
+	keys map[[48]byte]struct{}
+}
+
+func (s *testSigner) HasKey(pub [48]byte) bool {
+	_, ok := s.keys[pub]
+	return ok
+}
+
+func Test_DoppelgangerCheck(t *testing.T) {
+	d := duties.NewDoppelganger(10, map[uint64][48]byte{
+		1: {1},
+		3: {3},
+	})
+
+	// Votes up to the start slot may be ours.
+	d.Check(10, []uint64{1, 3})
+	assert.Empty(t, d.Detected())
+
+	d.Check(11, []uint64{0, 1, 2})
+	assert.Equal(t, map[[48]byte]uint64{{1}: 1}, d.Detected())
+
+	d.NewVote(&primitives.MultiValidatorVote{Data: &primitives.VoteData{Slot: 12}}, []uint64{3, 4})
+	assert.Equal(t, map[[48]byte]uint64{{1}: 1, {3}: 3}, d.Detected())
+}
+
+func Test_DisableKeys(t *testing.T) {
+	s := &testSigner{keys: map[[48]byte]struct{}{{1}: {}, {2}: {}}}
+	d := duties.New(logger.New(os.Stdout), s, nil)
+
+	assert.True(t, d.HasKey([48]byte{1}))
+	assert.True(t, d.HasKey([48]byte{2}))
+	assert.False(t, d.HasKey([48]byte{3}))
+
+	d.Disable(map[[48]byte]uint64{{2}: 2, {3}: 3})
+
+	assert.True(t, d.HasKey([48]byte{1}))
+	assert.False(t, d.HasKey([48]byte{2}))
+	assert.False(t, d.HasKey([48]byte{3}))
+}
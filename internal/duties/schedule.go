@@ -0,0 +1,64 @@
+package duties
+
+import (
+	"context"
+	"time"
+
+	"github.com/olympus-protocol/ogen/pkg/params"
+)
+
+// Clock computes the slot times of a chain.
+type Clock struct {
+	genesisTime  time.Time
+	slotDuration time.Duration
+}
+
+// NewClock returns the clock of a chain started at genesisTime.
+func NewClock(genesisTime time.Time, netParams *params.ChainParams) Clock {
+	return Clock{
+		genesisTime:  genesisTime,
+		slotDuration: time.Duration(netParams.SlotDuration) * time.Second,
+	}
+}
+
+// CurrentSlot returns the slot running now.
+func (c Clock) CurrentSlot() uint64 {
+	slot := time.Now().Sub(c.genesisTime) / c.slotDuration
+	if slot < 0 {
+		return 0
+	}
+	return uint64(slot)
+}
+
+// BlockTime returns the time the block of a slot is proposed.
+func (c Clock) BlockTime(slot uint64) time.Time {
+	return c.genesisTime.Add(time.Duration(slot) * c.slotDuration)
+}
+
+// VoteTime returns the time the votes of a slot are sent, half a slot before its block.
+func (c Clock) VoteTime(slot uint64) time.Time {
+	return c.BlockTime(slot).Add(-c.slotDuration / 2)
+}
+
+// Run runs a duty at the time returned by at for every slot after the current one until the context is done.
+// The slots that start while a duty runs are skipped.
+func Run(ctx context.Context, c Clock, at func(slot uint64) time.Time, duty func(slot uint64)) {
+	slot := c.CurrentSlot() + 1
+
+	for {
+		timer := time.NewTimer(time.Until(at(slot)))
+
+		select {
+		case <-timer.C:
+			duty(slot)
+			if next := c.CurrentSlot() + 1; next > slot+1 {
+				slot = next
+			} else {
+				slot++
+			}
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
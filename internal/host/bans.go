@@ -0,0 +1,111 @@
+package host
+
+import (
+	"os"
+	"path"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// banStore keeps the banned peers on disk with the time their ban expires, so bans persist across restarts.
+type banStore struct {
+	db *leveldb.DB
+}
+
+// openBanStore opens the ban list on the data folder and removes the expired bans.
+func openBanStore(datapath string) (*banStore, error) {
+	dir := path.Join(datapath, "badpeers")
+
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		// The ban list can always be rebuilt, start a new one when the old one can't be opened.
+		_ = os.RemoveAll(dir)
+		db, err = leveldb.OpenFile(dir, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bs := &banStore{db: db}
+
+	if err := bs.prune(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return bs, nil
+}
+
+// ban bans a peer until the specified time.
+func (bs *banStore) ban(p peer.ID, until time.Time) error {
+	tb, err := until.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return bs.db.Put([]byte(p), tb, nil)
+}
+
+// isBanned returns true if the peer has a ban that didn't expire. Expired bans are removed.
+func (bs *banStore) isBanned(p peer.ID) (bool, error) {
+	tb, err := bs.db.Get([]byte(p), nil)
+	if err == leveldb.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var until time.Time
+	if err := until.UnmarshalBinary(tb); err != nil {
+		return false, err
+	}
+
+	if time.Now().After(until) {
+		return false, bs.db.Delete([]byte(p), nil)
+	}
+
+	return true, nil
+}
+
+// bans returns the banned peers and the time their ban expires.
+func (bs *banStore) bans() (map[peer.ID]time.Time, error) {
+	bans := make(map[peer.ID]time.Time)
+
+	iter := bs.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		var until time.Time
+		if err := until.UnmarshalBinary(iter.Value()); err != nil {
+			continue
+		}
+		bans[peer.ID(iter.Key())] = until
+	}
+
+	return bans, iter.Error()
+}
+
+// prune removes the expired bans.
+func (bs *banStore) prune() error {
+	bans, err := bs.bans()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	batch := new(leveldb.Batch)
+	for p, until := range bans {
+		if now.After(until) {
+			batch.Delete([]byte(p))
+		}
+	}
+
+	return bs.db.Write(batch, nil)
+}
+
+func (bs *banStore) close() error {
+	return bs.db.Close()
+}
@@ -0,0 +1,392 @@
+package host
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+const (
+	// rangeSlots is the amount of slots requested on a single range request.
+	rangeSlots = 64
+
+	// maxRequestsPerPeer is the amount of ranges that can be requested to a peer at the same time.
+	maxRequestsPerPeer = 2
+
+	// maxPendingRanges limits the amount of downloaded ranges waiting for import.
+	maxPendingRanges = 32
+
+	// noPeersTimeout is the time to wait without peers to serve the ranges before giving up.
+	noPeersTimeout = time.Minute
+
+	// badBatchPenalty is the ban score added to a peer serving invalid blocks.
+	badBatchPenalty = 100
+
+	// unknownParentPenalty is the ban score added to a peer serving blocks that don't connect to our chain, or
+	// withholding the blocks another range connects to.
+	unknownParentPenalty = 50
+
+	// badBlockPenalty is the ban score added to a peer gossiping an invalid block.
+	badBlockPenalty = 100
+
+	// timeoutPenalty is the ban score added to a peer that doesn't answer a range request in time.
+	timeoutPenalty = 20
+)
+
+// blockRange is a range of slots downloaded from a single peer.
+type blockRange struct {
+	start uint64
+	end   uint64
+
+	peer      peer.ID
+	requestID uint64
+	deadline  time.Time
+
+	blocks []*primitives.Block
+	done   bool
+
+	// tried contains the peers that failed to serve this range.
+	tried map[peer.ID]struct{}
+
+	// recheck is the peer that served this range before. The range is downloaded again from a different peer
+	// when the next range doesn't connect to it.
+	recheck peer.ID
+}
+
+func (r *blockRange) reset() {
+	r.peer = ""
+	r.requestID = 0
+	r.blocks = nil
+	r.done = false
+}
+
+// downloader downloads slot ranges from multiple peers concurrently and imports them in order.
+type downloader struct {
+	sp *synchronizer
+
+	lock sync.Mutex
+
+	// next is the first slot without a range.
+	next uint64
+
+	// target is the last slot to download.
+	target uint64
+
+	// queue contains the ranges not imported yet sorted by slot.
+	queue []*blockRange
+
+	// requests maps the in-flight request ids to their range.
+	requests map[uint64]*blockRange
+
+	// last is the last imported range. lastChecked is set when it was downloaded again from a different peer
+	// after the next range didn't connect to it.
+	last        *blockRange
+	lastChecked bool
+
+	wake chan struct{}
+}
+
+func newDownloader(sp *synchronizer, start uint64, target uint64) *downloader {
+	return &downloader{
+		sp:       sp,
+		next:     start,
+		target:   target,
+		requests: make(map[uint64]*blockRange),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// run downloads and imports all the ranges up to the target slot. It returns once all the
+// ranges are imported or when no peer is able to serve them.
+func (d *downloader) run() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	lastPeerSeen := time.Now()
+
+	for {
+		if d.importReady() {
+			return
+		}
+
+		if d.schedule() {
+			lastPeerSeen = time.Now()
+		} else if time.Since(lastPeerSeen) > noPeersTimeout {
+			d.sp.log.Warn("no peers available to download blocks from")
+			return
+		}
+
+		select {
+		case <-d.sp.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// schedule creates new ranges, expires the timed out requests and assigns the pending ranges to idle peers.
+// It returns false when there is work to do but no peer to do it.
+func (d *downloader) schedule() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for len(d.queue) < maxPendingRanges && d.next <= d.target {
+		end := d.next + rangeSlots - 1
+		if end > d.target {
+			end = d.target
+		}
+		d.queue = append(d.queue, &blockRange{
+			start: d.next,
+			end:   end,
+			tried: make(map[peer.ID]struct{}),
+		})
+		d.next = end + 1
+	}
+
+	now := time.Now()
+	for _, r := range d.queue {
+		if r.peer != "" && !r.done && now.After(r.deadline) {
+			d.sp.log.Warnf("range request for slots %d-%d to peer %s timed out", r.start, r.end, r.peer)
+			d.failRange(r, timeoutPenalty)
+		}
+	}
+
+	load := make(map[peer.ID]int)
+	for _, r := range d.queue {
+		if r.peer != "" && !r.done {
+			load[r.peer]++
+		}
+	}
+
+	peers := d.sp.host.GetPeersInfo()
+
+	assigned := false
+	pending := false
+	for _, r := range d.queue {
+		if r.peer != "" {
+			continue
+		}
+		pending = true
+
+		p, ok := d.selectPeer(r, peers, load)
+		if !ok && len(r.tried) > 0 {
+			// Every peer able to serve the range failed, try them again.
+			r.tried = make(map[peer.ID]struct{})
+			p, ok = d.selectPeer(r, peers, load)
+		}
+		if !ok {
+			continue
+		}
+
+		if d.request(r, p) {
+			load[p]++
+			assigned = true
+		}
+	}
+
+	return assigned || !pending || len(d.requests) > 0
+}
+
+// selectPeer returns the least loaded peer that has finalized the range and didn't fail to serve it.
+func (d *downloader) selectPeer(r *blockRange, peers []*peerStats, load map[peer.ID]int) (peer.ID, bool) {
+	var best peer.ID
+	found := false
+	for _, p := range peers {
+		if p.ChainStats == nil || p.ChainStats.FinalizedSlot < r.end {
+			continue
+		}
+		if _, failed := r.tried[p.ID]; failed {
+			continue
+		}
+		if load[p.ID] >= maxRequestsPerPeer {
+			continue
+		}
+		if !found || load[p.ID] < load[best] {
+			best = p.ID
+			found = true
+		}
+	}
+	return best, found
+}
+
+// request sends the range request to a peer. It must be called holding the lock.
+func (d *downloader) request(r *blockRange, p peer.ID) bool {
+	requestID := d.sp.nextRequestID()
+
+	err := d.sp.host.SendMessage(p, &p2p.MsgGetBlocksByRange{
+		Version:   p2p.BlocksProtocolVersion,
+		RequestID: requestID,
+		StartSlot: r.start,
+		Count:     r.end - r.start + 1,
+	})
+	if err != nil {
+		d.sp.log.Debugf("unable to request range to peer %s: %s", p, err)
+		r.tried[p] = struct{}{}
+		return false
+	}
+
+	r.peer = p
+	r.requestID = requestID
+	r.deadline = time.Now().Add(requestTimeout)
+	d.requests[requestID] = r
+
+	return true
+}
+
+// failRange penalizes the peer that served the range and queues the range to be requested to a different peer.
+// It must be called holding the lock.
+func (d *downloader) failRange(r *blockRange, penalty uint64) {
+	d.sp.host.PenalizePeer(r.peer, penalty)
+	r.tried[r.peer] = struct{}{}
+	delete(d.requests, r.requestID)
+	r.reset()
+}
+
+// handles returns true if the request id belongs to a range request.
+func (d *downloader) handles(requestID uint64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, ok := d.requests[requestID]
+	return ok
+}
+
+// awaits returns true if the request id belongs to a range requested to the peer.
+func (d *downloader) awaits(id peer.ID, requestID uint64) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	r, ok := d.requests[requestID]
+	return ok && r.peer == id
+}
+
+// handleBlocks adds a batch of blocks to the range it answers. Batches with blocks outside the
+// range or not linked to each other are rejected.
+func (d *downloader) handleBlocks(id peer.ID, msg *p2p.MsgBlocks) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	r, ok := d.requests[msg.RequestID]
+	if !ok || r.peer != id {
+		return
+	}
+
+	for _, b := range msg.Blocks {
+		if b.Header == nil || b.Header.Slot < r.start || b.Header.Slot > r.end {
+			d.sp.log.Warnf("peer %s sent blocks outside of the requested range %d-%d", id, r.start, r.end)
+			d.failRange(r, badBatchPenalty)
+			d.signal()
+			return
+		}
+		if len(r.blocks) > 0 {
+			prev := r.blocks[len(r.blocks)-1]
+			if prev.Hash() != chainhash.Hash(b.Header.PrevBlockHash) || prev.Header.Slot >= b.Header.Slot {
+				d.sp.log.Warnf("peer %s sent unlinked blocks for range %d-%d", id, r.start, r.end)
+				d.failRange(r, badBatchPenalty)
+				d.signal()
+				return
+			}
+		}
+		r.blocks = append(r.blocks, b)
+	}
+
+	r.deadline = time.Now().Add(requestTimeout)
+
+	if msg.Done {
+		r.done = true
+		delete(d.requests, msg.RequestID)
+		d.signal()
+	}
+}
+
+func (d *downloader) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// importReady imports the downloaded ranges in order. It returns true when everything up to the target
+// slot is imported.
+func (d *downloader) importReady() bool {
+	for {
+		d.lock.Lock()
+		if len(d.queue) == 0 {
+			finished := d.next > d.target
+			d.lock.Unlock()
+			return finished
+		}
+		r := d.queue[0]
+		if !r.done {
+			d.lock.Unlock()
+			return false
+		}
+		blocks := r.blocks
+		d.lock.Unlock()
+
+		imported := false
+		for _, b := range blocks {
+			err := d.sp.processBlock(b, false)
+			if err == ErrorBlockAlreadyKnown {
+				continue
+			}
+			if err == nil {
+				imported = true
+				continue
+			}
+
+			d.sp.log.Warnf("invalid block from peer %s for range %d-%d: %s", r.peer, r.start, r.end, err)
+
+			d.lock.Lock()
+			if err == ErrorBlockParentUnknown {
+				d.unknownParent(r)
+			} else {
+				d.failRange(r, badBatchPenalty)
+			}
+			d.lock.Unlock()
+
+			return false
+		}
+
+		d.lock.Lock()
+		d.queue = d.queue[1:]
+		d.last = r
+		d.lastChecked = r.recheck != ""
+		d.lock.Unlock()
+
+		// The blocks missing on the first download of the range were withheld by its peer.
+		if r.recheck != "" && imported {
+			d.sp.log.Warnf("peer %s withheld blocks of range %d-%d", r.recheck, r.start, r.end)
+			d.sp.host.PenalizePeer(r.recheck, unknownParentPenalty)
+		}
+
+		d.sp.log.Infof("imported slots %d-%d from peer %s", r.start, r.end, r.peer)
+	}
+}
+
+// unknownParent handles a range that doesn't connect to the imported chain. Either the peer of the range is on a
+// different chain or the peer of the previous range withheld its last blocks. The previous range is downloaded again
+// from a different peer before blaming anyone, the peer of the range is only penalized when the previous range
+// doesn't change. It must be called holding the lock.
+func (d *downloader) unknownParent(r *blockRange) {
+	if d.last == nil || d.lastChecked {
+		d.failRange(r, unknownParentPenalty)
+		return
+	}
+
+	d.sp.log.Infof("range %d-%d doesn't connect to range %d-%d from peer %s, downloading it again", r.start, r.end, d.last.start, d.last.end, d.last.peer)
+
+	delete(d.requests, r.requestID)
+	r.reset()
+
+	d.queue = append([]*blockRange{{
+		start:   d.last.start,
+		end:     d.last.end,
+		tried:   map[peer.ID]struct{}{d.last.peer: {}},
+		recheck: d.last.peer,
+	}}, d.queue...)
+	d.last = nil
+}
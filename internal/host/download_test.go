@@ -0,0 +1,149 @@
+package host
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHost records the penalties of the peers and the messages sent to them.
+type testHost struct {
+	Host
+
+	lock      sync.Mutex
+	penalties map[peer.ID]uint64
+	sent      map[peer.ID][]p2p.Message
+}
+
+func newTestHost() *testHost {
+	return &testHost{
+		penalties: make(map[peer.ID]uint64),
+		sent:      make(map[peer.ID][]p2p.Message),
+	}
+}
+
+func (h *testHost) SendMessage(p peer.ID, msg p2p.Message) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.sent[p] = append(h.sent[p], msg)
+	return nil
+}
+
+func (h *testHost) PenalizePeer(p peer.ID, score uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.penalties[p] += score
+}
+
+func (h *testHost) TrackedPeers() int {
+	return 0
+}
+
+func newTestSynchronizer(h Host, ch chain.Blockchain) *synchronizer {
+	return &synchronizer{
+		host:    h,
+		ctx:     config.GlobalParams.Context,
+		log:     config.GlobalParams.Logger,
+		chain:   ch,
+		orphans: newOrphanPool(config.GlobalParams.NetParams.SlotDuration),
+	}
+}
+
+// serve sets the blocks of a range as downloaded from a peer.
+func serve(r *blockRange, p peer.ID, blocks []*primitives.Block) {
+	r.peer = p
+	r.blocks = blocks
+	r.done = true
+}
+
+// newTestDownloader returns a downloader for slots 1 to 12 split in two ranges, a chain to import them and the
+// blocks of those slots.
+func newTestDownloader(t *testing.T) (*downloader, *testHost, *chaintest.Chain, []*primitives.Block) {
+	src := chaintest.NewChain(t)
+	blocks := src.Extend(t, 12)
+
+	dst := src.Empty(t)
+	h := newTestHost()
+
+	d := newDownloader(newTestSynchronizer(h, dst), 1, 12)
+	d.queue = []*blockRange{
+		{start: 1, end: 6, tried: make(map[peer.ID]struct{})},
+		{start: 7, end: 12, tried: make(map[peer.ID]struct{})},
+	}
+	d.next = 13
+
+	return d, h, dst, blocks
+}
+
+func Test_DownloaderOutOfOrder(t *testing.T) {
+	d, h, dst, blocks := newTestDownloader(t)
+	r1, r2 := d.queue[0], d.queue[1]
+
+	// The second range is not imported until the first one is downloaded.
+	serve(r2, "peer-b", blocks[6:])
+	assert.False(t, d.importReady())
+	assert.Equal(t, uint64(0), dst.State().Tip().Slot)
+	assert.Len(t, d.queue, 2)
+
+	serve(r1, "peer-a", blocks[:6])
+	assert.True(t, d.importReady())
+	assert.Equal(t, blocks[11].Hash(), dst.State().Tip().Hash)
+	assert.Empty(t, h.penalties)
+}
+
+func Test_DownloaderWithheldBlocks(t *testing.T) {
+	d, h, dst, blocks := newTestDownloader(t)
+	r1, r2 := d.queue[0], d.queue[1]
+
+	// The peer of the first range withholds the block at slot 6, the second range doesn't connect.
+	serve(r1, "peer-a", blocks[:5])
+	serve(r2, "peer-b", blocks[6:])
+	assert.False(t, d.importReady())
+	assert.Empty(t, h.penalties)
+
+	// The first range is downloaded again from a different peer.
+	if !assert.Len(t, d.queue, 2) {
+		t.FailNow()
+	}
+	recheck := d.queue[0]
+	assert.Equal(t, uint64(1), recheck.start)
+	assert.Equal(t, uint64(6), recheck.end)
+	assert.Equal(t, peer.ID("peer-a"), recheck.recheck)
+	assert.Contains(t, recheck.tried, peer.ID("peer-a"))
+	assert.Equal(t, r2, d.queue[1])
+	assert.Equal(t, peer.ID(""), r2.peer)
+
+	serve(recheck, "peer-c", blocks[:6])
+	serve(r2, "peer-b", blocks[6:])
+	assert.True(t, d.importReady())
+	assert.Equal(t, blocks[11].Hash(), dst.State().Tip().Hash)
+
+	assert.Equal(t, map[peer.ID]uint64{"peer-a": unknownParentPenalty}, h.penalties)
+}
+
+func Test_DownloaderUnknownParent(t *testing.T) {
+	d, h, _, blocks := newTestDownloader(t)
+	r1, r2 := d.queue[0], d.queue[1]
+
+	// The peer of the second range serves blocks that don't connect to the first range.
+	serve(r1, "peer-a", blocks[:6])
+	serve(r2, "peer-b", blocks[7:])
+	assert.False(t, d.importReady())
+	assert.Empty(t, h.penalties)
+
+	// The first range doesn't change when downloaded again, so the peer of the second range is penalized.
+	serve(d.queue[0], "peer-c", blocks[:6])
+	serve(r2, "peer-b", blocks[7:])
+	assert.False(t, d.importReady())
+
+	assert.Equal(t, map[peer.ID]uint64{"peer-b": unknownParentPenalty}, h.penalties)
+	assert.Equal(t, []*blockRange{r2}, d.queue)
+	assert.Contains(t, r2.tried, peer.ID("peer-b"))
+}
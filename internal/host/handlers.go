@@ -3,17 +3,39 @@ package host
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/olympus-protocol/ogen/internal/chainindex"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
 	"github.com/olympus-protocol/ogen/pkg/p2p"
 	"io"
 	"strings"
+	"time"
 )
 
-// processMessages continuously reads from stream and handles any protobuf messages.
-func processMessages(ctx context.Context, net uint32, stream io.Reader, handler func(p2p.Message) error) error {
+var (
+	// ErrorProtocolVersion returns when a peer runs an unsupported protocol version.
+	ErrorProtocolVersion = errors.New("unsupported protocol version")
+
+	// ErrorGenesisMismatch returns when a peer uses a different genesis.
+	ErrorGenesisMismatch = errors.New("genesis hash doesn't match")
+
+	// ErrorForkMismatch returns when a peer follows different consensus rules.
+	ErrorForkMismatch = errors.New("fork digest doesn't match")
+
+	// ErrorQueueFull returns when a message is dropped because the peer doesn't read its messages in time.
+	ErrorQueueFull = errors.New("outgoing message queue full")
+)
+
+// processMessages continuously reads from stream and handles any protobuf messages. The stream is dropped when a
+// header announces a payload bigger than its command allows. The payload of a message is only read when allow
+// accepts the header, otherwise it is skipped. The request id of the responses is read before calling allow, it
+// is zero for the other commands.
+func processMessages(ctx context.Context, net uint32, stream io.Reader, allow func(header *p2p.MessageHeader, requestID uint64) bool, handler func(p2p.Message) error) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -22,7 +44,35 @@ func processMessages(ctx context.Context, net uint32, stream io.Reader, handler
 			break
 		}
 
-		msg, err := p2p.ReadMessage(stream, net)
+		header, err := p2p.ReadMessageHeader(stream, net)
+		if err != nil {
+			return err
+		}
+
+		if err := header.CheckLength(); err != nil {
+			return err
+		}
+
+		// The responses start with the request id, it is read to let the responses to our requests through.
+		payload := stream
+		requestID := uint64(0)
+		if _, isResponse := responseCommands[header.CommandString()]; isResponse && header.Length >= 8 {
+			prefix := make([]byte, 8)
+			if _, err := io.ReadFull(stream, prefix); err != nil {
+				return err
+			}
+			requestID = binary.LittleEndian.Uint64(prefix)
+			payload = io.MultiReader(bytes.NewReader(prefix), stream)
+		}
+
+		if !allow(header, requestID) {
+			if err := p2p.DiscardMessagePayload(payload, header); err != nil {
+				return err
+			}
+			continue
+		}
+
+		msg, err := p2p.ReadMessagePayload(payload, header)
 		if err != nil {
 			return err
 		}
@@ -34,7 +84,29 @@ func processMessages(ctx context.Context, net uint32, stream io.Reader, handler
 }
 
 func (h *host) receiveMessages(id peer.ID, r io.Reader) {
-	err := processMessages(h.ctx, h.netMagic, r, func(message p2p.Message) error {
+	allow := func(header *p2p.MessageHeader, requestID uint64) bool {
+		cmd := header.CommandString()
+		limit := h.limiter.limit(id, cmd, header.Length)
+		if limit == limitNone {
+			return true
+		}
+
+		// Dropping a response to our requests makes it time out and blames the peer for it.
+		if requestID != 0 && h.synchronizer.awaits(id, requestID) {
+			return true
+		}
+
+		h.log.Debugf("dropping message %s from peer %s over the rate limits", cmd, id)
+		h.stats.IncreaseThrottledMessages(id)
+
+		// The global limit can be reached by the traffic of the other peers.
+		if limit != limitGlobal {
+			h.PenalizePeer(id, rateLimitPenalty)
+		}
+		return false
+	}
+
+	err := processMessages(h.ctx, h.netMagic, r, allow, func(message p2p.Message) error {
 		cmd := message.Command()
 
 		h.log.Tracef("processing message %s from peer %s", cmd, id)
@@ -47,6 +119,16 @@ func (h *host) receiveMessages(id peer.ID, r io.Reader) {
 			handler = h.handleGetBlocksMsg
 		case p2p.MsgBlockCmd:
 			handler = h.handleBlockMsg
+		case p2p.MsgGetBlocksByRangeCmd:
+			handler = h.handleGetBlocksByRangeMsg
+		case p2p.MsgGetBlocksByRootCmd:
+			handler = h.handleGetBlocksByRootMsg
+		case p2p.MsgBlocksCmd:
+			handler = h.handleBlocksMsg
+		case p2p.MsgGetHeadersCmd:
+			handler = h.handleGetHeadersMsg
+		case p2p.MsgHeadersCmd:
+			handler = h.handleHeadersMsg
 		default:
 			h.log.Tracef("received unknown msg %s", cmd)
 			return nil
@@ -60,6 +142,16 @@ func (h *host) receiveMessages(id peer.ID, r io.Reader) {
 		return nil
 	})
 	if err != nil {
+		if err == p2p.ErrorNetMismatch {
+			h.log.Warnf("banning peer %s for using a different network", id)
+			h.stats.BanPeer(id)
+			return
+		}
+		if errors.Is(err, ErrorQueueFull) {
+			h.log.Warnf("disconnecting slow peer %s: %s", id, err)
+			_ = h.Disconnect(id)
+			return
+		}
 		if !strings.Contains(err.Error(), "stream reset") {
 			h.stats.IncreaseWrongMsgCount(id)
 			h.log.Errorf("error receiving messages from peer %s: %s", id, err)
@@ -77,6 +169,12 @@ func (h *host) handleVersionMsg(id peer.ID, msg p2p.Message) error {
 
 	// Send our version message if required
 	ourVersion := h.Version()
+
+	if err := checkVersionCompatible(ourVersion, theirVersion); err != nil {
+		h.log.Warnf("disconnecting incompatible peer %s: %s", id, err)
+		h.stats.SetPeerBan(id, incompatiblePeerBan)
+		return h.Disconnect(id)
+	}
 	direction := h.GetPeerDirection(id)
 
 	h.AddPeerStats(id, theirVersion, direction)
@@ -91,6 +189,20 @@ func (h *host) handleVersionMsg(id peer.ID, msg p2p.Message) error {
 	return nil
 }
 
+// checkVersionCompatible checks the peer runs a supported protocol version and follows the same chain.
+func checkVersionCompatible(ours *p2p.MsgVersion, theirs *p2p.MsgVersion) error {
+	if theirs.ProtocolVersion < p2p.MinProtocolVersion {
+		return ErrorProtocolVersion
+	}
+	if theirs.GenesisHash != ours.GenesisHash {
+		return ErrorGenesisMismatch
+	}
+	if theirs.ForkDigest != ours.ForkDigest {
+		return ErrorForkMismatch
+	}
+	return nil
+}
+
 func (h *host) handleGetBlocksMsg(id peer.ID, rawMsg p2p.Message) error {
 	msg, ok := rawMsg.(*p2p.MsgGetBlocks)
 	if !ok {
@@ -139,6 +251,205 @@ func (h *host) handleGetBlocksMsg(id peer.ID, rawMsg p2p.Message) error {
 	return nil
 }
 
+func (h *host) handleGetBlocksByRangeMsg(id peer.ID, rawMsg p2p.Message) error {
+	msg, ok := rawMsg.(*p2p.MsgGetBlocksByRange)
+	if !ok {
+		return errors.New("did not receive get blocks by range message")
+	}
+
+	h.log.Debugf("received getblocksrange from peer %s", id)
+
+	if msg.Version != p2p.BlocksProtocolVersion {
+		h.log.Debugf("unsupported block request version %d from peer %s", msg.Version, id)
+		return h.SendMessage(id, &p2p.MsgBlocks{RequestID: msg.RequestID, Done: true})
+	}
+
+	count := msg.Count
+	if count > p2p.MaxBlocksPerRequest {
+		count = p2p.MaxBlocksPerRequest
+	}
+
+	if len(msg.Locator) == 0 {
+		hashes, more := h.blocksBySlotRange(msg.StartSlot, count)
+		return h.sendBlocks(id, msg.RequestID, hashes, more)
+	}
+
+	ch := h.chain.State().Chain()
+
+	var hashes [][32]byte
+	row, more := ch.Next(h.findLocatorStart(msg.Locator))
+	for more && uint64(len(hashes)) < count {
+		hashes = append(hashes, row.Hash)
+		row, more = ch.Next(row)
+	}
+
+	return h.sendBlocks(id, msg.RequestID, hashes, more)
+}
+
+// findLocatorStart returns the first locator block that is part of our main chain.
+func (h *host) findLocatorStart(locator [][32]byte) *chainindex.BlockRow {
+	ch := h.chain.State().Chain()
+
+	for _, hash := range locator {
+		row, ok := h.chain.State().Index().Get(hash)
+		if !ok {
+			continue
+		}
+		mainRow, ok := ch.GetNodeByHeight(row.Height)
+		if ok && mainRow.Hash.IsEqual(&row.Hash) {
+			return row
+		}
+	}
+
+	return ch.Genesis()
+}
+
+func (h *host) handleGetHeadersMsg(id peer.ID, rawMsg p2p.Message) error {
+	msg, ok := rawMsg.(*p2p.MsgGetHeaders)
+	if !ok {
+		return errors.New("did not receive get headers message")
+	}
+
+	h.log.Debugf("received getheaders from peer %s", id)
+
+	if msg.Version != p2p.BlocksProtocolVersion {
+		h.log.Debugf("unsupported headers request version %d from peer %s", msg.Version, id)
+		return h.SendMessage(id, &p2p.MsgHeaders{RequestID: msg.RequestID})
+	}
+
+	count := msg.Count
+	if count > p2p.MaxHeadersPerMsg {
+		count = p2p.MaxHeadersPerMsg
+	}
+
+	ch := h.chain.State().Chain()
+
+	res := &p2p.MsgHeaders{RequestID: msg.RequestID}
+
+	row, more := ch.Next(h.findLocatorStart(msg.Locator))
+	for more && uint64(len(res.Headers)) < count {
+		block, err := h.chain.GetBlock(row.Hash)
+		if err != nil {
+			h.log.Errorf("unable to load block %s requested by peer %s: %s", row.Hash, id, err)
+			break
+		}
+		res.Headers = append(res.Headers, &p2p.SignedBlockHeader{
+			Header:          block.Header,
+			Signature:       block.Signature,
+			RandaoSignature: block.RandaoSignature,
+		})
+		row, more = ch.Next(row)
+	}
+
+	res.More = more
+
+	if err := h.sendResponse(id, res); err != nil {
+		return fmt.Errorf("unable to answer headers request %d: %w", msg.RequestID, err)
+	}
+
+	return nil
+}
+
+func (h *host) handleHeadersMsg(id peer.ID, msg p2p.Message) error {
+	headers, ok := msg.(*p2p.MsgHeaders)
+	if !ok {
+		return errors.New("non headers msg")
+	}
+
+	h.IncreasePeerReceivedBytes(id, msg.PayloadLength())
+
+	return h.synchronizer.handleHeaders(id, headers)
+}
+
+// blocksBySlotRange returns the hashes of the main chain blocks with slots from startSlot to startSlot+count-1.
+func (h *host) blocksBySlotRange(startSlot uint64, count uint64) ([][32]byte, bool) {
+	ch := h.chain.State().Chain()
+
+	row, ok := ch.GetNodeBySlot(startSlot)
+	if !ok {
+		return nil, false
+	}
+
+	more := true
+	if row.Slot < startSlot {
+		row, more = ch.Next(row)
+	}
+
+	var hashes [][32]byte
+	for more && row.Slot < startSlot+count {
+		hashes = append(hashes, row.Hash)
+		row, more = ch.Next(row)
+	}
+
+	return hashes, more
+}
+
+func (h *host) handleGetBlocksByRootMsg(id peer.ID, rawMsg p2p.Message) error {
+	msg, ok := rawMsg.(*p2p.MsgGetBlocksByRoot)
+	if !ok {
+		return errors.New("did not receive get blocks by root message")
+	}
+
+	h.log.Debugf("received getblocksroot from peer %s", id)
+
+	if msg.Version != p2p.BlocksProtocolVersion {
+		h.log.Debugf("unsupported block request version %d from peer %s", msg.Version, id)
+		return h.SendMessage(id, &p2p.MsgBlocks{RequestID: msg.RequestID, Done: true})
+	}
+
+	var hashes [][32]byte
+	for _, hash := range msg.Roots {
+		if h.chain.State().Index().Have(hash) {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return h.sendBlocks(id, msg.RequestID, hashes, false)
+}
+
+// sendBlocks sends the blocks to the peer in batches of p2p.MaxBlocksPerMsg. The last batch is marked as done,
+// an empty batch is sent when there are no blocks to let the peer know the request was answered. When a block
+// can't be loaded or sent the request is left unanswered, so the peer doesn't take a truncated answer as complete.
+func (h *host) sendBlocks(id peer.ID, requestID uint64, hashes [][32]byte, more bool) error {
+	batch := &p2p.MsgBlocks{RequestID: requestID}
+
+	for i, hash := range hashes {
+		block, err := h.chain.GetBlock(hash)
+		if err != nil {
+			h.log.Errorf("unable to load block %s requested by peer %s: %s", chainhash.Hash(hash), id, err)
+			return nil
+		}
+		batch.Blocks = append(batch.Blocks, block)
+
+		if len(batch.Blocks) == p2p.MaxBlocksPerMsg && i != len(hashes)-1 {
+			if err := h.sendResponse(id, batch); err != nil {
+				return fmt.Errorf("unable to answer block request %d: %w", requestID, err)
+			}
+			batch = &p2p.MsgBlocks{RequestID: requestID}
+		}
+	}
+
+	batch.Done = true
+	batch.More = more
+
+	if err := h.sendResponse(id, batch); err != nil {
+		return fmt.Errorf("unable to answer block request %d: %w", requestID, err)
+	}
+
+	return nil
+}
+
+func (h *host) handleBlocksMsg(id peer.ID, msg p2p.Message) error {
+	blocks, ok := msg.(*p2p.MsgBlocks)
+	if !ok {
+		return errors.New("non blocks msg")
+	}
+
+	h.IncreasePeerReceivedBytes(id, msg.PayloadLength())
+
+	return h.synchronizer.handleBlocks(id, blocks)
+}
+
 func (h *host) handleBlockMsg(id peer.ID, msg p2p.Message) error {
 	block, ok := msg.(*p2p.MsgBlock)
 	if !ok {
@@ -156,7 +467,7 @@ func (h *host) handleBlockMsg(id peer.ID, msg p2p.Message) error {
 }
 
 func (h *host) sendMessages(id peer.ID, w io.Writer) {
-	msgChan := make(chan p2p.Message)
+	msgChan := make(chan p2p.Message, outgoingQueueSize)
 
 	h.outgoingMessagesLock.Lock()
 	h.outgoingMessages[id] = msgChan
@@ -167,19 +478,64 @@ func (h *host) sendMessages(id peer.ID, w io.Writer) {
 			err := p2p.WriteMessage(w, msg, h.netMagic)
 			if err != nil {
 				h.log.Errorf("error sending message to peer %s: %s", id, err)
+
+				h.outgoingMessagesLock.Lock()
+				if h.outgoingMessages[id] == msgChan {
+					delete(h.outgoingMessages, id)
+				}
+				h.outgoingMessagesLock.Unlock()
+				return
 			}
 		}
 	}()
 }
 
+// SendMessage queues a message for a peer. When the queue of the peer is full the message is dropped, peers
+// that keep the queue full are disconnected.
 func (h *host) SendMessage(id peer.ID, msg p2p.Message) error {
 	h.outgoingMessagesLock.Lock()
-	defer h.outgoingMessagesLock.Unlock()
 	msgChan, found := h.outgoingMessages[id]
+	h.outgoingMessagesLock.Unlock()
 	if !found {
 		return fmt.Errorf("not tracking peer %s", id)
 	}
-	msgChan <- msg
+
+	select {
+	case msgChan <- msg:
+	default:
+		if h.stats.IncreaseDroppedMessages(id) >= maxDroppedMessages {
+			h.log.Warnf("disconnecting slow peer %s", id)
+			_ = h.Disconnect(id)
+		}
+		return ErrorQueueFull
+	}
+
+	h.stats.IncreasePeerSentBytes(id, msg.PayloadLength())
+	return nil
+}
+
+// sendResponse queues the answer to a request of a peer. Unlike SendMessage it waits up to responseQueueTimeout
+// for the queue to have room, requests are handled by the receiving routine of the peer so waiting only delays
+// the next requests of the same peer.
+func (h *host) sendResponse(id peer.ID, msg p2p.Message) error {
+	h.outgoingMessagesLock.Lock()
+	msgChan, found := h.outgoingMessages[id]
+	h.outgoingMessagesLock.Unlock()
+	if !found {
+		return fmt.Errorf("not tracking peer %s", id)
+	}
+
+	timer := time.NewTimer(responseQueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case msgChan <- msg:
+	case <-timer.C:
+		return ErrorQueueFull
+	case <-h.ctx.Done():
+		return h.ctx.Err()
+	}
+
 	h.stats.IncreasePeerSentBytes(id, msg.PayloadLength())
 	return nil
 }
@@ -192,10 +548,16 @@ func (h *host) handleStream(s network.Stream) {
 	}
 }
 
-func (h *host) listenTopics() {
+// listenTopic delivers the validated messages of a topic to the registered handler. The handlers get the peer that
+// relayed the message to us, which is the one we can ask for missing data. Relayed finalization messages are
+// skipped, they describe the chain of a peer we may not be connected to.
+func (h *host) listenTopic(cmd string, sub *pubsub.Subscription) {
 	for {
-		msg, err := h.topicSub.Next(h.ctx)
+		msg, err := sub.Next(h.ctx)
 		if err != nil {
+			if h.ctx.Err() != nil {
+				return
+			}
 			continue
 		}
 
@@ -203,15 +565,14 @@ func (h *host) listenTopics() {
 			continue
 		}
 
-		buf := bytes.NewBuffer(msg.Data)
-
-		msgData, err := p2p.ReadMessage(buf, h.netMagic)
-		if err != nil {
-			h.log.Warnf("unable to decode message: %s", err)
+		if cmd == p2p.MsgFinalizationCmd && msg.GetFrom() != msg.ReceivedFrom {
 			continue
 		}
 
-		cmd := msgData.Command()
+		msgData, ok := msg.ValidatorData.(p2p.Message)
+		if !ok {
+			continue
+		}
 
 		h.topicHandlersLock.Lock()
 		handler, found := h.topicHandlers[cmd]
@@ -220,7 +581,7 @@ func (h *host) listenTopics() {
 			continue
 		}
 
-		err = handler(msg.GetFrom(), msgData)
+		err = handler(msg.ReceivedFrom, msgData)
 		if err != nil {
 			h.log.Error(err)
 		}
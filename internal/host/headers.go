@@ -0,0 +1,299 @@
+package host
+
+import (
+	"errors"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+var (
+	// ErrorHeadersNotLinked returns when the received headers don't form a chain connected to our chain.
+	ErrorHeadersNotLinked = errors.New("headers not linked to a known block")
+
+	// ErrorHeaderFutureSlot returns when a received header is for a slot that didn't start yet.
+	ErrorHeaderFutureSlot = errors.New("header slot in the future")
+
+	// ErrorHeaderSignature returns when the proposer signatures of the headers don't validate.
+	ErrorHeaderSignature = errors.New("invalid header signatures")
+
+	// ErrorBodyMismatch returns when a block body doesn't match the requested header.
+	ErrorBodyMismatch = errors.New("block doesn't match the requested header")
+
+	// ErrorMissingBodies returns when a peer doesn't send all the requested blocks.
+	ErrorMissingBodies = errors.New("peer didn't send all the requested blocks")
+
+	// ErrorEmptyBatch returns when a peer sends a batch without blocks before finishing a request.
+	ErrorEmptyBatch = errors.New("empty blocks batch before the end of the request")
+)
+
+// requestHeaders asks the peer for the headers after our main chain tip. It must be called holding requestLock.
+func (sp *synchronizer) requestHeaders(id peer.ID) error {
+	sp.requestID = sp.nextRequestID()
+	sp.headers = nil
+	sp.headersMore = false
+	sp.bodies = nil
+
+	err := sp.host.SendMessage(id, &p2p.MsgGetHeaders{
+		Version:   p2p.BlocksProtocolVersion,
+		RequestID: sp.requestID,
+		Locator:   sp.blockLocator(),
+		Count:     p2p.MaxHeadersPerMsg,
+	})
+	if err != nil {
+		return err
+	}
+
+	sp.startRequestTimer(sp.requestID)
+
+	return nil
+}
+
+// handleHeaders checks the headers answering our request and starts downloading the block bodies.
+func (sp *synchronizer) handleHeaders(id peer.ID, msg *p2p.MsgHeaders) error {
+	sp.requestLock.Lock()
+	defer sp.requestLock.Unlock()
+
+	if sp.withPeer != id || sp.requestID != msg.RequestID {
+		sp.log.Debugf("ignoring unrequested headers from peer %s", id)
+		return nil
+	}
+
+	if err := sp.checkHeadersChain(msg.Headers); err != nil {
+		sp.rejectPeer(id, err)
+		return nil
+	}
+
+	sp.headers = msg.Headers
+	sp.headersMore = msg.More
+
+	if err := sp.requestBodies(id); err != nil {
+		sp.rejectPeer(id, err)
+	}
+
+	return nil
+}
+
+// checkHeadersChain checks the headers are sorted, linked to each other and the first one is linked to a known block.
+func (sp *synchronizer) checkHeadersChain(headers []*p2p.SignedBlockHeader) error {
+	netParams := config.GlobalParams.NetParams
+
+	currentSlot := uint64(time.Now().Sub(sp.chain.GenesisTime())/time.Second) / netParams.SlotDuration
+
+	for i, h := range headers {
+		if h.Header == nil {
+			return ErrorHeadersNotLinked
+		}
+
+		if h.Header.Slot > currentSlot+1 {
+			return ErrorHeaderFutureSlot
+		}
+
+		if i == 0 {
+			if !sp.chain.State().Index().Have(h.Header.PrevBlockHash) {
+				return ErrorHeadersNotLinked
+			}
+			continue
+		}
+
+		prev := headers[i-1].Header
+		if prev.Hash() != chainhash.Hash(h.Header.PrevBlockHash) || prev.Slot >= h.Header.Slot {
+			return ErrorHeadersNotLinked
+		}
+	}
+
+	return nil
+}
+
+// verifyHeaders checks the proposer signatures of the first headers with a single aggregate verification.
+// The proposers are only known for the epoch of the first header and the next one, the amount of headers
+// verified is returned.
+func (sp *synchronizer) verifyHeaders(headers []*p2p.SignedBlockHeader) (int, error) {
+	netParams := config.GlobalParams.NetParams
+
+	parent := chainhash.Hash(headers[0].Header.PrevBlockHash)
+
+	view, err := sp.chain.State().GetSubView(parent)
+	if err != nil {
+		return 0, err
+	}
+
+	parentState, _, err := sp.chain.State().GetStateForHashAtSlot(parent, headers[0].Header.Slot, &view)
+	if err != nil {
+		return 0, err
+	}
+
+	var pubs []common.PublicKey
+	var msgs [][32]byte
+	var sigs []common.Signature
+
+	n := 0
+	for _, h := range headers {
+		if n == p2p.MaxRootsPerRequest {
+			break
+		}
+
+		pub, err := parentState.GetProposerPublicKeyAtSlot(h.Header.Slot)
+		if err == state.ErrorProposerUnknown {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		sig, err := bls.SignatureFromBytes(h.Signature[:])
+		if err != nil {
+			return 0, err
+		}
+
+		randaoSig, err := bls.SignatureFromBytes(h.RandaoSignature[:])
+		if err != nil {
+			return 0, err
+		}
+
+		blockHash := h.Header.SigningMessage(netParams)
+		slotHash := primitives.RANDAOMessage(netParams, h.Header.Slot)
+
+		pubs = append(pubs, pub, pub)
+		msgs = append(msgs, blockHash, slotHash)
+		sigs = append(sigs, sig, randaoSig)
+
+		n++
+	}
+
+	if n == 0 {
+		return 0, state.ErrorProposerUnknown
+	}
+
+	if !bls.AggregateSignatures(sigs).AggregateVerify(pubs, msgs) {
+		return 0, ErrorHeaderSignature
+	}
+
+	return n, nil
+}
+
+// requestBodies asks the peer for the blocks of the next verified headers. When all the headers are downloaded
+// more headers are requested or the sync finishes. It must be called holding requestLock.
+func (sp *synchronizer) requestBodies(id peer.ID) error {
+	for len(sp.headers) > 0 && sp.chain.State().Index().Have(sp.headers[0].Header.Hash()) {
+		sp.headers = sp.headers[1:]
+	}
+
+	if len(sp.headers) == 0 {
+		if sp.headersMore {
+			return sp.requestHeaders(id)
+		}
+		if !sp.synced.get() {
+			sp.log.Info("Sync finished. Waiting for the next block...")
+		}
+		sp.finishSync()
+		return nil
+	}
+
+	n, err := sp.verifyHeaders(sp.headers)
+	if err != nil {
+		return err
+	}
+
+	sp.bodies = sp.headers[:n]
+	sp.headers = sp.headers[n:]
+
+	roots := make([][32]byte, len(sp.bodies))
+	for i, h := range sp.bodies {
+		roots[i] = h.Header.Hash()
+	}
+
+	sp.requestID = sp.nextRequestID()
+
+	err = sp.host.SendMessage(id, &p2p.MsgGetBlocksByRoot{
+		Version:   p2p.BlocksProtocolVersion,
+		RequestID: sp.requestID,
+		Roots:     roots,
+	})
+	if err != nil {
+		return err
+	}
+
+	sp.startRequestTimer(sp.requestID)
+
+	return nil
+}
+
+// handleBodies checks the blocks match the verified headers and imports them. It must be called holding requestLock.
+func (sp *synchronizer) handleBodies(id peer.ID, msg *p2p.MsgBlocks) error {
+	// Every batch must make progress, empty batches would keep the request alive forever.
+	if len(msg.Blocks) == 0 && !msg.Done {
+		sp.rejectPeer(id, ErrorEmptyBatch)
+		return nil
+	}
+
+	for _, block := range msg.Blocks {
+		if len(sp.bodies) == 0 {
+			sp.rejectPeer(id, ErrorBodyMismatch)
+			return nil
+		}
+
+		if err := checkBody(block, sp.bodies[0]); err != nil {
+			sp.rejectPeer(id, err)
+			return nil
+		}
+
+		err := sp.processBlock(block, true)
+		if err != nil && err != ErrorBlockAlreadyKnown {
+			sp.rejectPeer(id, err)
+			return nil
+		}
+
+		sp.bodies = sp.bodies[1:]
+	}
+
+	if len(msg.Blocks) > 0 && sp.recentSynced.get() {
+		sp.recentSynced.set(false)
+	}
+
+	if !msg.Done {
+		sp.startRequestTimer(msg.RequestID)
+		return nil
+	}
+
+	if len(sp.bodies) > 0 {
+		sp.rejectPeer(id, ErrorMissingBodies)
+		return nil
+	}
+
+	sp.bodies = nil
+
+	if err := sp.requestBodies(id); err != nil {
+		sp.rejectPeer(id, err)
+	}
+
+	return nil
+}
+
+// checkBody checks the block is the one described by the signed header and the block content matches the header merkle roots.
+func checkBody(block *primitives.Block, header *p2p.SignedBlockHeader) error {
+	if block.Header == nil || block.Hash() != header.Header.Hash() {
+		return ErrorBodyMismatch
+	}
+
+	if block.Signature != header.Signature || block.RandaoSignature != header.RandaoSignature {
+		return ErrorBodyMismatch
+	}
+
+	return block.CheckMerkleRoots()
+}
+
+// rejectPeer penalizes a peer that sent invalid data and restarts the sync. It must be called holding requestLock.
+func (sp *synchronizer) rejectPeer(id peer.ID, err error) {
+	sp.log.Warnf("invalid sync data from peer %s: %s", id, err)
+	sp.host.PenalizePeer(id, badBatchPenalty)
+	sp.finishSync()
+	go sp.initialBlockDownload()
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p"
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
 	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
@@ -27,9 +28,13 @@ import (
 const connectionTimeout = 2000 * time.Millisecond
 const connectionWait = 60 * time.Second
 
-// MessageHandler is a handler for a specific message.
+// MessageHandler is a handler for a specific message. The id is the peer that sent the message to us.
 type MessageHandler func(id peer.ID, msg p2p.Message) error
 
+// MessageValidator checks a gossip message before it is delivered and relayed to other peers. The id is the peer
+// that sent the message to us.
+type MessageValidator func(id peer.ID, msg p2p.Message) ValidationResult
+
 type Host interface {
 	ID() peer.ID
 	Version() *p2p.MsgVersion
@@ -47,6 +52,7 @@ type Host interface {
 	HandleConnection(net network.Network, conn network.Conn)
 
 	RegisterTopicHandler(messageName string, handler MessageHandler)
+	RegisterTopicValidator(messageName string, validator MessageValidator)
 	Broadcast(msg p2p.Message) error
 
 	Stop()
@@ -59,6 +65,8 @@ type Host interface {
 	RemovePeerStats(id peer.ID)
 	AddPeerStats(id peer.ID, msg *p2p.MsgVersion, dir network.Direction)
 	IncreasePeerReceivedBytes(p peer.ID, amount uint64)
+	PenalizePeer(p peer.ID, score uint64)
+	IsTrusted(p peer.ID) bool
 }
 
 type host struct {
@@ -72,14 +80,17 @@ type host struct {
 	lastConnect     map[peer.ID]time.Time
 	lastConnectLock sync.Mutex
 
-	topic             *pubsub.Topic
-	topicSub          *pubsub.Subscription
+	pubsub            *pubsub.PubSub
+	topics            map[string]*pubsub.Topic
 	topicHandlersLock sync.Mutex
 	topicHandlers     map[string]MessageHandler
+	topicValidators   map[string]MessageValidator
 
 	outgoingMessages     map[peer.ID]chan p2p.Message
 	outgoingMessagesLock sync.Mutex
 
+	peering      *peering
+	limiter      *rateLimiter
 	stats        *stats
 	discovery    *discovery
 	synchronizer *synchronizer
@@ -101,8 +112,14 @@ func (h *host) Version() *p2p.MsgVersion {
 	buf := make([]byte, 8)
 	_, _ = rand.Read(buf)
 
+	genesisHash := h.chain.GenesisHash()
+
 	msg := &p2p.MsgVersion{
+		ProtocolVersion: p2p.ProtocolVersion,
+		GenesisHash:     genesisHash,
+		ForkDigest:      params.ForkDigest(genesisHash, config.GlobalParams.NetParams.ForkVersion),
 		Tip:             tip.Height,
+		TipSlot:         tip.Slot,
 		TipHash:         tip.Hash,
 		Nonce:           binary.LittleEndian.Uint64(buf),
 		Timestamp:       uint64(time.Now().Unix()),
@@ -117,7 +134,7 @@ func (h *host) Version() *p2p.MsgVersion {
 }
 
 func (h *host) Synced() bool {
-	if h.synchronizer.synced && !h.synchronizer.recentSynced {
+	if h.synchronizer.synced.get() && !h.synchronizer.recentSynced.get() {
 		return true
 	}
 	return false
@@ -187,6 +204,11 @@ func (h *host) Connect(pi peer.AddrInfo) error {
 }
 
 func (h *host) HandleConnection(_ network.Network, conn network.Conn) {
+	if banned, _ := h.stats.IsBanned(conn.RemotePeer()); banned {
+		_ = conn.Close()
+		return
+	}
+
 	if conn.Stat().Direction != network.DirOutbound {
 		return
 	}
@@ -215,7 +237,25 @@ func (h *host) RegisterTopicHandler(messageName string, handler MessageHandler)
 	return
 }
 
+// RegisterTopicValidator registers a validator for a msg type on the pubsub channel. The validator
+// runs after the message is decoded and before it is delivered or relayed.
+func (h *host) RegisterTopicValidator(messageName string, validator MessageValidator) {
+	h.topicHandlersLock.Lock()
+	defer h.topicHandlersLock.Unlock()
+	_, found := h.topicValidators[messageName]
+	if !found {
+		h.topicValidators[messageName] = validator
+	}
+	return
+}
+
+// Broadcast publishes a message on the topic of its type.
 func (h *host) Broadcast(msg p2p.Message) error {
+	topic, ok := h.topics[msg.Command()]
+	if !ok {
+		return ErrorUnknownTopic
+	}
+
 	buf := bytes.NewBuffer([]byte{})
 
 	err := p2p.WriteMessage(buf, msg, h.netMagic)
@@ -223,7 +263,7 @@ func (h *host) Broadcast(msg p2p.Message) error {
 	if err != nil {
 		return err
 	}
-	return h.topic.Publish(h.ctx, buf.Bytes())
+	return topic.Publish(h.ctx, buf.Bytes())
 }
 
 func (h *host) Stop() {
@@ -246,8 +286,10 @@ func (h *host) GetPeerStats(p peer.ID) (*peerStats, bool) {
 	return h.stats.GetPeerStats(p)
 }
 
+// RemovePeerStats removes the stats and the rate limits of a disconnected peer.
 func (h *host) RemovePeerStats(p peer.ID) {
 	h.stats.Remove(p)
+	h.limiter.remove(p)
 }
 
 func (h *host) AddPeerStats(p peer.ID, ver *p2p.MsgVersion, dir network.Direction) {
@@ -258,6 +300,19 @@ func (h *host) IncreasePeerReceivedBytes(p peer.ID, amount uint64) {
 	h.stats.IncreasePeerReceivedBytes(p, amount)
 }
 
+// PenalizePeer increases the ban score of a peer for misbehaviour. Trusted peers are never penalized.
+func (h *host) PenalizePeer(p peer.ID, score uint64) {
+	if h.IsTrusted(p) {
+		return
+	}
+	h.stats.AddBanScore(p, score)
+}
+
+// IsTrusted returns true if the peer is configured as trusted.
+func (h *host) IsTrusted(p peer.ID) bool {
+	return h.peering.isTrusted(p)
+}
+
 func NewHostNode(ch chain.Blockchain) (Host, error) {
 	ctx := config.GlobalParams.Context
 	log := config.GlobalParams.Logger
@@ -272,6 +327,13 @@ func NewHostNode(ch chain.Blockchain) (Host, error) {
 		chain:            ch,
 		lastConnect:      make(map[peer.ID]time.Time),
 		outgoingMessages: make(map[peer.ID]chan p2p.Message),
+		topics:           make(map[string]*pubsub.Topic),
+		topicValidators:  make(map[string]MessageValidator),
+		limiter: newRateLimiter(rateLimits{
+			peerMsgRate:    float64(config.GlobalFlags.PeerMsgRate),
+			peerByteRate:   float64(config.GlobalFlags.PeerByteRate),
+			globalByteRate: float64(config.GlobalFlags.GlobalByteRate),
+		}),
 	}
 
 	node.topicHandlers = map[string]MessageHandler{
@@ -294,52 +356,63 @@ func NewHostNode(ch chain.Blockchain) (Host, error) {
 		return nil, err
 	}
 
-	opts := buildOptions(priv, ps)
-	h, err := libp2p.New(
-		ctx,
-		opts...,
-	)
+	node.peering, err = newPeering(config.GlobalFlags.StaticPeers, config.GlobalFlags.TrustedPeers)
 	if err != nil {
 		return nil, err
 	}
 
-	node.host = h
-
-	for _, a := range h.Addrs() {
-		log.Infof("binding to address: %s", a)
-	}
+	connman := connmgr.NewConnManager(2, 64, time.Second*60)
+	node.peering.protect(connman)
 
-	g, err := pubsub.NewGossipSub(node.ctx, h)
+	opts, err := buildOptions(priv, ps, connman)
 	if err != nil {
 		return nil, err
 	}
 
-	node.topic, err = g.Join("pub_channel")
+	h, err := libp2p.New(
+		ctx,
+		opts...,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = node.topic.Relay()
-	if err != nil {
-		return nil, err
+	node.host = h
+
+	for _, a := range h.Addrs() {
+		log.Infof("binding to address: %s", a)
 	}
 
-	node.topicSub, err = node.topic.Subscribe()
+	s, err := NewStatsService(node)
 	if err != nil {
 		return nil, err
 	}
+	node.stats = s
 
-	d, err := NewDiscovery(node.ctx, node, node.host)
+	scoreParams, scoreThresholds := node.peerScoreParams(netParams.SlotDuration)
+
+	node.pubsub, err = pubsub.NewGossipSub(node.ctx, h,
+		pubsub.WithMaxMessageSize(maxGossipSize()),
+		pubsub.WithPeerScore(scoreParams, scoreThresholds),
+		pubsub.WithPeerScoreInspect(node.inspectPeerScores, scoreInspectPeriod),
+		pubsub.WithDirectPeers(node.peering.static),
+	)
 	if err != nil {
 		return nil, err
 	}
-	node.discovery = d
 
-	s, err := NewStatsService(node)
-	if err != nil {
-		return nil, err
+	if config.GlobalFlags.DevMode {
+		err = node.startMdns("ogen-" + netParams.Name)
+		if err != nil {
+			return nil, err
+		}
+	} else if !config.GlobalFlags.NoDiscovery {
+		d, err := NewDiscovery(node.ctx, node, node.host)
+		if err != nil {
+			return nil, err
+		}
+		node.discovery = d
 	}
-	node.stats = s
 
 	sy, err := NewSynchronizer(node, ch)
 	if err != nil {
@@ -347,13 +420,20 @@ func NewHostNode(ch chain.Blockchain) (Host, error) {
 	}
 	node.synchronizer = sy
 
+	node.topicValidators[p2p.MsgBlockCmd] = sy.validateBlock
+
 	n := NewNotify(node, s)
 
 	node.Notify(n)
 
 	node.SetStreamHandler(params.ProtocolID(netParams.Name), node.handleStream)
 
-	go node.listenTopics()
+	err = node.joinTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	go node.maintainStaticPeers()
 
 	return node, nil
 }
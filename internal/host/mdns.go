@@ -0,0 +1,39 @@
+package host
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	mdns "github.com/libp2p/go-libp2p/p2p/discovery"
+)
+
+// mdnsInterval is the interval between the mDNS queries for local peers.
+const mdnsInterval = time.Second * 10
+
+// mdnsNotifee connects to the peers found on the local network.
+type mdnsNotifee struct {
+	h *host
+}
+
+// HandlePeerFound implements the discovery Notifee interface.
+func (m *mdnsNotifee) HandlePeerFound(pi peer.AddrInfo) {
+	if pi.ID == m.h.ID() {
+		return
+	}
+
+	if err := m.h.Connect(pi); err != nil {
+		m.h.log.Debugf("unable to connect to local peer %s: %s", pi.ID, err)
+	}
+}
+
+// startMdns starts looking for peers of the same network on the local network.
+func (h *host) startMdns(serviceTag string) error {
+	s, err := mdns.NewMdnsService(h.ctx, h.host, mdnsInterval, serviceTag)
+	if err != nil {
+		return err
+	}
+
+	s.RegisterNotifee(&mdnsNotifee{h: h})
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package host
+
+import (
+	"sync"
+	"time"
+
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+const (
+	// maxOrphans is the maximum amount of blocks kept waiting for their parent.
+	maxOrphans = 64
+
+	// orphanExpirySlots is the amount of slots an orphan block is kept waiting for its parent.
+	orphanExpirySlots = 5
+)
+
+type orphanBlock struct {
+	block   *primitives.Block
+	hash    chainhash.Hash
+	expires time.Time
+}
+
+// orphanPool keeps the blocks received with an unknown parent until the parent is imported.
+type orphanPool struct {
+	lock sync.Mutex
+
+	expiry time.Duration
+
+	byHash   map[chainhash.Hash]*orphanBlock
+	byParent map[chainhash.Hash][]*orphanBlock
+}
+
+func newOrphanPool(slotDuration uint64) *orphanPool {
+	return &orphanPool{
+		expiry:   time.Duration(slotDuration*orphanExpirySlots) * time.Second,
+		byHash:   make(map[chainhash.Hash]*orphanBlock),
+		byParent: make(map[chainhash.Hash][]*orphanBlock),
+	}
+}
+
+// add adds a block to the pool. When the pool is full the orphan closest to expire is removed.
+func (o *orphanPool) add(block *primitives.Block) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	hash := block.Hash()
+	if _, ok := o.byHash[hash]; ok {
+		return
+	}
+
+	o.expire()
+
+	if len(o.byHash) >= maxOrphans {
+		var oldest *orphanBlock
+		for _, ob := range o.byHash {
+			if oldest == nil || ob.expires.Before(oldest.expires) {
+				oldest = ob
+			}
+		}
+		o.remove(oldest)
+	}
+
+	ob := &orphanBlock{
+		block:   block,
+		hash:    hash,
+		expires: time.Now().Add(o.expiry),
+	}
+
+	parent := chainhash.Hash(block.Header.PrevBlockHash)
+	o.byHash[hash] = ob
+	o.byParent[parent] = append(o.byParent[parent], ob)
+}
+
+// have returns true if the block is on the pool.
+func (o *orphanPool) have(hash chainhash.Hash) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	_, ok := o.byHash[hash]
+	return ok
+}
+
+// size returns the amount of orphans on the pool.
+func (o *orphanPool) size() int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return len(o.byHash)
+}
+
+// takeChildren removes and returns the orphans with the specified parent.
+func (o *orphanPool) takeChildren(parent chainhash.Hash) []*primitives.Block {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.expire()
+
+	children := o.byParent[parent]
+	blocks := make([]*primitives.Block, 0, len(children))
+	for _, ob := range children {
+		blocks = append(blocks, ob.block)
+		delete(o.byHash, ob.hash)
+	}
+	delete(o.byParent, parent)
+
+	return blocks
+}
+
+// missingAncestor returns the hash of the first unknown ancestor of an orphan.
+func (o *orphanPool) missingAncestor(hash chainhash.Hash) chainhash.Hash {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	for {
+		ob, ok := o.byHash[hash]
+		if !ok {
+			return hash
+		}
+		hash = ob.block.Header.PrevBlockHash
+	}
+}
+
+// missingParents returns the hashes of the missing ancestors of all the orphans.
+func (o *orphanPool) missingParents() [][32]byte {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.expire()
+
+	var parents [][32]byte
+	for parent := range o.byParent {
+		if _, ok := o.byHash[parent]; !ok {
+			parents = append(parents, parent)
+		}
+	}
+
+	return parents
+}
+
+// expire removes the expired orphans. It must be called holding the lock.
+func (o *orphanPool) expire() {
+	now := time.Now()
+	for _, ob := range o.byHash {
+		if now.After(ob.expires) {
+			o.remove(ob)
+		}
+	}
+}
+
+// remove deletes an orphan from the pool. It must be called holding the lock.
+func (o *orphanPool) remove(ob *orphanBlock) {
+	delete(o.byHash, ob.hash)
+
+	parent := chainhash.Hash(ob.block.Header.PrevBlockHash)
+	siblings := o.byParent[parent]
+	for i, s := range siblings {
+		if s == ob {
+			siblings = append(siblings[:i], siblings[i+1:]...)
+			break
+		}
+	}
+	if len(siblings) == 0 {
+		delete(o.byParent, parent)
+	} else {
+		o.byParent[parent] = siblings
+	}
+}
@@ -0,0 +1,122 @@
+package host
+
+import (
+	"os"
+	"time"
+
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/pnet"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// staticPeersInterval is the interval between the checks to reconnect the static peers.
+	staticPeersInterval = time.Second * 30
+
+	staticPeerTag  = "static"
+	trustedPeerTag = "trusted"
+)
+
+// peering contains the peers configured by the user.
+type peering struct {
+	// static peers are always reconnected.
+	static []peer.AddrInfo
+
+	// trusted peers are never banned nor pruned by the connection manager.
+	trusted map[peer.ID]struct{}
+}
+
+// newPeering parses the static peers multiaddresses and the trusted peers. Trusted peers can be
+// specified by id or by multiaddress.
+func newPeering(static []string, trusted []string) (*peering, error) {
+	p := &peering{
+		trusted: make(map[peer.ID]struct{}),
+	}
+
+	for _, s := range static {
+		pi, err := parsePeerAddr(s)
+		if err != nil {
+			return nil, err
+		}
+		p.static = append(p.static, *pi)
+	}
+
+	for _, t := range trusted {
+		id, err := peer.Decode(t)
+		if err != nil {
+			pi, err := parsePeerAddr(t)
+			if err != nil {
+				return nil, err
+			}
+			id = pi.ID
+		}
+		p.trusted[id] = struct{}{}
+	}
+
+	return p, nil
+}
+
+// parsePeerAddr parses a multiaddress containing the peer id.
+func parsePeerAddr(s string) (*peer.AddrInfo, error) {
+	addr, err := ma.NewMultiaddr(s)
+	if err != nil {
+		return nil, err
+	}
+	return peer.AddrInfoFromP2pAddr(addr)
+}
+
+// isTrusted returns true if the peer is a trusted peer.
+func (p *peering) isTrusted(id peer.ID) bool {
+	_, ok := p.trusted[id]
+	return ok
+}
+
+// protect excludes the static and trusted peers from the connection manager limits.
+func (p *peering) protect(cm *connmgr.BasicConnMgr) {
+	for _, pi := range p.static {
+		cm.Protect(pi.ID, staticPeerTag)
+	}
+	for id := range p.trusted {
+		cm.Protect(id, trustedPeerTag)
+	}
+}
+
+// maintainStaticPeers connects to the static peers and reconnects them when the connection is lost.
+func (h *host) maintainStaticPeers() {
+	if len(h.peering.static) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(staticPeersInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, pi := range h.peering.static {
+			if h.host.Network().Connectedness(pi.ID) == network.Connected {
+				continue
+			}
+			if err := h.Connect(pi); err != nil {
+				h.log.Debugf("unable to connect to static peer %s: %s", pi.ID, err)
+			}
+		}
+
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadPSK loads the private network pre-shared key from a swarm key file.
+func loadPSK(file string) (pnet.PSK, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return pnet.DecodeV1PSK(f)
+}
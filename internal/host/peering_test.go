@@ -0,0 +1,113 @@
+package host
+
+import (
+	"crypto/rand"
+	"os"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPeerID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	assert.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	assert.NoError(t, err)
+	return id
+}
+
+func Test_NewPeering(t *testing.T) {
+	static := newPeerID(t)
+	trustedByID := newPeerID(t)
+	trustedByAddr := newPeerID(t)
+
+	p, err := newPeering(
+		[]string{"/ip4/127.0.0.1/tcp/24126/p2p/" + static.String()},
+		[]string{trustedByID.String(), "/ip4/127.0.0.1/tcp/24127/p2p/" + trustedByAddr.String()},
+	)
+	assert.NoError(t, err)
+
+	if assert.Len(t, p.static, 1) {
+		assert.Equal(t, static, p.static[0].ID)
+		assert.Equal(t, "/ip4/127.0.0.1/tcp/24126", p.static[0].Addrs[0].String())
+	}
+
+	assert.True(t, p.isTrusted(trustedByID))
+	assert.True(t, p.isTrusted(trustedByAddr))
+	assert.False(t, p.isTrusted(static))
+}
+
+func Test_NewPeeringInvalid(t *testing.T) {
+	id := newPeerID(t)
+
+	// Static peers need the peer id.
+	_, err := newPeering([]string{"/ip4/127.0.0.1/tcp/24126"}, nil)
+	assert.Error(t, err)
+
+	_, err = newPeering([]string{"not a multiaddress"}, nil)
+	assert.Error(t, err)
+
+	_, err = newPeering(nil, []string{"not a peer"})
+	assert.Error(t, err)
+
+	_, err = newPeering(nil, []string{"/ip4/127.0.0.1/tcp/24126", id.String()})
+	assert.Error(t, err)
+}
+
+// peeringHost is a host with trusted peers that records the disconnected peers.
+type peeringHost struct {
+	Host
+
+	peering      *peering
+	disconnected []peer.ID
+}
+
+func (h *peeringHost) IsTrusted(p peer.ID) bool {
+	return h.peering.isTrusted(p)
+}
+
+func (h *peeringHost) Disconnect(p peer.ID) error {
+	h.disconnected = append(h.disconnected, p)
+	return nil
+}
+
+func Test_TrustedPeerExemption(t *testing.T) {
+	trusted := newPeerID(t)
+	untrusted := newPeerID(t)
+
+	p, err := newPeering(nil, []string{trusted.String()})
+	assert.NoError(t, err)
+
+	bans, err := openBanStore(t.TempDir())
+	assert.NoError(t, err)
+	defer bans.close()
+
+	ph := &peeringHost{peering: p}
+	s := &stats{log: logger.New(os.Stdout), bans: bans, h: ph}
+	h := &host{peering: p, stats: s}
+
+	for _, id := range []peer.ID{trusted, untrusted} {
+		s.Add(id, &p2p.MsgVersion{}, network.DirInbound)
+	}
+
+	h.PenalizePeer(trusted, maxBanScore)
+	ps, _ := s.GetPeerStats(trusted)
+	assert.Zero(t, ps.BanScore)
+
+	s.BanPeer(trusted)
+	banned, err := s.IsBanned(trusted)
+	assert.NoError(t, err)
+	assert.False(t, banned)
+	assert.Empty(t, ph.disconnected)
+
+	h.PenalizePeer(untrusted, maxBanScore)
+	banned, err = s.IsBanned(untrusted)
+	assert.NoError(t, err)
+	assert.True(t, banned)
+	assert.Equal(t, []peer.ID{untrusted}, ph.disconnected)
+}
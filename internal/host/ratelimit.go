@@ -0,0 +1,263 @@
+package host
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+)
+
+const (
+	// outgoingQueueSize is the amount of messages queued for a peer before new messages are dropped.
+	outgoingQueueSize = 256
+
+	// maxDroppedMessages is the amount of messages dropped for a slow peer before it is disconnected.
+	maxDroppedMessages = 64
+
+	// droppedMessagesDecay is the time it takes to forget a dropped message. Only a peer that keeps falling
+	// behind reaches maxDroppedMessages.
+	droppedMessagesDecay = time.Second
+
+	// responseQueueTimeout is the time to wait for room in the outgoing queue of a peer to answer its requests.
+	// The peer stops being answered when it doesn't read its messages in time.
+	responseQueueTimeout = time.Second * 10
+
+	// rateLimitPenalty is the ban score added to a peer for every message over the rate limits.
+	rateLimitPenalty = 2
+
+	// requestMsgRate is the amount of block and header requests per second allowed for a peer.
+	requestMsgRate = 4
+)
+
+// requestCommands are the commands that make the node read blocks from the database. Their rate is
+// limited to requestMsgRate per peer.
+var requestCommands = map[string]struct{}{
+	p2p.MsgGetBlocksCmd:        {},
+	p2p.MsgGetBlocksByRangeCmd: {},
+	p2p.MsgGetBlocksByRootCmd:  {},
+	p2p.MsgGetHeadersCmd:       {},
+}
+
+// responseCommands are the commands answering our requests. They arrive in bursts, so they are only
+// limited by the peer and global byte rates.
+var responseCommands = map[string]struct{}{
+	p2p.MsgBlocksCmd:  {},
+	p2p.MsgHeadersCmd: {},
+}
+
+// commandByteRates are the bytes per second of each command a peer can send. They keep a peer from using
+// all of its byte rate with a single kind of message. Commands not listed are only limited by the peer and
+// global byte rates.
+var commandByteRates = map[string]float64{
+	p2p.MsgVersionCmd:          4 * 1024,
+	p2p.MsgGetBlocksCmd:        4 * 1024,
+	p2p.MsgGetBlocksByRangeCmd: 64 * 1024,
+	p2p.MsgGetBlocksByRootCmd:  64 * 1024,
+	p2p.MsgGetHeadersCmd:       64 * 1024,
+	p2p.MsgFinalizationCmd:     4 * 1024,
+	p2p.MsgBlockCmd:            2 * 1024 * 1024,
+	p2p.MsgVoteCmd:             512 * 1024,
+	p2p.MsgTxCmd:               256 * 1024,
+	p2p.MsgDepositsCmd:         256 * 1024,
+	p2p.MsgExitsCmd:            256 * 1024,
+	p2p.MsgPartialExitsCmd:     256 * 1024,
+	p2p.MsgSlashingsCmd:        256 * 1024,
+}
+
+// tokenBucket allows an average rate of tokens per second with bursts up to the bucket size. A take is
+// allowed while the bucket is not empty and can leave the bucket in debt, so messages bigger than the
+// bucket are delayed instead of rejected forever. A single take never removes more than the bucket size.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  rate,
+		tokens: rate,
+		last:   now,
+	}
+}
+
+// ready refills the bucket up to now and returns false if it is empty.
+func (b *tokenBucket) ready(now time.Time) bool {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	return b.tokens > 0
+}
+
+// charge removes n tokens from the bucket, up to the bucket size.
+func (b *tokenBucket) charge(n float64) {
+	if n > b.burst {
+		n = b.burst
+	}
+	b.tokens -= n
+}
+
+// take removes n tokens from the bucket after refilling it up to now. It returns false if the bucket is empty.
+func (b *tokenBucket) take(n float64, now time.Time) bool {
+	if !b.ready(now) {
+		return false
+	}
+	b.charge(n)
+	return true
+}
+
+// rateLimit is the limit that refused a message.
+type rateLimit int
+
+const (
+	// limitNone is returned for the messages within all the limits.
+	limitNone rateLimit = iota
+
+	// limitCommand is returned for the messages over the message or byte rate of their command.
+	limitCommand
+
+	// limitPeer is returned for the messages over the byte rate of the peer.
+	limitPeer
+
+	// limitGlobal is returned for the messages over the byte rate of all the peers. The peer sending them may
+	// be within its own limits.
+	limitGlobal
+)
+
+// rateLimits are the configured limits. A zero limit disables it.
+type rateLimits struct {
+	// peerMsgRate is the amount of messages per second of a single command a peer can send.
+	peerMsgRate float64
+
+	// peerByteRate is the amount of bytes per second a peer can send.
+	peerByteRate float64
+
+	// globalByteRate is the amount of bytes per second received from all the peers.
+	globalByteRate float64
+}
+
+// commandLimiter limits the messages and bytes of a single command. A nil bucket is unlimited.
+type commandLimiter struct {
+	msgs  *tokenBucket
+	bytes *tokenBucket
+}
+
+type peerLimiter struct {
+	bytes    *tokenBucket
+	commands map[string]*commandLimiter
+}
+
+// rateLimiter limits the incoming traffic per peer, per command and for all the peers.
+type rateLimiter struct {
+	lock sync.Mutex
+
+	limits rateLimits
+	global *tokenBucket
+	peers  map[peer.ID]*peerLimiter
+
+	// now returns the current time, it is replaced by the tests.
+	now func() time.Time
+}
+
+func newRateLimiter(limits rateLimits) *rateLimiter {
+	r := &rateLimiter{
+		limits: limits,
+		peers:  make(map[peer.ID]*peerLimiter),
+		now:    time.Now,
+	}
+	if limits.globalByteRate > 0 {
+		r.global = newTokenBucket(limits.globalByteRate, r.now())
+	}
+	return r
+}
+
+// limit returns the limit a message received from a peer is over, or limitNone if it is within all of them.
+// The size is the payload length announced by the message header, so the limits are charged before the
+// payload is read. The limits are only charged when all of them allow the message.
+func (r *rateLimiter) limit(id peer.ID, cmd string, size uint64) rateLimit {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+
+	pl, ok := r.peers[id]
+	if !ok {
+		pl = &peerLimiter{commands: make(map[string]*commandLimiter)}
+		if r.limits.peerByteRate > 0 {
+			pl.bytes = newTokenBucket(r.limits.peerByteRate, now)
+		}
+		r.peers[id] = pl
+	}
+
+	cl, ok := pl.commands[cmd]
+	if !ok {
+		cl = r.newCommandLimiter(cmd, now)
+		pl.commands[cmd] = cl
+	}
+
+	if cl.msgs != nil && !cl.msgs.ready(now) {
+		return limitCommand
+	}
+	if cl.bytes != nil && !cl.bytes.ready(now) {
+		return limitCommand
+	}
+	if pl.bytes != nil && !pl.bytes.ready(now) {
+		return limitPeer
+	}
+	if r.global != nil && !r.global.ready(now) {
+		return limitGlobal
+	}
+
+	if cl.msgs != nil {
+		cl.msgs.charge(1)
+	}
+	for _, b := range []*tokenBucket{cl.bytes, pl.bytes, r.global} {
+		if b != nil {
+			b.charge(float64(size))
+		}
+	}
+
+	return limitNone
+}
+
+// allow returns true if a message received from a peer is within the limits.
+func (r *rateLimiter) allow(id peer.ID, cmd string, size uint64) bool {
+	return r.limit(id, cmd, size) == limitNone
+}
+
+func (r *rateLimiter) newCommandLimiter(cmd string, now time.Time) *commandLimiter {
+	cl := new(commandLimiter)
+
+	if _, isResponse := responseCommands[cmd]; isResponse {
+		return cl
+	}
+
+	rate := r.limits.peerMsgRate
+	if _, isRequest := requestCommands[cmd]; isRequest {
+		rate = requestMsgRate
+	}
+	if rate > 0 {
+		cl.msgs = newTokenBucket(rate, now)
+	}
+
+	if byteRate, ok := commandByteRates[cmd]; ok && r.limits.peerByteRate > 0 {
+		cl.bytes = newTokenBucket(byteRate, now)
+	}
+
+	return cl
+}
+
+// remove removes the limiter of a disconnected peer.
+func (r *rateLimiter) remove(id peer.ID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.peers, id)
+}
@@ -0,0 +1,222 @@
+package host
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, now)
+
+	// The bucket starts full and allows a burst of its rate.
+	for i := 0; i < 10; i++ {
+		assert.True(t, b.take(1, now))
+	}
+	assert.False(t, b.take(1, now))
+
+	// Half a second refills half of the bucket.
+	now = now.Add(500 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		assert.True(t, b.take(1, now))
+	}
+	assert.False(t, b.take(1, now))
+
+	// The refill never goes over the burst.
+	now = now.Add(time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.True(t, b.take(1, now))
+	}
+	assert.False(t, b.take(1, now))
+
+	// A time before the last take doesn't change the bucket.
+	assert.False(t, b.take(1, now.Add(-time.Hour)))
+}
+
+func Test_TokenBucketDebt(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, now)
+
+	// A take bigger than the bucket is allowed and empties it.
+	assert.True(t, b.take(5, now))
+	assert.True(t, b.take(25, now))
+	assert.False(t, b.take(1, now))
+
+	// The debt is never bigger than the bucket, it is paid in a second.
+	now = now.Add(500 * time.Millisecond)
+	assert.False(t, b.take(1, now))
+
+	now = now.Add(600 * time.Millisecond)
+	assert.True(t, b.take(1, now))
+}
+
+// newTestRateLimiter returns a rate limiter with a stopped clock.
+func newTestRateLimiter(limits rateLimits) *rateLimiter {
+	r := newRateLimiter(limits)
+	now := time.Now()
+	r.now = func() time.Time {
+		return now
+	}
+	return r
+}
+
+func Test_RateLimiterCommands(t *testing.T) {
+	r := newTestRateLimiter(rateLimits{peerMsgRate: 50, peerByteRate: 4 * 1024 * 1024})
+
+	for i := 0; i < 50; i++ {
+		assert.True(t, r.allow("peer", p2p.MsgVoteCmd, 100))
+	}
+	assert.False(t, r.allow("peer", p2p.MsgVoteCmd, 100))
+
+	// Every command and every peer has its own limits.
+	assert.True(t, r.allow("peer", p2p.MsgTxCmd, 100))
+	assert.True(t, r.allow("other", p2p.MsgVoteCmd, 100))
+
+	for i := 0; i < requestMsgRate; i++ {
+		assert.True(t, r.allow("peer", p2p.MsgGetBlocksByRangeCmd, 100))
+	}
+	assert.False(t, r.allow("peer", p2p.MsgGetBlocksByRangeCmd, 100))
+
+	// Responses are only limited by the byte rates.
+	for i := 0; i < 1000; i++ {
+		assert.True(t, r.allow("peer", p2p.MsgBlocksCmd, 100))
+	}
+}
+
+func Test_RateLimiterBytes(t *testing.T) {
+	r := newTestRateLimiter(rateLimits{peerMsgRate: 50, peerByteRate: 1024 * 1024, globalByteRate: 2 * 1024 * 1024})
+
+	// The command byte rate is reached before the message rate.
+	assert.True(t, r.allow("peer", p2p.MsgVersionCmd, uint64(commandByteRates[p2p.MsgVersionCmd])))
+	assert.False(t, r.allow("peer", p2p.MsgVersionCmd, 1))
+
+	// The peer byte rate is shared by all the commands.
+	assert.True(t, r.allow("peer", p2p.MsgBlocksCmd, 1024*1024))
+	assert.False(t, r.allow("peer", p2p.MsgHeadersCmd, 1))
+
+	// The global byte rate is shared by all the peers.
+	assert.True(t, r.allow("other", p2p.MsgBlocksCmd, 1024*1024))
+	assert.False(t, r.allow("third", p2p.MsgBlocksCmd, 1))
+}
+
+func Test_RateLimiterLimits(t *testing.T) {
+	r := newTestRateLimiter(rateLimits{peerMsgRate: 2, peerByteRate: 1024 * 1024, globalByteRate: 1024 * 1024})
+
+	assert.Equal(t, limitNone, r.limit("peer", p2p.MsgVoteCmd, 100))
+	assert.Equal(t, limitNone, r.limit("peer", p2p.MsgVoteCmd, 100))
+	assert.Equal(t, limitCommand, r.limit("peer", p2p.MsgVoteCmd, 100))
+
+	assert.Equal(t, limitNone, r.limit("peer", p2p.MsgBlocksCmd, 1024*1024-200))
+	assert.Equal(t, limitPeer, r.limit("peer", p2p.MsgTxCmd, 100))
+	assert.Equal(t, limitGlobal, r.limit("other", p2p.MsgTxCmd, 100))
+}
+
+func Test_RateLimiterChargesAllowedMessages(t *testing.T) {
+	r := newTestRateLimiter(rateLimits{peerMsgRate: 1, peerByteRate: 1024 * 1024})
+
+	assert.True(t, r.allow("peer", p2p.MsgBlocksCmd, 1024*1024))
+
+	// A message refused by the peer byte rate doesn't use the rates of its command.
+	assert.Equal(t, limitPeer, r.limit("peer", p2p.MsgTxCmd, 100))
+	cl := r.peers["peer"].commands[p2p.MsgTxCmd]
+	assert.Equal(t, float64(1), cl.msgs.tokens)
+	assert.Equal(t, commandByteRates[p2p.MsgTxCmd], cl.bytes.tokens)
+}
+
+func Test_RateLimiterDisabled(t *testing.T) {
+	r := newTestRateLimiter(rateLimits{})
+
+	for i := 0; i < 1000; i++ {
+		assert.True(t, r.allow("peer", p2p.MsgVersionCmd, 1024*1024))
+	}
+
+	// The request rate is always limited.
+	for i := 0; i < requestMsgRate; i++ {
+		assert.True(t, r.allow("peer", p2p.MsgGetHeadersCmd, 100))
+	}
+	assert.False(t, r.allow("peer", p2p.MsgGetHeadersCmd, 100))
+}
+
+func Test_ProcessMessagesSkipsPayload(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+
+	throttled := &p2p.MsgGetBlocks{LastBlockHash: [32]byte{1}}
+	allowed := &p2p.MsgGetBlocks{LastBlockHash: [32]byte{2}}
+	assert.NoError(t, p2p.WriteMessage(buf, throttled, 1))
+	assert.NoError(t, p2p.WriteMessage(buf, allowed, 1))
+
+	headers := 0
+	allow := func(header *p2p.MessageHeader, requestID uint64) bool {
+		headers++
+		assert.Equal(t, uint64(0), requestID)
+		assert.Equal(t, p2p.MsgGetBlocksCmd, header.CommandString())
+		assert.Equal(t, throttled.PayloadLength(), header.Length)
+		return headers > 1
+	}
+
+	var received []p2p.Message
+	err := processMessages(context.Background(), 1, buf, allow, func(msg p2p.Message) error {
+		received = append(received, msg)
+		return nil
+	})
+
+	// The stream ends after the second message.
+	assert.Error(t, err)
+	assert.Equal(t, 2, headers)
+	assert.Equal(t, []p2p.Message{allowed}, received)
+}
+
+func Test_ProcessMessagesRequestID(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+
+	throttled := &p2p.MsgBlocks{RequestID: 7}
+	allowed := &p2p.MsgHeaders{RequestID: 8, Headers: []*p2p.SignedBlockHeader{}, More: true}
+	assert.NoError(t, p2p.WriteMessage(buf, throttled, 1))
+	assert.NoError(t, p2p.WriteMessage(buf, allowed, 1))
+
+	var requestIDs []uint64
+	allow := func(header *p2p.MessageHeader, requestID uint64) bool {
+		requestIDs = append(requestIDs, requestID)
+		return requestID == allowed.RequestID
+	}
+
+	var received []p2p.Message
+	err := processMessages(context.Background(), 1, buf, allow, func(msg p2p.Message) error {
+		received = append(received, msg)
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, []uint64{7, 8}, requestIDs)
+	assert.Equal(t, []p2p.Message{allowed}, received)
+}
+
+func Test_ProcessMessagesPayloadTooBig(t *testing.T) {
+	msg := &p2p.MsgGetBlocks{}
+	buf := bytes.NewBuffer([]byte{})
+	assert.NoError(t, p2p.WriteMessage(buf, msg, 1))
+
+	// Announce a payload bigger than the command allows.
+	b := buf.Bytes()
+	header := new(p2p.MessageHeader)
+	assert.NoError(t, header.Unmarshal(b[:p2p.MessageHeaderSize]))
+	header.Length = msg.MaxPayloadLength() + 1
+	hb, err := header.Marshal()
+	assert.NoError(t, err)
+	copy(b, hb)
+
+	allow := func(header *p2p.MessageHeader, requestID uint64) bool {
+		t.Fatal("the limits are checked for a payload over the maximum")
+		return false
+	}
+
+	err = processMessages(context.Background(), 1, bytes.NewBuffer(b), allow, func(msg p2p.Message) error {
+		return nil
+	})
+	assert.Equal(t, p2p.ErrorSizeExceed, err)
+}
@@ -0,0 +1,117 @@
+package host
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+)
+
+const (
+	// maxBanScore is the application ban score that bans a peer.
+	maxBanScore = 500
+
+	// banScoreDecay is the time it takes the ban score of a peer to decrease by one point. A peer reaching the
+	// maximum ban score is forgiven after 50 minutes.
+	banScoreDecay = time.Second * 6
+
+	// scoreInspectPeriod is the interval between the gossip score checks.
+	scoreInspectPeriod = time.Second * 10
+
+	// gossipThreshold is the score below which no gossip is exchanged with a peer.
+	gossipThreshold = -100
+
+	// publishThreshold is the score below which our messages are not published to a peer.
+	publishThreshold = -200
+
+	// graylistThreshold is the score below which the messages of a peer are ignored.
+	graylistThreshold = -400
+
+	// gossipBanThreshold is the score that bans a peer. It is reached by the application ban score alone
+	// or by invalid message deliveries on any topic.
+	gossipBanThreshold = -maxBanScore
+)
+
+// topicWeights defines the weight of every gossip topic on the peer score.
+var topicWeights = map[string]float64{
+	p2p.MsgBlockCmd:        0.8,
+	p2p.MsgVoteCmd:         0.5,
+	p2p.MsgTxCmd:           0.2,
+	p2p.MsgDepositsCmd:     0.2,
+	p2p.MsgExitsCmd:        0.2,
+	p2p.MsgPartialExitsCmd: 0.2,
+	p2p.MsgSlashingsCmd:    0.2,
+	p2p.MsgFinalizationCmd: 0.2,
+}
+
+// peerScoreParams returns the gossipsub peer scoring for our topics. Peers gain score by staying in the mesh
+// and being the first to deliver messages, and lose score for messages rejected by the topic validators. The
+// application ban score is added as the app specific score.
+func (h *host) peerScoreParams(slotDuration uint64) (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds) {
+	slot := time.Duration(slotDuration) * time.Second
+
+	topics := make(map[string]*pubsub.TopicScoreParams)
+	for _, msg := range gossipMessages {
+		cmd := msg.Command()
+		topics[topicName(cmd)] = &pubsub.TopicScoreParams{
+			TopicWeight: topicWeights[cmd],
+
+			TimeInMeshWeight:  0.03,
+			TimeInMeshQuantum: slot,
+			TimeInMeshCap:     300,
+
+			FirstMessageDeliveriesWeight: 1,
+			FirstMessageDeliveriesDecay:  pubsub.ScoreParameterDecay(20 * slot),
+			FirstMessageDeliveriesCap:    50,
+
+			InvalidMessageDeliveriesWeight: -200,
+			InvalidMessageDeliveriesDecay:  pubsub.ScoreParameterDecay(50 * slot),
+		}
+	}
+
+	params := &pubsub.PeerScoreParams{
+		Topics:        topics,
+		TopicScoreCap: 100,
+
+		AppSpecificScore:  h.appScore,
+		AppSpecificWeight: 1,
+
+		DecayInterval: pubsub.DefaultDecayInterval,
+		DecayToZero:   pubsub.DefaultDecayToZero,
+		RetainScore:   banPeerTimePenalization,
+	}
+
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             gossipThreshold,
+		PublishThreshold:            publishThreshold,
+		GraylistThreshold:           graylistThreshold,
+		AcceptPXThreshold:           10,
+		OpportunisticGraftThreshold: 2,
+	}
+
+	return params, thresholds
+}
+
+// appScore returns the application ban score of a peer as a negative gossip score. Trusted peers have no penalty.
+func (h *host) appScore(p peer.ID) float64 {
+	if h.IsTrusted(p) {
+		return 0
+	}
+	ps, ok := h.stats.GetPeerStats(p)
+	if !ok {
+		return 0
+	}
+	return -float64(ps.BanScore)
+}
+
+// inspectPeerScores stores the gossip score of every peer and bans the peers below the ban threshold.
+func (h *host) inspectPeerScores(scores map[peer.ID]float64) {
+	for p, score := range scores {
+		h.stats.SetGossipScore(p, score)
+		if score <= gossipBanThreshold {
+			h.log.Warnf("banning peer %s with gossip score %.2f", p, score)
+			h.stats.BanPeer(p)
+		}
+	}
+}
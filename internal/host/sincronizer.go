@@ -9,11 +9,19 @@ import (
 	"github.com/olympus-protocol/ogen/pkg/logger"
 	"github.com/olympus-protocol/ogen/pkg/p2p"
 	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const MinPeersForSyncStart = 3
 
+// MinPeersForDevSyncStart is the amount of peers required to start the sync on dev mode.
+const MinPeersForDevSyncStart = 1
+
+// requestTimeout is the time to wait for the next batch of a block request before giving up on the peer.
+const requestTimeout = time.Second * 30
+
 var (
 	// ErrorBlockAlreadyKnown returns when received a block already known
 	ErrorBlockAlreadyKnown = errors.New("block already known")
@@ -22,6 +30,22 @@ var (
 	ErrorBlockParentUnknown = errors.New("unknown block parent")
 )
 
+// syncFlag is a sync state flag safe for concurrent use, it is read by the gossip validators without holding
+// the request lock.
+type syncFlag int32
+
+func (f *syncFlag) get() bool {
+	return atomic.LoadInt32((*int32)(f)) == 1
+}
+
+func (f *syncFlag) set(v bool) {
+	n := int32(0)
+	if v {
+		n = 1
+	}
+	atomic.StoreInt32((*int32)(f), n)
+}
+
 type synchronizer struct {
 	host Host
 	ctx  context.Context
@@ -29,27 +53,76 @@ type synchronizer struct {
 
 	chain chain.Blockchain
 
-	synced          bool
-	recentSynced    bool
-	withPeer        peer.ID
-	blockStallTimer *time.Timer
+	synced       syncFlag
+	recentSynced syncFlag
+
+	// requestLock protects the state of the in-flight block request.
+	requestLock  sync.Mutex
+	withPeer     peer.ID
+	requestID    uint64
+	requestTimer *time.Timer
+	downloader   *downloader
+
+	// headers are the verified headers pending to download, bodies the headers of the requested block bodies.
+	headers     []*p2p.SignedBlockHeader
+	headersMore bool
+	bodies      []*p2p.SignedBlockHeader
+
+	requestCounter uint64
+
+	orphans *orphanPool
 
 	lastFinalizedEpoch uint64
 }
 
 func (sp *synchronizer) initialBlockDownload() {
 
+	minPeers := MinPeersForSyncStart
+	if config.GlobalFlags.DevMode {
+		minPeers = MinPeersForDevSyncStart
+	}
+
 	for {
 		time.Sleep(time.Second * 1)
-		if sp.host.TrackedPeers() < MinPeersForSyncStart {
+		if sp.host.TrackedPeers() < minPeers {
 			continue
 		}
 		break
 	}
 
+	sp.synced.set(false)
+
+	// Download the finalized part of the chain from all the peers at the same time.
+	finalized, _ := sp.chain.State().GetFinalizedHead()
+	target := uint64(0)
+	for _, p := range sp.host.GetPeersInfo() {
+		if p.ChainStats != nil && p.ChainStats.FinalizedSlot > target {
+			target = p.ChainStats.FinalizedSlot
+		}
+	}
+
+	if target > finalized.Slot {
+		sp.log.Infof("downloading slots %d-%d from peers", finalized.Slot+1, target)
+
+		d := newDownloader(sp, finalized.Slot+1, target)
+
+		sp.requestLock.Lock()
+		sp.downloader = d
+		sp.requestLock.Unlock()
+
+		d.run()
+
+		sp.requestLock.Lock()
+		sp.downloader = nil
+		sp.requestLock.Unlock()
+	}
+
+	// Fetch the blocks after the finalized checkpoint from a single peer.
 	peerSelected, ok := sp.host.FindBestPeer()
 	if !ok {
-		sp.synced = true
+		sp.requestLock.Lock()
+		sp.finishSync()
+		sp.requestLock.Unlock()
 		return
 	}
 
@@ -58,82 +131,306 @@ func (sp *synchronizer) initialBlockDownload() {
 	return
 }
 
-// askForBlocks will ask a peer for blocks.
+// nextRequestID returns a new id for a block request.
+func (sp *synchronizer) nextRequestID() uint64 {
+	return atomic.AddUint64(&sp.requestCounter, 1)
+}
+
+// blockLocator returns a list of hashes of our main chain starting at the tip. The first ten hashes
+// are consecutive, after that the step doubles for each hash. The genesis hash is always the last one.
+func (sp *synchronizer) blockLocator() [][32]byte {
+	ch := sp.chain.State().Chain()
+	tip := ch.Tip()
+
+	locator := make([][32]byte, 0, p2p.MaxLocatorHashes)
+
+	step := uint64(1)
+	height := tip.Height
+	for height > 0 && len(locator) < p2p.MaxLocatorHashes-1 {
+		row, ok := ch.GetNodeByHeight(height)
+		if !ok {
+			break
+		}
+		locator = append(locator, row.Hash)
+
+		if len(locator) >= 10 {
+			step *= 2
+		}
+		if step >= height {
+			break
+		}
+		height -= step
+	}
+
+	locator = append(locator, ch.Genesis().Hash)
+
+	return locator
+}
+
+// askForBlocks will sync the blocks after our main chain tip from a peer. The headers are requested
+// and verified first, the block bodies are only downloaded for valid headers.
 func (sp *synchronizer) askForBlocks(id peer.ID) {
+	sp.requestLock.Lock()
+	defer sp.requestLock.Unlock()
 
-	sp.synced = false
+	sp.synced.set(false)
 	sp.withPeer = id
 
-	finalized, _ := sp.chain.State().GetFinalizedHead()
+	if err := sp.requestHeaders(id); err != nil {
+		sp.log.Error("unable to send headers request msg")
+		sp.finishSync()
+	}
+}
 
-	err := sp.host.SendMessage(id, &p2p.MsgGetBlocks{
-		LastBlockHash: finalized.Hash,
-	})
+// askForBlocksByRoot asks a peer for specific blocks. It is used to fetch the missing parent of a
+// block received after the node is synced.
+func (sp *synchronizer) askForBlocksByRoot(id peer.ID, roots [][32]byte) {
+	sp.requestLock.Lock()
+	defer sp.requestLock.Unlock()
 
+	if sp.withPeer != "" {
+		return
+	}
+
+	sp.withPeer = id
+	sp.requestID = sp.nextRequestID()
+
+	err := sp.host.SendMessage(id, &p2p.MsgGetBlocksByRoot{
+		Version:   p2p.BlocksProtocolVersion,
+		RequestID: sp.requestID,
+		Roots:     roots,
+	})
 	if err != nil {
 		sp.log.Error("unable to send block request msg")
+		sp.withPeer = ""
 		return
 	}
 
-	sp.blockStallTimer = time.NewTimer(time.Second * 5)
+	sp.startRequestTimer(sp.requestID)
+}
 
-	go sp.waitForBlocksTimer()
+// startRequestTimer gives up on the request if the peer doesn't answer in time. It must be called holding requestLock.
+func (sp *synchronizer) startRequestTimer(requestID uint64) {
+	if sp.requestTimer != nil {
+		sp.requestTimer.Stop()
+	}
+	sp.requestTimer = time.AfterFunc(requestTimeout, func() {
+		sp.requestLock.Lock()
+		defer sp.requestLock.Unlock()
 
-	return
+		if sp.requestID != requestID || sp.withPeer == "" {
+			return
+		}
+
+		sp.log.Warnf("block request to peer %s timed out", sp.withPeer)
+		sp.finishSync()
+		go sp.initialBlockDownload()
+	})
 }
 
-func (sp *synchronizer) waitForBlocksTimer() {
-	<-sp.blockStallTimer.C
-	sp.synced = true
-	sp.recentSynced = true
+// finishSync clears the in-flight request. It must be called holding requestLock.
+func (sp *synchronizer) finishSync() {
+	if sp.requestTimer != nil {
+		sp.requestTimer.Stop()
+	}
+	sp.headers = nil
+	sp.bodies = nil
+	if !sp.synced.get() {
+		sp.recentSynced.set(true)
+	}
+	sp.synced.set(true)
 	sp.withPeer = ""
-	sp.log.Info("Sync finished. Waiting for the next block...")
-	return
 }
 
+// awaits returns true if one of our requests to the peer has the request id.
+func (sp *synchronizer) awaits(id peer.ID, requestID uint64) bool {
+	sp.requestLock.Lock()
+	d := sp.downloader
+	requested := sp.withPeer == id && sp.requestID == requestID
+	sp.requestLock.Unlock()
+
+	return requested || (d != nil && d.awaits(id, requestID))
+}
+
+// handleBlocks processes a batch of blocks answering one of our requests.
+func (sp *synchronizer) handleBlocks(id peer.ID, msg *p2p.MsgBlocks) error {
+	sp.requestLock.Lock()
+	d := sp.downloader
+	sp.requestLock.Unlock()
+
+	if d != nil && d.handles(msg.RequestID) {
+		d.handleBlocks(id, msg)
+		return nil
+	}
+
+	sp.requestLock.Lock()
+	defer sp.requestLock.Unlock()
+
+	if sp.withPeer != id || sp.requestID != msg.RequestID {
+		sp.log.Debugf("ignoring unrequested blocks from peer %s", id)
+		return nil
+	}
+
+	if sp.bodies != nil {
+		return sp.handleBodies(id, msg)
+	}
+
+	for _, block := range msg.Blocks {
+		err := sp.processBlock(block, false)
+		if err == nil || err == ErrorBlockAlreadyKnown {
+			continue
+		}
+
+		if err == ErrorBlockParentUnknown && sp.synced.get() {
+			sp.orphans.add(block)
+			continue
+		}
+
+		sp.log.Errorf("unable to process block from peer %s: %s", id, err)
+		sp.finishSync()
+		if err == ErrorBlockParentUnknown {
+			go sp.askForBlocks(id)
+			return nil
+		}
+		return err
+	}
+
+	if len(msg.Blocks) > 0 && sp.recentSynced.get() {
+		sp.recentSynced.set(false)
+	}
+
+	if !msg.Done {
+		sp.startRequestTimer(msg.RequestID)
+		return nil
+	}
+
+	if msg.More {
+		sp.withPeer = ""
+		go sp.askForBlocks(id)
+		return nil
+	}
+
+	// Keep fetching the ancestors of the orphan blocks while the peer has them.
+	if sp.synced.get() && len(msg.Blocks) > 0 {
+		if missing := sp.orphans.missingParents(); len(missing) > 0 {
+			sp.finishSync()
+			if sp.orphans.size() >= maxOrphans {
+				go sp.askForBlocks(id)
+				return nil
+			}
+			if len(missing) > p2p.MaxRootsPerRequest {
+				missing = missing[:p2p.MaxRootsPerRequest]
+			}
+			go sp.askForBlocksByRoot(id, missing)
+			return nil
+		}
+	}
+
+	if !sp.synced.get() {
+		sp.log.Info("Sync finished. Waiting for the next block...")
+	}
+	sp.finishSync()
+
+	return nil
+}
+
+// handleBlock processes a gossiped block. The missing parents of orphan blocks are requested to the peer that relayed
+// the block, since it must have them to validate it.
 func (sp *synchronizer) handleBlock(id peer.ID, block *primitives.Block) error {
-	if !sp.synced && sp.withPeer != id {
+	if !sp.synced.get() {
 		sp.log.Info("received block during sync, waiting to finish...")
 		return nil
 	}
-	err := sp.processBlock(block)
+	err := sp.processBlock(block, false)
 	if err != nil {
 		if err == ErrorBlockAlreadyKnown {
 			sp.log.Error(err)
 			return nil
 		}
 		if err == ErrorBlockParentUnknown {
-			if sp.synced {
-				sp.log.Error(err)
-				s, ok := sp.host.GetPeerStats(id)
-				if !ok {
-					return nil
-				}
-				just, _ := sp.chain.State().GetJustifiedHead()
-				if s.ChainStats.JustifiedSlot >= just.Slot {
-					go sp.initialBlockDownload()
-					return nil
-				}
-				return nil
-			}
+			sp.addOrphan(id, block)
 			return nil
 		}
 		sp.log.Error(err)
+		sp.host.PenalizePeer(id, badBlockPenalty)
 		return err
 	}
 
-	if sp.recentSynced {
-		sp.recentSynced = false
+	if sp.recentSynced.get() {
+		sp.recentSynced.set(false)
 	}
 
-	if !sp.synced {
-		sp.blockStallTimer.Reset(time.Second * 3)
+	return nil
+}
+
+// addOrphan keeps a block with an unknown parent and asks the peer that sent it for its missing ancestor.
+func (sp *synchronizer) addOrphan(id peer.ID, block *primitives.Block) {
+	if sp.orphans.have(block.Hash()) {
+		return
 	}
+	sp.log.Debugf("received block %s with unknown parent from peer %s", block.Hash(), id)
+	sp.orphans.add(block)
+	sp.askForBlocksByRoot(id, [][32]byte{sp.orphans.missingAncestor(block.Hash())})
+}
 
-	return nil
+// validateBlock checks a gossiped block before it is relayed. Blocks already known, outside the slot window
+// or received during sync are ignored. Blocks with a known parent must be signed by the slot proposer. The proposer
+// of a block with an unknown parent can't be checked, so the block is not relayed, it is kept as an orphan and its
+// parent is requested to the peer that sent it.
+func (sp *synchronizer) validateBlock(id peer.ID, msg p2p.Message) ValidationResult {
+	data, ok := msg.(*p2p.MsgBlock)
+	if !ok || data.Data == nil || data.Data.Header == nil {
+		return ValidationReject
+	}
+	block := data.Data
+
+	if !sp.synced.get() {
+		return ValidationIgnore
+	}
+
+	if sp.chain.State().Index().Have(block.Hash()) {
+		return ValidationIgnore
+	}
+
+	currentSlot := uint64(time.Now().Sub(sp.chain.GenesisTime())/time.Second) / config.GlobalParams.NetParams.SlotDuration
+	if block.Header.Slot > currentSlot+1 {
+		return ValidationIgnore
+	}
+
+	finalized, _ := sp.chain.State().GetFinalizedHead()
+	if finalized != nil && block.Header.Slot <= finalized.Slot {
+		return ValidationIgnore
+	}
+
+	if err := block.CheckMerkleRoots(); err != nil {
+		sp.log.Debugf("rejected block %s from peer %s: %s", block.Hash(), id, err)
+		return ValidationReject
+	}
+
+	if !sp.chain.State().Index().Have(block.Header.PrevBlockHash) {
+		sp.addOrphan(id, block)
+		return ValidationIgnore
+	}
+
+	_, err := sp.verifyHeaders([]*p2p.SignedBlockHeader{{
+		Header:          block.Header,
+		Signature:       block.Signature,
+		RandaoSignature: block.RandaoSignature,
+	}})
+	if err == ErrorHeaderSignature {
+		sp.log.Debugf("rejected block %s from peer %s: %s", block.Hash(), id, err)
+		return ValidationReject
+	}
+	if err != nil {
+		return ValidationIgnore
+	}
+
+	return ValidationAccept
 }
 
-func (sp *synchronizer) processBlock(block *primitives.Block) error {
+// processBlock adds a block to the chain. The proposer signatures are not checked again when
+// signatureChecked is set.
+func (sp *synchronizer) processBlock(block *primitives.Block, signatureChecked bool) error {
 
 	// Check if we already have this block
 	if sp.chain.State().Index().Have(block.Hash()) {
@@ -147,7 +444,11 @@ func (sp *synchronizer) processBlock(block *primitives.Block) error {
 
 	// Process block
 	sp.log.Debugf("processing block %s", block.Hash())
-	if err := sp.chain.ProcessBlock(block); err != nil {
+	process := sp.chain.ProcessBlock
+	if signatureChecked {
+		process = sp.chain.ProcessTrustedBlock
+	}
+	if err := process(block); err != nil {
 		return err
 	}
 
@@ -155,7 +456,7 @@ func (sp *synchronizer) processBlock(block *primitives.Block) error {
 	// when a new state is finalized.
 	// When this happens we should announce all blocks our new status.
 
-	if sp.chain.State().TipState().GetFinalizedEpoch() > sp.lastFinalizedEpoch && sp.synced {
+	if sp.chain.State().TipState().GetFinalizedEpoch() > sp.lastFinalizedEpoch && sp.synced.get() {
 
 		tip := sp.chain.State().Tip()
 		justified, _ := sp.chain.State().GetJustifiedHead()
@@ -182,6 +483,13 @@ func (sp *synchronizer) processBlock(block *primitives.Block) error {
 
 	sp.lastFinalizedEpoch = sp.chain.State().TipState().GetFinalizedEpoch()
 
+	// Import the orphans waiting for this block.
+	for _, orphan := range sp.orphans.takeChildren(block.Hash()) {
+		if err := sp.processBlock(orphan, false); err != nil && err != ErrorBlockAlreadyKnown {
+			sp.log.Debugf("unable to process orphan block %s: %s", orphan.Hash(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -189,11 +497,12 @@ func (sp *synchronizer) processBlock(block *primitives.Block) error {
 func NewSynchronizer(host Host, chain chain.Blockchain) (*synchronizer, error) {
 
 	sp := &synchronizer{
-		host:   host,
-		log:    config.GlobalParams.Logger,
-		ctx:    config.GlobalParams.Context,
-		chain:  chain,
-		synced: false,
+		host:  host,
+		log:   config.GlobalParams.Logger,
+		ctx:   config.GlobalParams.Context,
+		chain: chain,
+
+		orphans: newOrphanPool(config.GlobalParams.NetParams.SlotDuration),
 	}
 
 	go sp.initialBlockDownload()
@@ -0,0 +1,85 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HandleBlockUnknownParent(t *testing.T) {
+	src := chaintest.NewChain(t)
+	blocks := src.Extend(t, 3)
+
+	h := newTestHost()
+	sp := newTestSynchronizer(h, src.Empty(t))
+	sp.synced.set(true)
+
+	assert.NoError(t, sp.handleBlock("relayer", blocks[2]))
+	assert.True(t, sp.orphans.have(blocks[2].Hash()))
+
+	// The missing parent is requested to the peer that relayed the block.
+	if !assert.Len(t, h.sent[peer.ID("relayer")], 1) {
+		t.FailNow()
+	}
+	msg, ok := h.sent[peer.ID("relayer")][0].(*p2p.MsgGetBlocksByRoot)
+	assert.True(t, ok)
+	assert.Equal(t, [][32]byte{blocks[1].Hash()}, msg.Roots)
+	assert.Empty(t, h.penalties)
+}
+
+func Test_ValidateBlockUnknownParent(t *testing.T) {
+	src := chaintest.NewChain(t)
+	blocks := src.Extend(t, 3)
+
+	h := newTestHost()
+	dst := src.Empty(t)
+	sp := newTestSynchronizer(h, dst)
+	sp.synced.set(true)
+
+	assert.NoError(t, dst.ProcessBlock(blocks[0]))
+	assert.Equal(t, ValidationAccept, sp.validateBlock("relayer", &p2p.MsgBlock{Data: blocks[1]}))
+
+	// The proposer of a block with an unknown parent can't be checked, it is not relayed but kept as an orphan.
+	assert.Equal(t, ValidationIgnore, sp.validateBlock("relayer", &p2p.MsgBlock{Data: blocks[2]}))
+	assert.True(t, sp.orphans.have(blocks[2].Hash()))
+	assert.Len(t, h.sent[peer.ID("relayer")], 1)
+}
+
+func Test_HandleBodiesEmptyBatch(t *testing.T) {
+	src := chaintest.NewChain(t)
+	blocks := src.Extend(t, 2)
+
+	h := newTestHost()
+	sp := newTestSynchronizer(h, src.Empty(t))
+	sp.withPeer = "syncer"
+	sp.requestID = 1
+	sp.bodies = []*p2p.SignedBlockHeader{{Header: blocks[0].Header}, {Header: blocks[1].Header}}
+
+	// An empty batch that doesn't finish the request makes no progress, the peer is rejected.
+	assert.NoError(t, sp.handleBodies("syncer", &p2p.MsgBlocks{RequestID: 1}))
+	assert.Equal(t, uint64(badBatchPenalty), h.penalties[peer.ID("syncer")])
+	assert.Nil(t, sp.bodies)
+	assert.Equal(t, peer.ID(""), sp.withPeer)
+}
+
+func Test_SynchronizerAwaits(t *testing.T) {
+	d, _, _, _ := newTestDownloader(t)
+	sp := d.sp
+	sp.withPeer = "syncer"
+	sp.requestID = 1
+	d.queue[0].peer = "ranger"
+	d.queue[0].requestID = 2
+	d.requests[2] = d.queue[0]
+	sp.downloader = d
+
+	assert.True(t, sp.awaits("syncer", 1))
+	assert.True(t, sp.awaits("ranger", 2))
+
+	// The request id must belong to a request sent to the same peer.
+	assert.False(t, sp.awaits("ranger", 1))
+	assert.False(t, sp.awaits("syncer", 2))
+	assert.False(t, sp.awaits("syncer", 3))
+}
@@ -1,7 +1,6 @@
 package host
 
 import (
-	"github.com/VictoriaMetrics/fastcache"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
@@ -15,6 +14,9 @@ import (
 
 const (
 	banPeerTimePenalization = time.Minute * 60
+
+	// incompatiblePeerBan is the time a peer following a different chain is banned.
+	incompatiblePeerBan = time.Minute * 10
 )
 
 type peerChainStats struct {
@@ -37,41 +39,53 @@ type peerStats struct {
 	BytesSent     uint64
 	BadMessages   int
 	BanScore      uint64
-}
+	GossipScore   float64
 
-type stats struct {
-	log logger.Logger
+	// ThrottledMessages are the messages received over the rate limits.
+	ThrottledMessages uint64
+	// DroppedMessages are the messages not sent because the outgoing queue was full.
+	DroppedMessages uint64
 
-	banPeersCache *fastcache.Cache
-	peersStats    sync.Map
-	count         int
-	h             Host
+	// banScoreDecayed is the last time the ban score was decayed.
+	banScoreDecayed time.Time
+	// droppedDecayed is the last time the dropped messages were decayed.
+	droppedDecayed time.Time
 }
 
-// IsBanned returns if a known peer is banned for bad behaviour
-func (s *stats) IsBanned(p peer.ID) (bool, error) {
-	ip, err := p.MarshalBinary()
-	if err != nil {
-		return false, err
-	}
+// decay decreases the ban score by one point for every banScoreDecay and the dropped messages by one for every
+// droppedMessagesDecay elapsed since their last decay.
+func (ps *peerStats) decay(now time.Time) {
+	ps.BanScore, ps.banScoreDecayed = decayCounter(ps.BanScore, ps.banScoreDecayed, now, banScoreDecay)
+	ps.DroppedMessages, ps.droppedDecayed = decayCounter(ps.DroppedMessages, ps.droppedDecayed, now, droppedMessagesDecay)
+}
 
-	data, ok := s.banPeersCache.HasGet(nil, ip)
-	if !ok {
-		return false, nil
+// decayCounter decreases a counter by one for every interval elapsed since the last decay. It returns the new
+// value and the time of the last decay.
+func decayCounter(value uint64, last time.Time, now time.Time, interval time.Duration) (uint64, time.Time) {
+	if value == 0 {
+		return 0, now
 	}
 
-	var t time.Time
-	err = t.UnmarshalBinary(data)
-	if err != nil {
-		return false, err
+	decay := uint64(now.Sub(last) / interval)
+	if decay >= value {
+		return 0, now
 	}
 
-	if time.Now().Unix() > t.Unix() {
-		s.banPeersCache.Del(ip)
-		return false, nil
-	}
+	return value - decay, last.Add(time.Duration(decay) * interval)
+}
+
+type stats struct {
+	log logger.Logger
+
+	bans       *banStore
+	peersStats sync.Map
+	count      int
+	h          Host
+}
 
-	return true, nil
+// IsBanned returns if a known peer is banned for bad behaviour
+func (s *stats) IsBanned(p peer.ID) (bool, error) {
+	return s.bans.isBanned(p)
 }
 
 func (s *stats) GetPeerStats(p peer.ID) (*peerStats, bool) {
@@ -83,20 +97,28 @@ func (s *stats) GetPeerStats(p peer.ID) (*peerStats, bool) {
 	if !ok {
 		return nil, false
 	}
+	stats.decay(time.Now())
 	return &stats, true
 }
 
+// SetPeerBan bans a peer for the specified duration. The ban is kept on disk until it expires.
 func (s *stats) SetPeerBan(p peer.ID, until time.Duration) {
-	ip, err := p.MarshalBinary()
-	if err != nil {
+	if s.h.IsTrusted(p) {
 		return
 	}
-	t := time.Now().Add(until)
-	tb, err := t.MarshalBinary()
+	err := s.bans.ban(p, time.Now().Add(until))
 	if err != nil {
+		s.log.Errorf("unable to store ban for peer %s: %s", p, err)
+	}
+}
+
+// BanPeer bans and disconnects a peer.
+func (s *stats) BanPeer(p peer.ID) {
+	if s.h.IsTrusted(p) {
 		return
 	}
-	s.banPeersCache.Set(ip, tb)
+	s.SetPeerBan(p, banPeerTimePenalization)
+	_ = s.h.Disconnect(p)
 }
 
 // FindBestPeer will perform a contextual check for peers and return a random peer ahead if we need to sync.
@@ -159,6 +181,9 @@ func (s *stats) Add(p peer.ID, ver *p2p.MsgVersion, dir network.Direction) {
 		BytesSent:     0,
 		BadMessages:   0,
 		BanScore:      0,
+
+		banScoreDecayed: time.Now(),
+		droppedDecayed:  time.Now(),
 	}
 	s.peersStats.Store(p, peerStats)
 	s.count += 1
@@ -199,8 +224,7 @@ func (s *stats) Remove(p peer.ID) {
 }
 
 func (s *stats) Close() {
-	datapath := config.GlobalFlags.DataPath
-	_ = s.banPeersCache.SaveToFile(datapath + "/badpeers")
+	_ = s.bans.close()
 }
 
 func (s *stats) IncreaseWrongMsgCount(p peer.ID) {
@@ -214,14 +238,47 @@ func (s *stats) IncreaseWrongMsgCount(p peer.ID) {
 	}
 
 	stats.BadMessages += 1
-	stats.BanScore += 10
+	s.peersStats.Store(p, stats)
+
+	s.AddBanScore(p, 10)
+}
+
+// AddBanScore increases the ban score of a peer and bans it when it reaches the limit.
+func (s *stats) AddBanScore(p peer.ID, score uint64) {
+	ps, ok := s.peersStats.Load(p)
+	if !ok {
+		return
+	}
+	stats, ok := ps.(peerStats)
+	if !ok {
+		return
+	}
+
+	stats.decay(time.Now())
+	stats.BanScore += score
+
+	s.log.Tracef("Adding %d banscore to peer %s", score, p.String())
 
-	s.log.Tracef("Adding %d banscore to peer %s", 10, p.String())
-	if stats.BanScore >= 500 {
-		s.SetPeerBan(p, banPeerTimePenalization)
-		_ = s.h.Disconnect(p)
+	s.peersStats.Store(p, stats)
+
+	if stats.BanScore >= maxBanScore {
+		s.BanPeer(p)
+	}
+}
+
+// SetGossipScore stores the last gossipsub score of a peer.
+func (s *stats) SetGossipScore(p peer.ID, score float64) {
+	ps, ok := s.peersStats.Load(p)
+	if !ok {
+		return
+	}
+	stats, ok := ps.(peerStats)
+	if !ok {
+		return
 	}
 
+	stats.GossipScore = score
+
 	s.peersStats.Store(p, stats)
 }
 
@@ -240,6 +297,42 @@ func (s *stats) IncreasePeerReceivedBytes(p peer.ID, amount uint64) {
 	s.peersStats.Store(p, stats)
 }
 
+// IncreaseThrottledMessages counts a message received over the rate limits.
+func (s *stats) IncreaseThrottledMessages(p peer.ID) {
+	ps, ok := s.peersStats.Load(p)
+	if !ok {
+		return
+	}
+	stats, ok := ps.(peerStats)
+	if !ok {
+		return
+	}
+
+	stats.ThrottledMessages += 1
+
+	s.peersStats.Store(p, stats)
+}
+
+// IncreaseDroppedMessages counts a message not sent to a peer and returns the amount of dropped messages
+// that didn't decay yet.
+func (s *stats) IncreaseDroppedMessages(p peer.ID) uint64 {
+	ps, ok := s.peersStats.Load(p)
+	if !ok {
+		return 0
+	}
+	stats, ok := ps.(peerStats)
+	if !ok {
+		return 0
+	}
+
+	stats.decay(time.Now())
+	stats.DroppedMessages += 1
+
+	s.peersStats.Store(p, stats)
+
+	return stats.DroppedMessages
+}
+
 func (s *stats) IncreasePeerSentBytes(p peer.ID, amount uint64) {
 	ps, ok := s.peersStats.Load(p)
 	if !ok {
@@ -258,13 +351,16 @@ func (s *stats) IncreasePeerSentBytes(p peer.ID, amount uint64) {
 func NewStatsService(h Host) (*stats, error) {
 	datapath := config.GlobalFlags.DataPath
 	log := config.GlobalParams.Logger
-	cache := fastcache.LoadFromFileOrNew(datapath+"/badpeers", 50*1024*1024)
+	bans, err := openBanStore(datapath)
+	if err != nil {
+		return nil, err
+	}
 
 	ss := &stats{
-		log:           log,
-		banPeersCache: cache,
-		count:         0,
-		h:             h,
+		log:   log,
+		bans:  bans,
+		count: 0,
+		h:     h,
 	}
 
 	return ss, nil
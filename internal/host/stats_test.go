@@ -0,0 +1,62 @@
+package host
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BanScoreDecay(t *testing.T) {
+	s := &stats{log: logger.New(os.Stdout), h: newTestHost()}
+	s.Add("peer", &p2p.MsgVersion{}, network.DirInbound)
+
+	s.AddBanScore("peer", 100)
+	ps, ok := s.GetPeerStats("peer")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), ps.BanScore)
+
+	// Move the last decay back in time as if the penalty was added 5 minutes ago.
+	ps.banScoreDecayed = ps.banScoreDecayed.Add(-5 * time.Minute)
+	s.peersStats.Store(ps.ID, *ps)
+
+	ps, _ = s.GetPeerStats("peer")
+	assert.Equal(t, uint64(100-5*time.Minute/banScoreDecay), ps.BanScore)
+
+	s.AddBanScore("peer", 20)
+	ps, _ = s.GetPeerStats("peer")
+	assert.Equal(t, uint64(120-5*time.Minute/banScoreDecay), ps.BanScore)
+
+	ps.banScoreDecayed = ps.banScoreDecayed.Add(-time.Hour)
+	s.peersStats.Store(ps.ID, *ps)
+
+	ps, _ = s.GetPeerStats("peer")
+	assert.Zero(t, ps.BanScore)
+}
+
+func Test_DroppedMessagesDecay(t *testing.T) {
+	s := &stats{log: logger.New(os.Stdout), h: newTestHost()}
+	s.Add("peer", &p2p.MsgVersion{}, network.DirInbound)
+
+	for i := 0; i < 10; i++ {
+		s.IncreaseDroppedMessages("peer")
+	}
+	ps, _ := s.GetPeerStats("peer")
+	assert.Equal(t, uint64(10), ps.DroppedMessages)
+
+	// Move the last decay back in time as if the messages were dropped 4 seconds ago.
+	ps.droppedDecayed = ps.droppedDecayed.Add(-4 * droppedMessagesDecay)
+	s.peersStats.Store(ps.ID, *ps)
+
+	assert.Equal(t, uint64(7), s.IncreaseDroppedMessages("peer"))
+
+	ps, _ = s.GetPeerStats("peer")
+	ps.droppedDecayed = ps.droppedDecayed.Add(-time.Minute)
+	s.peersStats.Store(ps.ID, *ps)
+
+	assert.Equal(t, uint64(1), s.IncreaseDroppedMessages("peer"))
+}
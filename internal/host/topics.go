@@ -0,0 +1,127 @@
+package host
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+)
+
+// ValidationResult is the result of a gossip message validation.
+type ValidationResult = pubsub.ValidationResult
+
+const (
+	// ValidationAccept delivers the message and relays it to other peers.
+	ValidationAccept = pubsub.ValidationAccept
+
+	// ValidationReject drops the message and penalizes the peer that relayed it.
+	ValidationReject = pubsub.ValidationReject
+
+	// ValidationIgnore drops the message without penalizing the peer.
+	ValidationIgnore = pubsub.ValidationIgnore
+)
+
+// ErrorUnknownTopic returns when a message is broadcasted without a gossip topic for its type.
+var ErrorUnknownTopic = errors.New("no gossip topic for message")
+
+// gossipMessages contains an empty message for every message type with a gossip topic.
+var gossipMessages = []p2p.Message{
+	new(p2p.MsgBlock),
+	new(p2p.MsgVote),
+	new(p2p.MsgTx),
+	new(p2p.MsgDeposits),
+	new(p2p.MsgExits),
+	new(p2p.MsgPartialExits),
+	new(p2p.MsgSlashings),
+	new(p2p.MsgFinalization),
+}
+
+// topicName returns the name of the gossip topic for a message type on the current network.
+func topicName(cmd string) string {
+	return fmt.Sprintf("/ogen/%s/%s", config.GlobalParams.NetParams.Name, cmd)
+}
+
+// maxGossipSize returns the size of the biggest message that can be gossiped.
+func maxGossipSize() int {
+	max := uint64(0)
+	for _, msg := range gossipMessages {
+		if msg.MaxPayloadLength() > max {
+			max = msg.MaxPayloadLength()
+		}
+	}
+	return int(max + p2p.MessageHeaderSize)
+}
+
+// joinTopics joins and subscribes to the gossip topic of every message type.
+func (h *host) joinTopics() error {
+	for _, msg := range gossipMessages {
+		cmd := msg.Command()
+		name := topicName(cmd)
+
+		err := h.pubsub.RegisterTopicValidator(name, h.validateTopicMsg(cmd, msg.MaxPayloadLength()))
+		if err != nil {
+			return err
+		}
+
+		topic, err := h.pubsub.Join(name)
+		if err != nil {
+			return err
+		}
+
+		sub, err := topic.Subscribe()
+		if err != nil {
+			return err
+		}
+
+		h.topics[cmd] = topic
+
+		go h.listenTopic(cmd, sub)
+	}
+
+	return nil
+}
+
+// validateTopicMsg returns the pubsub validator of a topic. Messages bigger than the maximum size of the
+// topic type, that can't be decoded or that belong to a different topic are rejected. Messages relayed over
+// the rate limits of the peer are ignored before they are decoded, an honest peer may relay a burst it didn't
+// create so they are not penalized. Valid messages are passed to the validator registered for the type along
+// with the peer that relayed them.
+func (h *host) validateTopicMsg(cmd string, maxPayload uint64) pubsub.ValidatorEx {
+	return func(_ context.Context, id peer.ID, m *pubsub.Message) pubsub.ValidationResult {
+		if uint64(len(m.Data)) > maxPayload+p2p.MessageHeaderSize {
+			return ValidationReject
+		}
+
+		if id != h.ID() && !h.limiter.allow(id, cmd, uint64(len(m.Data))) {
+			h.log.Debugf("ignoring message on topic %s from peer %s over the rate limits", cmd, id)
+			h.stats.IncreaseThrottledMessages(id)
+			return ValidationIgnore
+		}
+
+		msg, err := p2p.ReadMessage(bytes.NewBuffer(m.Data), h.netMagic)
+		if err != nil || msg.Command() != cmd {
+			h.log.Debugf("invalid message on topic %s from peer %s", cmd, id)
+			return ValidationReject
+		}
+
+		m.ValidatorData = msg
+
+		if m.GetFrom() == h.host.ID() {
+			return ValidationAccept
+		}
+
+		h.topicHandlersLock.Lock()
+		validator, found := h.topicValidators[cmd]
+		h.topicHandlersLock.Unlock()
+		if !found {
+			return ValidationAccept
+		}
+
+		return validator(id, msg)
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"os"
 	"path"
 	"sort"
-	"time"
 )
 
 // Retrieves an external ipv4 address and converts into a libp2p formatted value.
@@ -152,42 +151,87 @@ func multiAddressBuilderWithID(ipAddr, protocol string, port string, id peer.ID)
 	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/%s/%s/p2p/%s", ipAddr, protocol, port, id.String()))
 }
 
-func buildOptions(priKey crypto.PrivKey, ps peerstore.Peerstore) []libp2p.Option {
-	netParams := config.GlobalParams.NetParams
-	var listen []ma.Multiaddr
-	var ips []string
-	ipv4, err := ExternalIPv4()
-	if err == nil {
-		ips = append(ips, ipv4)
-	}
-	ipv6, err := ExternalIPv6()
-	if err == nil {
-		ips = append(ips, ipv6)
-	}
-	if len(ips) == 0 {
-		panic("no ip available to listen")
-	}
-	for _, ip := range ips {
-		addr, err := multiAddressBuilder(ip, netParams.DefaultP2PPort)
-		if err != nil {
-			panic("no ip available to listen")
-		}
-		listen = append(listen, addr)
+// buildOptions returns the libp2p options for the node. NAT port mapping and hop relay are disabled when
+// discovery is disabled or on dev mode, the connection is limited to the private network when a pre-shared key is configured.
+func buildOptions(priKey crypto.PrivKey, ps peerstore.Peerstore, connman *connmgr.BasicConnMgr) ([]libp2p.Option, error) {
+	listen, err := listenAddrs(listenPort(), config.GlobalFlags.DevMode)
+	if err != nil {
+		return nil, err
 	}
 
-	connman := connmgr.NewConnManager(2, 64, time.Second*60)
-
 	options := []libp2p.Option{
 		libp2p.Identity(priKey),
 		libp2p.ListenAddrs(listen...),
-		libp2p.NATPortMap(),
 		libp2p.UserAgent(fmt.Sprintf("ogen/%s", params.Version)),
 		libp2p.ConnectionManager(connman),
 		libp2p.Peerstore(ps),
-		libp2p.EnableRelay(circuit.OptActive, circuit.OptHop),
 	}
 
-	return options
+	if !config.GlobalFlags.NoDiscovery && !config.GlobalFlags.DevMode {
+		options = append(options, libp2p.NATPortMap(), libp2p.EnableRelay(circuit.OptActive, circuit.OptHop))
+	}
+
+	if config.GlobalFlags.PSKFile != "" {
+		psk, err := loadPSK(config.GlobalFlags.PSKFile)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, libp2p.PrivateNetwork(psk))
+	}
+
+	return options, nil
+}
+
+// listenPort returns the port set with the port flag or the default port of the network.
+func listenPort() string {
+	if config.GlobalFlags.Port != "" {
+		return config.GlobalFlags.Port
+	}
+	return config.GlobalParams.NetParams.DefaultP2PPort
+}
+
+// listenAddrs returns the addresses to listen on. The node listens on the external IPv4 and IPv6 addresses,
+// on dev mode it listens on the loopback and on all the LAN addresses. When no address is found the
+// node listens on the loopback.
+func listenAddrs(port string, dev bool) ([]ma.Multiaddr, error) {
+	var ips []string
+	if dev {
+		lan, err := retrieveIPAddrs()
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, "127.0.0.1")
+		for _, ip := range lan {
+			ips = append(ips, ip.String())
+		}
+	} else {
+		if ipv4, err := ExternalIPv4(); err == nil {
+			ips = append(ips, ipv4)
+		}
+		if ipv6, err := ExternalIPv6(); err == nil {
+			ips = append(ips, ipv6)
+		}
+		if len(ips) == 0 {
+			ips = append(ips, "127.0.0.1")
+		}
+	}
+
+	var listen []ma.Multiaddr
+	seen := make(map[string]struct{})
+	for _, ip := range ips {
+		if _, ok := seen[ip]; ok {
+			continue
+		}
+		seen[ip] = struct{}{}
+
+		addr, err := multiAddressBuilder(ip, port)
+		if err != nil {
+			return nil, err
+		}
+		listen = append(listen, addr)
+	}
+
+	return listen, nil
 }
 
 func (h *host) loadPrivateKey() (crypto.PrivKey, error) {
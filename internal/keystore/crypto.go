@@ -0,0 +1,120 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// scryptN, scryptR and scryptP are the scrypt parameters recommended by EIP-2335.
+	scryptN = 1 << 18
+	scryptR = 8
+	scryptP = 1
+
+	// encryptionKeySize is the size of the AES-256 key derived from the passphrase.
+	encryptionKeySize = 32
+)
+
+var (
+	// encryptionBucket stores the parameters used to derive the encryption key from the passphrase.
+	encryptionBucket = []byte("encryption")
+	kdfKey           = []byte("kdf")
+)
+
+// kdfParams are the key derivation parameters stored on the keystore in the format of the EIP-2335 kdf module.
+type kdfParams struct {
+	Function string `json:"function"`
+	Params   struct {
+		DKLen int    `json:"dklen"`
+		N     int    `json:"n"`
+		R     int    `json:"r"`
+		P     int    `json:"p"`
+		Salt  string `json:"salt"`
+	} `json:"params"`
+}
+
+// newKDFParams returns scrypt parameters with a random salt.
+func newKDFParams() (*kdfParams, error) {
+	var salt [32]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	p := &kdfParams{Function: "scrypt"}
+	p.Params.DKLen = encryptionKeySize
+	p.Params.N = scryptN
+	p.Params.R = scryptR
+	p.Params.P = scryptP
+	p.Params.Salt = hex.EncodeToString(salt[:])
+
+	return p, nil
+}
+
+// deriveKey derives the encryption key from the passphrase.
+func (p *kdfParams) deriveKey(passphrase string) ([]byte, error) {
+	if p.Function != "scrypt" || p.Params.DKLen != encryptionKeySize {
+		return nil, ErrorUnknownKDF
+	}
+
+	salt, err := hex.DecodeString(p.Params.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	return scrypt.Key([]byte(passphrase), salt, p.Params.N, p.Params.R, p.Params.P, p.Params.DKLen)
+}
+
+func (p *kdfParams) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *kdfParams) Unmarshal(b []byte) error {
+	return json.Unmarshal(b, p)
+}
+
+// encrypt encrypts the data with AES-256-GCM. The random nonce is prepended to the ciphertext.
+func encrypt(key []byte, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decrypt decrypts data encrypted with encrypt. A wrong key returns ErrorWrongPassphrase.
+func decrypt(key []byte, data []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, ErrorWrongPassphrase
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrorWrongPassphrase
+	}
+
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
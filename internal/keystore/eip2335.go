@@ -0,0 +1,298 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// JSONKeystoreVersion is the EIP-2335 keystore version.
+const JSONKeystoreVersion = 4
+
+// keyPathPrefix is the derivation path of the keys derived from the keystore mnemonic without the index.
+const keyPathPrefix = "m/12381/1997/0/"
+
+var (
+	// ErrorJSONKeystoreVersion returned when the JSON keystore version is not supported.
+	ErrorJSONKeystoreVersion = errors.New("unsupported JSON keystore version")
+
+	// ErrorJSONKeystoreModule returned when the JSON keystore uses an unsupported kdf, checksum or cipher.
+	ErrorJSONKeystoreModule = errors.New("unsupported JSON keystore crypto module")
+
+	// ErrorJSONKeystorePassphrase returned when the passphrase doesn't match the JSON keystore checksum.
+	ErrorJSONKeystorePassphrase = errors.New("wrong JSON keystore passphrase")
+
+	// ErrorJSONKeystorePubKey returned when the decrypted key doesn't match the JSON keystore public key.
+	ErrorJSONKeystorePubKey = errors.New("JSON keystore public key doesn't match the secret")
+
+	// ErrorKeyExists returned when importing a key already on the keystore.
+	ErrorKeyExists = errors.New("the key already exists on the keystore")
+)
+
+// JSONKeystore is an encrypted validator key in the EIP-2335 format.
+type JSONKeystore struct {
+	Crypto      JSONKeystoreCrypto `json:"crypto"`
+	Description string             `json:"description"`
+	PubKey      string             `json:"pubkey"`
+	Path        string             `json:"path"`
+	UUID        string             `json:"uuid"`
+	Version     int                `json:"version"`
+}
+
+// JSONKeystoreCrypto contains the kdf, checksum and cipher modules of a JSON keystore.
+type JSONKeystoreCrypto struct {
+	KDF      JSONKeystoreModule `json:"kdf"`
+	Checksum JSONKeystoreModule `json:"checksum"`
+	Cipher   JSONKeystoreModule `json:"cipher"`
+}
+
+// JSONKeystoreModule is a crypto module of a JSON keystore.
+type JSONKeystoreModule struct {
+	Function string                 `json:"function"`
+	Params   map[string]interface{} `json:"params"`
+	Message  string                 `json:"message"`
+}
+
+// EncryptJSONKeystore encrypts a key with the passphrase using scrypt and AES-128-CTR.
+func EncryptJSONKeystore(key *Key, passphrase string) (*JSONKeystore, error) {
+	var salt [32]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+	var iv [16]byte
+	if _, err := rand.Read(iv[:]); err != nil {
+		return nil, err
+	}
+
+	dk, err := scrypt.Key(normalizePassphrase(passphrase), salt[:], scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherMsg, err := aes128CTR(dk[:16], iv[:], key.Secret.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(append(append([]byte{}, dk[16:32]...), cipherMsg...))
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	path := ""
+	if key.Path > 0 {
+		path = keyPathPrefix + strconv.FormatInt(key.Path, 10)
+	}
+
+	return &JSONKeystore{
+		Crypto: JSONKeystoreCrypto{
+			KDF: JSONKeystoreModule{
+				Function: "scrypt",
+				Params: map[string]interface{}{
+					"dklen": 32,
+					"n":     scryptN,
+					"r":     scryptR,
+					"p":     scryptP,
+					"salt":  hex.EncodeToString(salt[:]),
+				},
+			},
+			Checksum: JSONKeystoreModule{
+				Function: "sha256",
+				Params:   map[string]interface{}{},
+				Message:  hex.EncodeToString(checksum[:]),
+			},
+			Cipher: JSONKeystoreModule{
+				Function: "aes-128-ctr",
+				Params: map[string]interface{}{
+					"iv": hex.EncodeToString(iv[:]),
+				},
+				Message: hex.EncodeToString(cipherMsg),
+			},
+		},
+		PubKey:  hex.EncodeToString(key.Secret.PublicKey().Marshal()),
+		Path:    path,
+		UUID:    id.String(),
+		Version: JSONKeystoreVersion,
+	}, nil
+}
+
+// DecryptJSONKeystore decrypts the key of a JSON keystore. Keys derived with the ogen path keep their index,
+// other keys have the path -1. The imported keys are enabled.
+func DecryptJSONKeystore(jks *JSONKeystore, passphrase string) (*Key, error) {
+	if jks.Version != JSONKeystoreVersion {
+		return nil, ErrorJSONKeystoreVersion
+	}
+	if jks.Crypto.Checksum.Function != "sha256" || jks.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, ErrorJSONKeystoreModule
+	}
+
+	dk, err := jsonKeystoreKDF(&jks.Crypto.KDF, normalizePassphrase(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	if len(dk) < 32 {
+		return nil, ErrorJSONKeystoreModule
+	}
+
+	cipherMsg, err := hex.DecodeString(jks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := hex.DecodeString(jks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := sha256.Sum256(append(append([]byte{}, dk[16:32]...), cipherMsg...))
+	if !bytes.Equal(expected[:], checksum) {
+		return nil, ErrorJSONKeystorePassphrase
+	}
+
+	ivStr, _ := jks.Crypto.Cipher.Params["iv"].(string)
+	iv, err := hex.DecodeString(ivStr)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, ErrorJSONKeystoreModule
+	}
+
+	secretBytes, err := aes128CTR(dk[:16], iv, cipherMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := bls.SecretKeyFromBytes(secretBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if jks.PubKey != "" && !strings.EqualFold(jks.PubKey, hex.EncodeToString(secret.PublicKey().Marshal())) {
+		return nil, ErrorJSONKeystorePubKey
+	}
+
+	path := int64(-1)
+	if strings.HasPrefix(jks.Path, keyPathPrefix) {
+		if n, err := strconv.ParseInt(strings.TrimPrefix(jks.Path, keyPathPrefix), 10, 64); err == nil {
+			path = n
+		}
+	}
+
+	return &Key{
+		Secret: secret,
+		Enable: true,
+		Path:   path,
+	}, nil
+}
+
+// ExportKey returns the key of the public key as a JSON keystore encrypted with the passphrase.
+func (k *keystore) ExportKey(pub [48]byte, passphrase string) (*JSONKeystore, error) {
+	if !k.open {
+		return nil, ErrorNoOpen
+	}
+	key, ok := k.GetValidatorKey(pub)
+	if !ok {
+		return nil, ErrorKeyNotOnKeystore
+	}
+	return EncryptJSONKeystore(key, passphrase)
+}
+
+// ImportKey decrypts a JSON keystore and adds the key to the keystore. Keys already on the keystore are
+// not overwritten and return ErrorKeyExists.
+func (k *keystore) ImportKey(jks *JSONKeystore, passphrase string) (*Key, error) {
+	if !k.open {
+		return nil, ErrorNoOpen
+	}
+	key, err := DecryptJSONKeystore(jks, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub [48]byte
+	copy(pub[:], key.Secret.PublicKey().Marshal())
+	if _, ok := k.GetValidatorKey(pub); ok {
+		return nil, ErrorKeyExists
+	}
+
+	return key, k.AddKey(key)
+}
+
+// MarshalJSONKeystore serializes a JSON keystore.
+func MarshalJSONKeystore(jks *JSONKeystore) ([]byte, error) {
+	return json.MarshalIndent(jks, "", "  ")
+}
+
+// UnmarshalJSONKeystore deserializes a JSON keystore.
+func UnmarshalJSONKeystore(b []byte) (*JSONKeystore, error) {
+	jks := new(JSONKeystore)
+	return jks, json.Unmarshal(b, jks)
+}
+
+// jsonKeystoreKDF derives the decryption key with the scrypt or pbkdf2 kdf module.
+func jsonKeystoreKDF(m *JSONKeystoreModule, passphrase []byte) ([]byte, error) {
+	saltStr, _ := m.Params["salt"].(string)
+	salt, err := hex.DecodeString(saltStr)
+	if err != nil {
+		return nil, ErrorJSONKeystoreModule
+	}
+
+	dkLen := paramInt(m.Params, "dklen")
+
+	switch m.Function {
+	case "scrypt":
+		return scrypt.Key(passphrase, salt, paramInt(m.Params, "n"), paramInt(m.Params, "r"), paramInt(m.Params, "p"), dkLen)
+	case "pbkdf2":
+		if prf, _ := m.Params["prf"].(string); prf != "hmac-sha256" {
+			return nil, ErrorJSONKeystoreModule
+		}
+		return pbkdf2.Key(passphrase, salt, paramInt(m.Params, "c"), dkLen, sha256.New), nil
+	default:
+		return nil, ErrorJSONKeystoreModule
+	}
+}
+
+// paramInt returns an integer parameter of a module decoded from JSON.
+func paramInt(params map[string]interface{}, name string) int {
+	switch v := params[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+// normalizePassphrase normalizes the passphrase to NFKD and removes the control codes as specified by EIP-2335.
+func normalizePassphrase(passphrase string) []byte {
+	normalized := norm.NFKD.String(passphrase)
+	return []byte(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, normalized))
+}
+
+func aes128CTR(key []byte, iv []byte, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(out, data)
+	return out, nil
+}
@@ -0,0 +1,78 @@
+package keystore_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/stretchr/testify/assert"
+)
+
+// pbkdf2Vector is the PBKDF2 test vector of EIP-2335.
+const pbkdf2Vector = `{
+    "crypto": {
+        "kdf": {"function": "pbkdf2", "params": {"dklen": 32, "c": 262144, "prf": "hmac-sha256", "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"}, "message": ""},
+        "checksum": {"function": "sha256", "params": {}, "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"},
+        "cipher": {"function": "aes-128-ctr", "params": {"iv": "264daa3f303d7259501c93d997d84fe6"}, "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"}
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+
+const vectorPassphrase = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+
+func Test_JSONKeystoreVector(t *testing.T) {
+	jks, err := keystore.UnmarshalJSONKeystore([]byte(pbkdf2Vector))
+	assert.NoError(t, err)
+
+	_, err = keystore.DecryptJSONKeystore(jks, "wrong passphrase")
+	assert.Equal(t, keystore.ErrorJSONKeystorePassphrase, err)
+
+	key, err := keystore.DecryptJSONKeystore(jks, vectorPassphrase)
+	assert.NoError(t, err)
+	assert.Equal(t, "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f", hex.EncodeToString(key.Secret.Marshal()))
+	assert.Equal(t, int64(-1), key.Path)
+}
+
+func Test_JSONKeystoreImportExport(t *testing.T) {
+	useTempDataPath(t)
+
+	secret, err := bls.RandKey()
+	assert.NoError(t, err)
+
+	jks, err := keystore.EncryptJSONKeystore(&keystore.Key{Secret: secret, Enable: true, Path: 3}, "json passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, "m/12381/1997/0/3", jks.Path)
+	assert.Equal(t, hex.EncodeToString(secret.PublicKey().Marshal()), jks.PubKey)
+
+	b, err := keystore.MarshalJSONKeystore(jks)
+	assert.NoError(t, err)
+	jks, err = keystore.UnmarshalJSONKeystore(b)
+	assert.NoError(t, err)
+
+	ks := keystore.NewKeystore()
+	assert.NoError(t, ks.CreateKeystore(testPassphrase))
+	defer ks.Close()
+
+	imported, err := ks.ImportKey(jks, "json passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), imported.Path)
+
+	_, err = ks.ImportKey(jks, "json passphrase")
+	assert.Equal(t, keystore.ErrorKeyExists, err)
+
+	var pub [48]byte
+	copy(pub[:], secret.PublicKey().Marshal())
+
+	exported, err := ks.ExportKey(pub, "other passphrase")
+	assert.NoError(t, err)
+
+	key, err := keystore.DecryptJSONKeystore(exported, "other passphrase")
+	assert.NoError(t, err)
+	assert.Equal(t, secret.Marshal(), key.Secret.Marshal())
+	assert.NotEqual(t, jks.UUID, exported.UUID)
+}
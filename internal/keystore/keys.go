@@ -100,8 +100,7 @@ func (k *keystore) GetValidatorKey(pubkey [48]byte) (*Key, bool) {
 		return nil, false
 	}
 
-	keystoreKey := new(Key)
-	err = keystoreKey.Unmarshal(key)
+	keystoreKey, err := k.decryptKey(key)
 	if err != nil {
 		return nil, false
 	}
@@ -122,9 +121,8 @@ func (k *keystore) GetValidatorKeys() ([]*Key, error) {
 
 		bkt := tx.Bucket(keysBucket)
 
-		err := bkt.ForEach(func(k, v []byte) error {
-			key := new(Key)
-			err := key.Unmarshal(v)
+		err := bkt.ForEach(func(_, v []byte) error {
+			key, err := k.decryptKey(v)
 			if err != nil {
 				return err
 			}
@@ -147,16 +145,21 @@ func (k *keystore) GetValidatorKeys() ([]*Key, error) {
 
 // GenerateNewValidatorKey generates new validator keys and adds it to the map and database.
 func (k *keystore) GenerateNewValidatorKey(amount uint64) ([]*Key, error) {
+	keys, err := k.DeriveNewValidatorKeys(amount)
+	if err != nil {
+		return nil, err
+	}
+	return keys, k.SaveValidatorKeys(keys)
+}
+
+// DeriveNewValidatorKeys derives the validator keys following the last path without adding them to the database.
+// The same keys are derived until they are saved.
+func (k *keystore) DeriveNewValidatorKeys(amount uint64) ([]*Key, error) {
 	if !k.open {
 		return nil, ErrorNoOpen
 	}
 
 	lastPath := k.GetLastPath()
-	newPath := lastPath + int(amount)
-	err := k.SetLastPath(newPath)
-	if err != nil {
-		return nil, err
-	}
 	keys := make([]*Key, amount)
 
 	seed := bip39.NewSeed(k.GetMnemonic(), "")
@@ -165,25 +168,44 @@ func (k *keystore) GenerateNewValidatorKey(amount uint64) ([]*Key, error) {
 		// Generate a new key
 		path := "m/12381/1997/0/" + strconv.Itoa(lastPath+aggPath)
 
-		sec, err := hdwallet.CreateHDWallet(seed, path)
+		sec, err := hdwallet.CreateBLSHDWallet(seed, path)
 		if err != nil {
 			return nil, err
 		}
 
-		key := &Key{
+		keys[i] = &Key{
 			Secret: sec,
 			Enable: true,
 			Path:   int64(lastPath + aggPath),
 		}
+	}
 
-		err = k.AddKey(key)
-		if err != nil {
-			return nil, err
+	return keys, nil
+}
+
+// SaveValidatorKeys adds derived validator keys to the database and moves the last path after them.
+func (k *keystore) SaveValidatorKeys(keys []*Key) error {
+	if !k.open {
+		return ErrorNoOpen
+	}
+
+	lastPath := k.GetLastPath()
+	for _, key := range keys {
+		if int(key.Path) > lastPath {
+			lastPath = int(key.Path)
 		}
-		keys[i] = key
+	}
+	if err := k.SetLastPath(lastPath); err != nil {
+		return err
 	}
 
-	return keys, nil
+	for _, key := range keys {
+		if err := k.AddKey(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (k *keystore) AddKey(key *Key) error {
@@ -197,7 +219,7 @@ func (k *keystore) AddKey(key *Key) error {
 
 		bkt := tx.Bucket(keysBucket)
 
-		kr, err := key.Marshal()
+		kr, err := k.encryptKey(key)
 		if err != nil {
 			return err
 		}
@@ -213,20 +235,22 @@ func (k *keystore) AddKey(key *Key) error {
 
 // ToggleKey toggles the keystore key as enabled/disabled
 func (k *keystore) ToggleKey(pub [48]byte, value bool) error {
+	if !k.open {
+		return ErrorNoOpen
+	}
 	err := k.db.Update(func(tx *bbolt.Tx) error {
 		keysBkt := tx.Bucket(keysBucket)
-		k := keysBkt.Get(pub[:])
-		if k == nil {
+		raw := keysBkt.Get(pub[:])
+		if raw == nil {
 			return ErrorKeyNotOnKeystore
 		}
-		key := new(Key)
-		err := key.Unmarshal(k)
+		key, err := k.decryptKey(raw)
 		if err != nil {
 			return err
 		}
 		key.Enable = value
 
-		rawK, err := key.Marshal()
+		rawK, err := k.encryptKey(key)
 		if err != nil {
 			return err
 		}
@@ -241,3 +265,26 @@ func (k *keystore) ToggleKey(pub [48]byte, value bool) error {
 	}
 	return nil
 }
+
+// encryptKey serializes and encrypts a key to store it on the database.
+func (k *keystore) encryptKey(key *Key) ([]byte, error) {
+	b, err := key.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(k.key, b)
+}
+
+// decryptKey decrypts and deserializes a key stored on the database.
+func (k *keystore) decryptKey(b []byte) (*Key, error) {
+	raw, err := decrypt(k.key, b)
+	if err != nil {
+		return nil, err
+	}
+	key := new(Key)
+	err = key.Unmarshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
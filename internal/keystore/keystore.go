@@ -5,7 +5,9 @@ import (
 	"errors"
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
 	"github.com/olympus-protocol/ogen/pkg/bip39"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
 	"go.etcd.io/bbolt"
+	"os"
 	"path"
 )
 
@@ -24,6 +26,24 @@ var (
 
 	// ErrorKeyNotOnKeystore returned when tried to fetch a key that is not on the keystore
 	ErrorKeyNotOnKeystore = errors.New("the specified public key doesn't exists on the keystore")
+
+	// ErrorNewerSchema returned when the keystore was created by a newer version of the software.
+	ErrorNewerSchema = errors.New("the keystore was created by a newer version of ogen, please upgrade")
+
+	// ErrorEmptyPassphrase returned when the keystore is created or encrypted without a passphrase.
+	ErrorEmptyPassphrase = errors.New("the keystore passphrase can't be empty")
+
+	// ErrorWrongPassphrase returned when the passphrase doesn't decrypt the keystore.
+	ErrorWrongPassphrase = errors.New("wrong keystore passphrase")
+
+	// ErrorUnknownKDF returned when the keystore uses unsupported key derivation parameters.
+	ErrorUnknownKDF = errors.New("unsupported keystore key derivation function")
+
+	// ErrorNotEncrypted returned when opening a keystore that stores the secrets in plaintext.
+	ErrorNotEncrypted = errors.New("the keystore is not encrypted, run 'ogen keystore encrypt' to encrypt it")
+
+	// ErrorAlreadyEncrypted returned when encrypting a keystore that is already encrypted.
+	ErrorAlreadyEncrypted = errors.New("the keystore is already encrypted")
 )
 
 var (
@@ -36,19 +56,27 @@ var (
 )
 
 type Keystore interface {
-	CreateKeystore() error
-	OpenKeystore() error
+	CreateKeystore(passphrase string) error
+	OpenKeystore(passphrase string) error
+	EncryptKeystore(passphrase string) error
+	ChangePassphrase(passphrase string) error
 	Close() error
 	GenerateNewValidatorKey(amount uint64) ([]*Key, error)
+	DeriveNewValidatorKeys(amount uint64) ([]*Key, error)
+	SaveValidatorKeys(keys []*Key) error
 	HasKeysToParticipate() bool
 
 	GetValidatorKey(pubkey [48]byte) (*Key, bool)
 	GetValidatorKeys() ([]*Key, error)
 	GetMnemonic() string
 	GetLastPath() int
+	GetWithdrawKey(index uint32) (common.SecretKey, error)
 
 	ToggleKey(pub [48]byte, value bool) error
 	AddKey(k *Key) error
+
+	ExportKey(pub [48]byte, passphrase string) (*JSONKeystore, error)
+	ImportKey(jks *JSONKeystore, passphrase string) (*Key, error)
 }
 
 // keystore is a wrapper for the keystore database
@@ -63,21 +91,28 @@ type keystore struct {
 	datapath string
 	// open prevents accessing the database when is closed
 	open bool
+	// kdf are the parameters used to derive the encryption key
+	kdf *kdfParams
+	// key is the encryption key derived from the passphrase
+	key []byte
 }
 
 var _ Keystore = &keystore{}
 
-// CreateKeystore will create a new keystore and initialize it with a new mnemonic.
-func (k *keystore) CreateKeystore() error {
+// CreateKeystore will create a new keystore and initialize it with a new mnemonic encrypted with the passphrase.
+func (k *keystore) CreateKeystore(passphrase string) error {
 	if k.open {
 		return ErrorAlreadyOpen
 	}
+	if passphrase == "" {
+		return ErrorEmptyPassphrase
+	}
 	// Open the database
-	db, err := bbolt.Open(path.Join(k.datapath, "keystore.db"), 0600, nil)
+	db, err := bbolt.Open(k.path(), 0600, nil)
 	if err != nil {
 		return err
 	}
-	err = k.initialize(db)
+	err = k.initialize(db, passphrase)
 	if err != nil {
 		_ = db.Close()
 		if err == bbolt.ErrBucketExists {
@@ -88,13 +123,91 @@ func (k *keystore) CreateKeystore() error {
 	return nil
 }
 
-// OpenKeystore opens an already created keystore, returns error if the Keystore is previously initialized.
-func (k *keystore) OpenKeystore() error {
+// OpenKeystore opens an already created keystore and decrypts it with the passphrase. Keystores storing the secrets
+// in plaintext must be encrypted with EncryptKeystore first.
+func (k *keystore) OpenKeystore(passphrase string) error {
 	if k.open {
 		return ErrorAlreadyOpen
 	}
 	// Open the database
-	db, err := bbolt.Open(path.Join(k.datapath, "keystore.db"), 0600, nil)
+	db, err := bbolt.Open(k.path(), 0600, nil)
+	if err != nil {
+		return err
+	}
+	err = k.checkState(db, true)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	err = k.migrate(db)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	err = k.unlock(db, passphrase)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	err = k.load(db)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	return nil
+}
+
+// EncryptKeystore encrypts the secrets of a keystore created before encryption with the passphrase and opens it.
+// The database is compacted and the migration backups of the plaintext versions removed to avoid leaving the
+// secrets in plaintext on disk.
+func (k *keystore) EncryptKeystore(passphrase string) error {
+	if k.open {
+		return ErrorAlreadyOpen
+	}
+	if passphrase == "" {
+		return ErrorEmptyPassphrase
+	}
+	db, err := bbolt.Open(k.path(), 0600, nil)
+	if err != nil {
+		return err
+	}
+	err = k.checkState(db, false)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	k.kdf, err = newKDFParams()
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	k.key, err = k.kdf.deriveKey(passphrase)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	err = k.migrate(db)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+
+	err = db.Close()
+	if err != nil {
+		return err
+	}
+	err = compactFile(k.path())
+	if err != nil {
+		return err
+	}
+	err = removePlaintextBackups(k.path())
+	if err != nil {
+		return err
+	}
+
+	db, err = bbolt.Open(k.path(), 0600, nil)
 	if err != nil {
 		return err
 	}
@@ -106,9 +219,91 @@ func (k *keystore) OpenKeystore() error {
 	return nil
 }
 
+// ChangePassphrase encrypts the keystore secrets with a new passphrase. The keystore must be open.
+func (k *keystore) ChangePassphrase(passphrase string) error {
+	if !k.open {
+		return ErrorNoOpen
+	}
+	if passphrase == "" {
+		return ErrorEmptyPassphrase
+	}
+
+	kdf, err := newKDFParams()
+	if err != nil {
+		return err
+	}
+	newKey, err := kdf.deriveKey(passphrase)
+	if err != nil {
+		return err
+	}
+
+	err = k.db.Update(func(tx *bbolt.Tx) error {
+		keysBkt := tx.Bucket(keysBucket)
+
+		reencrypted := make(map[string][]byte)
+		err := keysBkt.ForEach(func(pub, v []byte) error {
+			raw, err := decrypt(k.key, v)
+			if err != nil {
+				return err
+			}
+			enc, err := encrypt(newKey, raw)
+			if err != nil {
+				return err
+			}
+			reencrypted[string(pub)] = enc
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for pub, v := range reencrypted {
+			if err := keysBkt.Put([]byte(pub), v); err != nil {
+				return err
+			}
+		}
+
+		mnemonic, err := encrypt(newKey, []byte(k.mnemonic))
+		if err != nil {
+			return err
+		}
+		err = tx.Bucket(mnemonicBucket).Put(mnemonicKey, mnemonic)
+		if err != nil {
+			return err
+		}
+
+		return putKDFParams(tx, kdf)
+	})
+	if err != nil {
+		return err
+	}
+
+	k.kdf = kdf
+	k.key = newKey
+
+	// Compact the database to remove the secrets encrypted with the previous passphrase from the free pages.
+	err = k.db.Close()
+	if err != nil {
+		return err
+	}
+	k.open = false
+	err = compactFile(k.path())
+	if err != nil {
+		return err
+	}
+	db, err := bbolt.Open(k.path(), 0600, nil)
+	if err != nil {
+		return err
+	}
+	k.db = db
+	k.open = true
+	return nil
+}
+
 // Close closes the keystore database
 func (k *keystore) Close() error {
 	k.open = false
+	k.key = nil
+	k.mnemonic = ""
 	return k.db.Close()
 }
 
@@ -146,15 +341,28 @@ func (k *keystore) SetLastPath(p int) error {
 	return nil
 }
 
-func (k *keystore) initialize(db *bbolt.DB) error {
+func (k *keystore) initialize(db *bbolt.DB, passphrase string) error {
+	kdf, err := newKDFParams()
+	if err != nil {
+		return err
+	}
+	key, err := kdf.deriveKey(passphrase)
+	if err != nil {
+		return err
+	}
 
-	err := db.Update(func(tx *bbolt.Tx) error {
+	err = db.Update(func(tx *bbolt.Tx) error {
 
 		_, err := tx.CreateBucketIfNotExists(keysBucket)
 		if err != nil {
 			return err
 		}
 
+		err = setSchemaVersion(tx, SchemaVersion)
+		if err != nil {
+			return err
+		}
+
 		mnemonicBkt, err := tx.CreateBucketIfNotExists(mnemonicBucket)
 		if err != nil {
 			return err
@@ -170,7 +378,17 @@ func (k *keystore) initialize(db *bbolt.DB) error {
 			return err
 		}
 
-		err = mnemonicBkt.Put(mnemonicKey, []byte(mnemonic))
+		err = putKDFParams(tx, kdf)
+		if err != nil {
+			return err
+		}
+
+		encMnemonic, err := encrypt(key, []byte(mnemonic))
+		if err != nil {
+			return err
+		}
+
+		err = mnemonicBkt.Put(mnemonicKey, encMnemonic)
 		if err != nil {
 			return err
 		}
@@ -192,6 +410,8 @@ func (k *keystore) initialize(db *bbolt.DB) error {
 	if err != nil {
 		return err
 	}
+	k.kdf = kdf
+	k.key = key
 	return k.load(db)
 }
 
@@ -223,16 +443,98 @@ func (k *keystore) load(db *bbolt.DB) error {
 		return nil
 	})
 
+	if err != nil {
+		return err
+	}
+	mnemonic, err := decrypt(k.key, mnemonicBytes)
 	if err != nil {
 		return err
 	}
 	k.db = db
 	k.open = true
-	k.mnemonic = string(mnemonicBytes)
+	k.mnemonic = string(mnemonic)
 	k.lastPath = int(binary.LittleEndian.Uint64(lastPathBytes))
 	return nil
 }
 
+// checkState checks the keystore is initialized and its secrets are encrypted or not.
+func (k *keystore) checkState(db *bbolt.DB, encrypted bool) error {
+	return db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket(keysBucket) == nil {
+			return ErrorNotInitialized
+		}
+		isEncrypted := tx.Bucket(encryptionBucket) != nil
+		if encrypted && !isEncrypted {
+			return ErrorNotEncrypted
+		}
+		if !encrypted && isEncrypted {
+			return ErrorAlreadyEncrypted
+		}
+		return nil
+	})
+}
+
+// unlock derives the encryption key from the passphrase using the parameters stored on the keystore.
+func (k *keystore) unlock(db *bbolt.DB, passphrase string) error {
+	kdf := new(kdfParams)
+	err := db.View(func(tx *bbolt.Tx) error {
+		return kdf.Unmarshal(tx.Bucket(encryptionBucket).Get(kdfKey))
+	})
+	if err != nil {
+		return err
+	}
+	key, err := kdf.deriveKey(passphrase)
+	if err != nil {
+		return err
+	}
+	k.kdf = kdf
+	k.key = key
+	return nil
+}
+
+func (k *keystore) path() string {
+	return path.Join(k.datapath, "keystore.db")
+}
+
+func putKDFParams(tx *bbolt.Tx, kdf *kdfParams) error {
+	bkt, err := tx.CreateBucketIfNotExists(encryptionBucket)
+	if err != nil {
+		return err
+	}
+	b, err := kdf.Marshal()
+	if err != nil {
+		return err
+	}
+	return bkt.Put(kdfKey, b)
+}
+
+// compactFile rewrites the database on a new file so the data deleted or overwritten is not kept on the free pages.
+func compactFile(p string) error {
+	src, err := bbolt.Open(p, 0600, &bbolt.Options{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := p + ".compact"
+	dst, err := bbolt.Open(tmp, 0600, nil)
+	if err != nil {
+		return err
+	}
+
+	err = bbolt.Compact(dst, src, 0)
+	if err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	err = dst.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
 // NewKeystore creates a new keystore instance.
 func NewKeystore() Keystore {
 	return &keystore{
@@ -7,17 +7,29 @@ import (
 	"testing"
 )
 
+const testPassphrase = "test passphrase"
+
 func init() {
 	config.GlobalFlags = &config.Flags{
 		DataPath: "./",
 	}
 }
 
+// useTempDataPath sets the data folder to a temporary directory until the test ends and returns it.
+func useTempDataPath(t *testing.T) string {
+	datapath := config.GlobalFlags.DataPath
+	t.Cleanup(func() {
+		config.GlobalFlags.DataPath = datapath
+	})
+	config.GlobalFlags.DataPath = t.TempDir()
+	return config.GlobalFlags.DataPath
+}
+
 func Test_Keystore(t *testing.T) {
 
 	ks := keystore.NewKeystore()
 
-	err := ks.CreateKeystore()
+	err := ks.CreateKeystore(testPassphrase)
 	assert.NoError(t, err)
 
 	mnemonic := ks.GetMnemonic()
@@ -27,7 +39,7 @@ func Test_Keystore(t *testing.T) {
 	err = ks.Close()
 	assert.NoError(t, err)
 
-	err = ks.OpenKeystore()
+	err = ks.OpenKeystore(testPassphrase)
 	assert.NoError(t, err)
 
 	openMnemonic := ks.GetMnemonic()
@@ -48,7 +60,7 @@ func Test_Keystore(t *testing.T) {
 	err = ks.Close()
 	assert.NoError(t, err)
 
-	err = ks.OpenKeystore()
+	err = ks.OpenKeystore(testPassphrase)
 	assert.NoError(t, err)
 
 	assert.Equal(t, 25, ks.GetLastPath())
@@ -77,3 +89,69 @@ func Test_Keystore(t *testing.T) {
 	assert.False(t, keyDisabled.Enable)
 
 }
+
+func Test_KeystorePassphrase(t *testing.T) {
+	useTempDataPath(t)
+
+	ks := keystore.NewKeystore()
+
+	assert.Equal(t, keystore.ErrorEmptyPassphrase, ks.CreateKeystore(""))
+	assert.NoError(t, ks.CreateKeystore(testPassphrase))
+
+	mnemonic := ks.GetMnemonic()
+	keys, err := ks.GenerateNewValidatorKey(2)
+	assert.NoError(t, err)
+	assert.NoError(t, ks.Close())
+
+	assert.Equal(t, keystore.ErrorWrongPassphrase, ks.OpenKeystore("wrong passphrase"))
+
+	assert.NoError(t, ks.OpenKeystore(testPassphrase))
+	assert.NoError(t, ks.ChangePassphrase("new passphrase"))
+
+	var pub [48]byte
+	copy(pub[:], keys[0].Secret.PublicKey().Marshal())
+	key, ok := ks.GetValidatorKey(pub)
+	assert.True(t, ok)
+	assert.Equal(t, keys[0].Secret.Marshal(), key.Secret.Marshal())
+	assert.NoError(t, ks.Close())
+
+	assert.Equal(t, keystore.ErrorWrongPassphrase, ks.OpenKeystore(testPassphrase))
+	assert.NoError(t, ks.OpenKeystore("new passphrase"))
+	assert.Equal(t, mnemonic, ks.GetMnemonic())
+
+	allKeys, err := ks.GetValidatorKeys()
+	assert.NoError(t, err)
+	assert.Len(t, allKeys, 2)
+	assert.NoError(t, ks.Close())
+}
+
+func Test_KeystoreDeriveKeys(t *testing.T) {
+	useTempDataPath(t)
+
+	ks := keystore.NewKeystore()
+	assert.NoError(t, ks.CreateKeystore(testPassphrase))
+	defer ks.Close()
+
+	keys, err := ks.DeriveNewValidatorKeys(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, ks.GetLastPath())
+
+	var pub [48]byte
+	copy(pub[:], keys[0].Secret.PublicKey().Marshal())
+	_, ok := ks.GetValidatorKey(pub)
+	assert.False(t, ok)
+
+	// The same keys are derived until they are saved.
+	again, err := ks.DeriveNewValidatorKeys(2)
+	assert.NoError(t, err)
+	assert.Equal(t, keys[1].Secret.Marshal(), again[1].Secret.Marshal())
+
+	assert.NoError(t, ks.SaveValidatorKeys(keys))
+	assert.Equal(t, 2, ks.GetLastPath())
+	_, ok = ks.GetValidatorKey(pub)
+	assert.True(t, ok)
+
+	next, err := ks.DeriveNewValidatorKeys(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), next[0].Path)
+}
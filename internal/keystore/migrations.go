@@ -0,0 +1,169 @@
+package keystore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"go.etcd.io/bbolt"
+)
+
+// SchemaVersion is the version of the keystore database layout supported by this binary.
+const SchemaVersion = 2
+
+// encryptionSchemaVersion is the first schema version with encrypted secrets. The backups of older versions
+// have the secrets in plaintext.
+const encryptionSchemaVersion = 2
+
+var (
+	schemaBucket     = []byte("schema")
+	schemaVersionKey = []byte("schema-version")
+)
+
+// migration upgrades the keystore database from the previous schema version to version.
+type migration struct {
+	version     uint64
+	description string
+	migrate     func(k *keystore, tx *bbolt.Tx) error
+}
+
+// migrations must be sorted by version. Keystores created before versioning have schema version 0.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "add schema version",
+		migrate:     func(k *keystore, tx *bbolt.Tx) error { return nil },
+	},
+	{
+		version:     encryptionSchemaVersion,
+		description: "encrypt the mnemonic and the keys",
+		migrate:     encryptSecrets,
+	},
+}
+
+// encryptSecrets encrypts the mnemonic and the keys with the key set by EncryptKeystore.
+func encryptSecrets(k *keystore, tx *bbolt.Tx) error {
+	if tx.Bucket(encryptionBucket) != nil {
+		return nil
+	}
+	if k.key == nil {
+		return ErrorNotEncrypted
+	}
+
+	keysBkt := tx.Bucket(keysBucket)
+
+	encrypted := make(map[string][]byte)
+	err := keysBkt.ForEach(func(pub, v []byte) error {
+		enc, err := encrypt(k.key, v)
+		if err != nil {
+			return err
+		}
+		encrypted[string(pub)] = enc
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for pub, v := range encrypted {
+		if err := keysBkt.Put([]byte(pub), v); err != nil {
+			return err
+		}
+	}
+
+	mnemonicBkt := tx.Bucket(mnemonicBucket)
+	mnemonic, err := encrypt(k.key, mnemonicBkt.Get(mnemonicKey))
+	if err != nil {
+		return err
+	}
+	err = mnemonicBkt.Put(mnemonicKey, mnemonic)
+	if err != nil {
+		return err
+	}
+
+	return putKDFParams(tx, k.kdf)
+}
+
+func getSchemaVersion(tx *bbolt.Tx) uint64 {
+	bkt := tx.Bucket(schemaBucket)
+	if bkt == nil {
+		return 0
+	}
+	v := bkt.Get(schemaVersionKey)
+	if len(v) != 8 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(v)
+}
+
+func setSchemaVersion(tx *bbolt.Tx, version uint64) error {
+	bkt, err := tx.CreateBucketIfNotExists(schemaBucket)
+	if err != nil {
+		return err
+	}
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], version)
+	return bkt.Put(schemaVersionKey, buf[:])
+}
+
+// migrate runs the pending migrations on the keystore database. A copy of the database is stored
+// next to it before any migration is applied.
+func (k *keystore) migrate(db *bbolt.DB) error {
+	var version uint64
+	err := db.View(func(tx *bbolt.Tx) error {
+		version = getSchemaVersion(tx)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if version > SchemaVersion {
+		return fmt.Errorf("%w: keystore schema version %d, supported up to %d", ErrorNewerSchema, version, SchemaVersion)
+	}
+
+	if version == SchemaVersion {
+		return nil
+	}
+
+	backup := backupPath(db.Path(), version)
+	err = db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(backup, 0600)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to backup keystore before migration: %s", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		err := db.Update(func(tx *bbolt.Tx) error {
+			if err := m.migrate(k, tx); err != nil {
+				return err
+			}
+			return setSchemaVersion(tx, m.version)
+		})
+		if err != nil {
+			return fmt.Errorf("keystore migration to version %d (%s) failed, a backup is available at %s: %s", m.version, m.description, backup, err)
+		}
+	}
+
+	return nil
+}
+
+// backupPath returns the path of the copy stored before migrating the keystore from a schema version.
+func backupPath(dbPath string, version uint64) string {
+	return fmt.Sprintf("%s.backup-v%d", dbPath, version)
+}
+
+// removePlaintextBackups removes the backups stored by the migrations before the secrets were encrypted.
+func removePlaintextBackups(dbPath string) error {
+	for version := uint64(0); version < encryptionSchemaVersion; version++ {
+		backup := backupPath(dbPath, version)
+		err := os.Remove(backup)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove the plaintext keystore backup %s, it must be deleted manually: %s", backup, err)
+		}
+	}
+	return nil
+}
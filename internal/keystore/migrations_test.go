@@ -0,0 +1,132 @@
+package keystore_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func Test_KeystoreMigrations(t *testing.T) {
+
+	dir := useTempDataPath(t)
+	dbPath := path.Join(dir, "keystore.db")
+
+	ks := keystore.NewKeystore()
+	assert.NoError(t, ks.CreateKeystore(testPassphrase))
+	mnemonic := ks.GetMnemonic()
+	assert.NoError(t, ks.Close())
+
+	// Remove the schema version to simulate a keystore created before versioning.
+	db, err := bbolt.Open(dbPath, 0600, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		return tx.DeleteBucket([]byte("schema"))
+	}))
+	assert.NoError(t, db.Close())
+
+	assert.NoError(t, ks.OpenKeystore(testPassphrase))
+	assert.Equal(t, mnemonic, ks.GetMnemonic())
+	assert.NoError(t, ks.Close())
+
+	_, err = os.Stat(dbPath + ".backup-v0")
+	assert.NoError(t, err)
+
+	// Set a schema version newer than the supported one.
+	db, err = bbolt.Open(dbPath, 0600, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], keystore.SchemaVersion+1)
+		return tx.Bucket([]byte("schema")).Put([]byte("schema-version"), buf[:])
+	}))
+	assert.NoError(t, db.Close())
+
+	err = ks.OpenKeystore(testPassphrase)
+	assert.ErrorIs(t, err, keystore.ErrorNewerSchema)
+}
+
+func Test_KeystoreEncryptPlaintext(t *testing.T) {
+
+	dir := useTempDataPath(t)
+	dbPath := path.Join(dir, "keystore.db")
+
+	secret, err := bls.RandKey()
+	assert.NoError(t, err)
+	key := &keystore.Key{Secret: secret, Enable: true, Path: 1}
+	rawKey, err := key.Marshal()
+	assert.NoError(t, err)
+
+	var pub [48]byte
+	copy(pub[:], secret.PublicKey().Marshal())
+
+	mnemonic := "test mnemonic"
+
+	// Create a schema version 1 keystore with the secrets in plaintext.
+	db, err := bbolt.Open(dbPath, 0600, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		keys, err := tx.CreateBucket([]byte("keys"))
+		if err != nil {
+			return err
+		}
+		if err := keys.Put(pub[:], rawKey); err != nil {
+			return err
+		}
+		mnemonicBkt, err := tx.CreateBucket([]byte("mnemonic"))
+		if err != nil {
+			return err
+		}
+		if err := mnemonicBkt.Put([]byte("mnemonic-key"), []byte(mnemonic)); err != nil {
+			return err
+		}
+		lastPath, err := tx.CreateBucket([]byte("last-path"))
+		if err != nil {
+			return err
+		}
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], 1)
+		if err := lastPath.Put([]byte("last-path-key"), buf[:]); err != nil {
+			return err
+		}
+		schema, err := tx.CreateBucket([]byte("schema"))
+		if err != nil {
+			return err
+		}
+		binary.LittleEndian.PutUint64(buf[:], 1)
+		return schema.Put([]byte("schema-version"), buf[:])
+	}))
+	assert.NoError(t, db.Close())
+
+	// A plaintext backup left by a migration of an older binary.
+	assert.NoError(t, ioutil.WriteFile(dbPath+".backup-v0", []byte(mnemonic), 0600))
+
+	ks := keystore.NewKeystore()
+	assert.Equal(t, keystore.ErrorNotEncrypted, ks.OpenKeystore(testPassphrase))
+
+	assert.NoError(t, ks.EncryptKeystore(testPassphrase))
+	assert.Equal(t, mnemonic, ks.GetMnemonic())
+	assert.NoError(t, ks.Close())
+
+	_, err = os.Stat(dbPath + ".backup-v0")
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(dbPath + ".backup-v1")
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Equal(t, keystore.ErrorAlreadyEncrypted, ks.EncryptKeystore(testPassphrase))
+
+	assert.NoError(t, ks.OpenKeystore(testPassphrase))
+	assert.Equal(t, mnemonic, ks.GetMnemonic())
+	assert.Equal(t, 1, ks.GetLastPath())
+
+	stored, ok := ks.GetValidatorKey(pub)
+	assert.True(t, ok)
+	assert.Equal(t, key, stored)
+	assert.NoError(t, ks.Close())
+}
@@ -0,0 +1,23 @@
+package keystore
+
+import (
+	"strconv"
+
+	"github.com/olympus-protocol/ogen/pkg/bip39"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/hdwallet"
+)
+
+// withdrawPathPrefix is the derivation path of the withdraw keys derived from the keystore mnemonic without the index.
+const withdrawPathPrefix = "m/12381/1997/1/"
+
+// GetWithdrawKey derives the withdraw key of an index from the keystore mnemonic. Withdraw keys fund the deposits and
+// sign the exits of the validators, they are never stored on the keystore.
+func (k *keystore) GetWithdrawKey(index uint32) (common.SecretKey, error) {
+	if !k.open {
+		return nil, ErrorNoOpen
+	}
+
+	seed := bip39.NewSeed(k.GetMnemonic(), "")
+	return hdwallet.CreateBLSHDWallet(seed, withdrawPathPrefix+strconv.Itoa(int(index)))
+}
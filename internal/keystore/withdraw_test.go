@@ -0,0 +1,41 @@
+package keystore_test
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithdrawKey(t *testing.T) {
+	useTempDataPath(t)
+
+	ks := keystore.NewKeystore()
+
+	_, err := ks.GetWithdrawKey(0)
+	assert.Equal(t, keystore.ErrorNoOpen, err)
+
+	assert.NoError(t, ks.CreateKeystore(testPassphrase))
+
+	first, err := ks.GetWithdrawKey(0)
+	assert.NoError(t, err)
+	second, err := ks.GetWithdrawKey(1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Marshal(), second.Marshal())
+
+	// Withdraw keys don't use the validator keys derivation path and are not stored.
+	keys, err := ks.GenerateNewValidatorKey(1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, keys[0].Secret.Marshal(), second.Marshal())
+
+	assert.NoError(t, ks.Close())
+	assert.NoError(t, ks.OpenKeystore(testPassphrase))
+	again, err := ks.GetWithdrawKey(1)
+	assert.NoError(t, err)
+	assert.Equal(t, second.Marshal(), again.Marshal())
+
+	allKeys, err := ks.GetValidatorKeys()
+	assert.NoError(t, err)
+	assert.Len(t, allKeys, 1)
+	assert.NoError(t, ks.Close())
+}
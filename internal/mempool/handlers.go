@@ -3,9 +3,19 @@ package mempool
 import (
 	"errors"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/internal/state"
 	"github.com/olympus-protocol/ogen/pkg/p2p"
 )
 
+// invalidVotePenalty is the ban score added to a peer gossiping a vote that doesn't validate.
+const invalidVotePenalty = 20
+
+// isInvalidVote returns true for the errors of votes that are not valid on any chain. Votes for a different
+// justified checkpoint or target may be valid on the fork of the peer, they are dropped without penalty.
+func isInvalidVote(err error) bool {
+	return err == state.ErrorVoteSignature || err == state.ErrorVoteSlot
+}
+
 func (p *pool) handleVote(id peer.ID, msg p2p.Message) error {
 
 	if id == p.host.ID() {
@@ -42,9 +52,14 @@ func (p *pool) handleVote(id peer.ID, msg p2p.Message) error {
 	p.log.Debugf("received vote from %s with %d votes", id, len(data.Data.ParticipationBitfield.BitIndices()))
 	err = p.AddVote(data.Data, currentState)
 	if err != nil {
+		if isInvalidVote(err) {
+			p.host.PenalizePeer(id, invalidVotePenalty)
+		}
 		return err
 	}
 
+	p.notifyVote(data.Data, currentState)
+
 	return nil
 }
 
@@ -138,3 +153,39 @@ func (p *pool) handleTx(id peer.ID, msg p2p.Message) error {
 
 	return nil
 }
+
+func (p *pool) handleSlashings(id peer.ID, msg p2p.Message) error {
+	if id == p.host.ID() {
+		return nil
+	}
+
+	p.host.IncreasePeerReceivedBytes(id, msg.PayloadLength())
+
+	data, ok := msg.(*p2p.MsgSlashings)
+	if !ok {
+		return errors.New("wrong message on slashings topic")
+	}
+
+	for _, d := range data.ProposerSlashings {
+		err := p.AddProposerSlashing(d)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, d := range data.VoteSlashings {
+		err := p.AddVoteSlashing(d)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, d := range data.RANDAOSlashings {
+		err := p.AddRANDAOSlashing(d)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
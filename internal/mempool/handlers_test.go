@@ -0,0 +1,56 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/internal/host"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHost records the penalties of the peers.
+type testHost struct {
+	host.Host
+
+	penalties map[peer.ID]uint64
+}
+
+func (h *testHost) ID() peer.ID {
+	return "self"
+}
+
+func (h *testHost) IncreasePeerReceivedBytes(peer.ID, uint64) {}
+
+func (h *testHost) PenalizePeer(p peer.ID, score uint64) {
+	h.penalties[p] += score
+}
+
+func Test_HandleVotePenalties(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	ch.Extend(t, 5)
+
+	h := &testHost{penalties: make(map[peer.ID]uint64)}
+	p := NewPool(ch, h).(*pool)
+
+	tip := ch.State().Tip()
+
+	// A vote for a different justified checkpoint may be valid on the fork of the peer.
+	otherFork := ch.Vote(t, tip.Hash, tip.Slot)
+	otherFork.Data.FromEpoch++
+	assert.Error(t, p.handleVote("peer", &p2p.MsgVote{Data: otherFork}))
+	assert.Empty(t, h.penalties)
+
+	key, err := bls.RandKey()
+	assert.NoError(t, err)
+	forged := ch.Vote(t, tip.Hash, tip.Slot)
+	msg := forged.Data.SigningMessage(p.netParams)
+	copy(forged.Sig[:], key.Sign(msg[:]).Marshal())
+	assert.Error(t, p.handleVote("peer", &p2p.MsgVote{Data: forged}))
+	assert.Equal(t, map[peer.ID]uint64{"peer": invalidVotePenalty}, h.penalties)
+
+	assert.NoError(t, p.handleVote("other", &p2p.MsgVote{Data: ch.Vote(t, tip.Hash, tip.Slot)}))
+	assert.Equal(t, map[peer.ID]uint64{"peer": invalidVotePenalty}, h.penalties)
+}
@@ -45,12 +45,16 @@ type Pool interface {
 	GetRANDAOSlashings(s state.State) ([]*primitives.RANDAOSlashing, state.State)
 
 	RemoveByBlock(b *primitives.Block, s state.State)
+
+	NotifyVotes(n VoteNotifee)
+	UnnotifyVotes(n VoteNotifee)
 }
 
 type pool struct {
 	netParams *params.ChainParams
 	log       logger.Logger
 	ctx       context.Context
+	datapath  string
 
 	chain chain.Blockchain
 	host  host.Host
@@ -73,6 +77,11 @@ type pool struct {
 	proposerSlashings []*primitives.ProposerSlashing
 
 	randaoSlashings []*primitives.RANDAOSlashing
+
+	slashingsLock sync.Mutex
+
+	notifees    map[VoteNotifee]struct{}
+	notifeeLock sync.Mutex
 }
 
 func (p *pool) AddVote(d *primitives.MultiValidatorVote, s state.State) error {
@@ -164,6 +173,7 @@ func (p *pool) AddVote(d *primitives.MultiValidatorVote, s state.State) error {
 				if err != nil {
 					return true
 				}
+				p.announceSlashings(&p2p.MsgSlashings{VoteSlashings: []*primitives.VoteSlashing{vs}})
 				return true
 			}
 		}
@@ -211,6 +221,7 @@ func (p *pool) AddVote(d *primitives.MultiValidatorVote, s state.State) error {
 				if err != nil {
 					return err
 				}
+				p.announceSlashings(&p2p.MsgSlashings{VoteSlashings: []*primitives.VoteSlashing{vs}})
 				return nil
 			}
 
@@ -360,7 +371,7 @@ func (p *pool) AddTx(d *primitives.Tx) error {
 		return fmt.Errorf("insufficient balance of %d for %d transaction", cs.Balances[fpkh], d.Amount+d.Fee)
 	}
 
-	if err := d.VerifySig(); err != nil {
+	if err := d.VerifySig(p.netParams, p.currentSlot()); err != nil {
 		return err
 	}
 
@@ -382,6 +393,16 @@ func (p *pool) AddTx(d *primitives.Tx) error {
 func (p *pool) AddVoteSlashing(d *primitives.VoteSlashing) error {
 	p.log.Warn("WARNING: Vote slashing condition detected.")
 
+	if err := p.addVoteSlashing(d); err != nil {
+		p.log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// addVoteSlashing validates a vote slashing against the tip state and adds it to the pool.
+func (p *pool) addVoteSlashing(d *primitives.VoteSlashing) error {
 	slot1 := d.Vote1.Data.Slot
 	slot2 := d.Vote2.Data.Slot
 
@@ -392,15 +413,16 @@ func (p *pool) AddVoteSlashing(d *primitives.VoteSlashing) error {
 
 	tipState, err := p.chain.State().TipStateAtSlot(maxSlot)
 	if err != nil {
-		p.log.Error(err)
 		return err
 	}
 
 	if _, err := tipState.IsVoteSlashingValid(d); err != nil {
-		p.log.Error(err)
 		return err
 	}
 
+	p.slashingsLock.Lock()
+	defer p.slashingsLock.Unlock()
+
 	sh := d.Hash()
 	for _, d := range p.voteSlashings {
 		dh := d.Hash()
@@ -434,6 +456,9 @@ func (p *pool) AddProposerSlashing(d *primitives.ProposerSlashing) error {
 		return nil
 	}
 
+	p.slashingsLock.Lock()
+	defer p.slashingsLock.Unlock()
+
 	sh := d.Hash()
 	for _, d := range p.proposerSlashings {
 		dh := d.Hash()
@@ -447,6 +472,13 @@ func (p *pool) AddProposerSlashing(d *primitives.ProposerSlashing) error {
 	return nil
 }
 
+// announceSlashings broadcasts the slashings detected by this node.
+func (p *pool) announceSlashings(msg *p2p.MsgSlashings) {
+	if err := p.host.Broadcast(msg); err != nil {
+		p.log.Error(err)
+	}
+}
+
 func (p *pool) AddRANDAOSlashing(_ *primitives.RANDAOSlashing) error {
 	//panic("implement me")
 	return nil
@@ -669,6 +701,8 @@ func (p *pool) GetTxs(s state.State, feeReceiver [20]byte) ([]*primitives.Tx, st
 }
 
 func (p *pool) GetVoteSlashings(s state.State) ([]*primitives.VoteSlashing, state.State) {
+	p.slashingsLock.Lock()
+	defer p.slashingsLock.Unlock()
 
 	slashings := make([]*primitives.VoteSlashing, 0, primitives.MaxVoteSlashingsPerBlock)
 	newMempool := make([]*primitives.VoteSlashing, 0, len(p.voteSlashings))
@@ -691,6 +725,8 @@ func (p *pool) GetVoteSlashings(s state.State) ([]*primitives.VoteSlashing, stat
 }
 
 func (p *pool) GetProposerSlashings(s state.State) ([]*primitives.ProposerSlashing, state.State) {
+	p.slashingsLock.Lock()
+	defer p.slashingsLock.Unlock()
 
 	slashings := make([]*primitives.ProposerSlashing, 0, primitives.MaxProposerSlashingsPerBlock)
 	newMempool := make([]*primitives.ProposerSlashing, 0, len(p.proposerSlashings))
@@ -713,6 +749,8 @@ func (p *pool) GetProposerSlashings(s state.State) ([]*primitives.ProposerSlashi
 }
 
 func (p *pool) GetRANDAOSlashings(s state.State) ([]*primitives.RANDAOSlashing, state.State) {
+	p.slashingsLock.Lock()
+	defer p.slashingsLock.Unlock()
 
 	slashings := make([]*primitives.RANDAOSlashing, 0, primitives.MaxRANDAOSlashingsPerBlock)
 	newMempool := make([]*primitives.RANDAOSlashing, 0, len(p.randaoSlashings))
@@ -841,6 +879,9 @@ func (p *pool) RemoveByBlock(b *primitives.Block, s state.State) {
 		}
 	}
 
+	p.slashingsLock.Lock()
+	defer p.slashingsLock.Unlock()
+
 	newProposerSlashings := make([]*primitives.ProposerSlashing, 0, len(p.proposerSlashings))
 	for _, ps := range p.proposerSlashings {
 		psHash := ps.Hash()
@@ -936,13 +977,20 @@ func (p *pool) RemoveByBlock(b *primitives.Block, s state.State) {
 var _ Pool = &pool{}
 
 func (p *pool) Close() {
-	datapath := config.GlobalFlags.DataPath
-	_ = p.pool.SaveToFile(datapath + "/mempool")
+	if err := p.save(); err != nil {
+		p.log.Errorf("unable to save mempool: %s", err)
+	}
 }
 
-// Start initializes the pool listeners
+// Start loads the pool entries stored on disk and initializes the pool listeners
 func (p *pool) Start() {
 
+	if err := p.load(); err != nil {
+		p.log.Errorf("unable to load mempool: %s", err)
+	}
+
+	go p.persistHandler()
+
 	p.host.RegisterTopicHandler(p2p.MsgVoteCmd, p.handleVote)
 
 	p.host.RegisterTopicHandler(p2p.MsgDepositsCmd, p.handleDeposits)
@@ -953,31 +1001,39 @@ func (p *pool) Start() {
 
 	p.host.RegisterTopicHandler(p2p.MsgTxCmd, p.handleTx)
 
+	p.host.RegisterTopicHandler(p2p.MsgSlashingsCmd, p.handleSlashings)
+
+	p.host.RegisterTopicValidator(p2p.MsgVoteCmd, p.validateVote)
+
+	p.host.RegisterTopicValidator(p2p.MsgDepositsCmd, p.validateDeposits)
+
+	p.host.RegisterTopicValidator(p2p.MsgExitsCmd, p.validateExits)
+
+	p.host.RegisterTopicValidator(p2p.MsgPartialExitsCmd, p.validatePartialExits)
+
+	p.host.RegisterTopicValidator(p2p.MsgTxCmd, p.validateTx)
+
+	p.host.RegisterTopicValidator(p2p.MsgSlashingsCmd, p.validateSlashings)
+
 	return
 
 }
 
 func NewPool(ch chain.Blockchain, h host.Host) Pool {
-	datapath := config.GlobalFlags.DataPath
-
-	var cache *fastcache.Cache
-	var err error
-	cache, err = fastcache.LoadFromFile(datapath + "/mempool")
-	if err != nil {
-		cache = fastcache.New(300 * 1024 * 1024)
-	}
 	return &pool{
 		netParams: config.GlobalParams.NetParams,
 		log:       config.GlobalParams.Logger,
 		ctx:       config.GlobalParams.Context,
+		datapath:  config.GlobalFlags.DataPath,
 		chain:     ch,
 		host:      h,
 
-		pool: cache,
+		pool: fastcache.New(300 * 1024 * 1024),
 
 		singleVotes:       make(map[[32]byte][]*primitives.MultiValidatorVote),
 		voteSlashings:     []*primitives.VoteSlashing{},
 		proposerSlashings: []*primitives.ProposerSlashing{},
 		randaoSlashings:   []*primitives.RANDAOSlashing{},
+		notifees:          make(map[VoteNotifee]struct{}),
 	}
 }
@@ -0,0 +1,64 @@
+package mempool
+
+import (
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// VoteNotifee is notified of the votes received from the network.
+type VoteNotifee interface {
+	// NewVote notifies of a valid vote received from a peer and the indices of the validators that signed it.
+	NewVote(vote *primitives.MultiValidatorVote, validators []uint64)
+}
+
+// NotifyVotes registers a notifee to be notified of the received votes.
+func (p *pool) NotifyVotes(n VoteNotifee) {
+	p.notifeeLock.Lock()
+	defer p.notifeeLock.Unlock()
+
+	p.notifees[n] = struct{}{}
+}
+
+// UnnotifyVotes unregisters a vote notifee.
+func (p *pool) UnnotifyVotes(n VoteNotifee) {
+	p.notifeeLock.Lock()
+	defer p.notifeeLock.Unlock()
+
+	delete(p.notifees, n)
+}
+
+// notifyVote notifies a received vote to the notifees.
+func (p *pool) notifyVote(vote *primitives.MultiValidatorVote, s state.State) {
+	p.notifeeLock.Lock()
+	defer p.notifeeLock.Unlock()
+
+	if len(p.notifees) == 0 {
+		return
+	}
+
+	validators, err := VoteValidators(vote, s)
+	if err != nil {
+		p.log.Debugf("unable to get the validators of a vote: %s", err)
+		return
+	}
+
+	for n := range p.notifees {
+		n.NewVote(vote, validators)
+	}
+}
+
+// VoteValidators returns the indices of the validators that signed a vote.
+func VoteValidators(vote *primitives.MultiValidatorVote, s state.State) ([]uint64, error) {
+	committee, err := s.GetVoteCommittee(vote.Data.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	var validators []uint64
+	for _, i := range vote.ParticipationBitfield.BitIndices() {
+		if i < len(committee) {
+			validators = append(validators, committee[i])
+		}
+	}
+	return validators, nil
+}
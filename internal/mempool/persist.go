@@ -0,0 +1,246 @@
+package mempool
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// PoolFileVersion is the version of the on-disk mempool format.
+const PoolFileVersion = 1
+
+// persistInterval is the interval between pool saves while the node is running.
+const persistInterval = time.Minute * 5
+
+// SerializablePool is the on-disk representation of the pool entries.
+type SerializablePool struct {
+	Version           uint64
+	Votes             []*primitives.MultiValidatorVote `ssz-max:"65536"`
+	Deposits          []*primitives.Deposit            `ssz-max:"65536"`
+	Exits             []*primitives.Exit               `ssz-max:"65536"`
+	PartialExits      []*primitives.PartialExit        `ssz-max:"65536"`
+	Txs               []*primitives.Tx                 `ssz-max:"1048576"`
+	VoteSlashings     []*primitives.VoteSlashing       `ssz-max:"1024"`
+	ProposerSlashings []*primitives.ProposerSlashing   `ssz-max:"1024"`
+	RANDAOSlashings   []*primitives.RANDAOSlashing     `ssz-max:"1024"`
+}
+
+// Marshal encodes the data.
+func (s *SerializablePool) Marshal() ([]byte, error) {
+	b, err := s.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, b), nil
+}
+
+// Unmarshal decodes the data.
+func (s *SerializablePool) Unmarshal(b []byte) error {
+	d, err := snappy.Decode(nil, b)
+	if err != nil {
+		return err
+	}
+	return s.UnmarshalSSZ(d)
+}
+
+func (p *pool) poolFilePath() string {
+	return path.Join(p.datapath, "mempool")
+}
+
+// toSerializable collects all the pool entries.
+func (p *pool) toSerializable() *SerializablePool {
+	sp := &SerializablePool{
+		Version: PoolFileVersion,
+	}
+
+	p.votesKeys.Range(func(key, value interface{}) bool {
+		hash := key.(chainhash.Hash)
+		v := new(primitives.MultiValidatorVote)
+		if err := v.Unmarshal(p.pool.Get(nil, appendKey(hash[:], PoolTypeVote))); err == nil {
+			sp.Votes = append(sp.Votes, v)
+		}
+		return true
+	})
+
+	p.depositKeys.Range(func(key, value interface{}) bool {
+		pub := key.([48]byte)
+		d := new(primitives.Deposit)
+		if err := d.Unmarshal(p.pool.Get(nil, appendKey(pub[:], PoolTypeDeposit))); err == nil {
+			sp.Deposits = append(sp.Deposits, d)
+		}
+		return true
+	})
+
+	p.exitKeys.Range(func(key, value interface{}) bool {
+		pub := key.([48]byte)
+		e := new(primitives.Exit)
+		if err := e.Unmarshal(p.pool.Get(nil, appendKey(pub[:], PoolTypeExit))); err == nil {
+			sp.Exits = append(sp.Exits, e)
+		}
+		return true
+	})
+
+	p.partialExitKeys.Range(func(key, value interface{}) bool {
+		pub := key.([48]byte)
+		pe := new(primitives.PartialExit)
+		if err := pe.Unmarshal(p.pool.Get(nil, appendKey(pub[:], PoolTypePartialExit))); err == nil {
+			sp.PartialExits = append(sp.PartialExits, pe)
+		}
+		return true
+	})
+
+	p.txKeys.Range(func(key, value interface{}) bool {
+		txKey := key.([28]byte)
+		tx := new(primitives.Tx)
+		if err := tx.Unmarshal(p.pool.Get(nil, appendKey(txKey[:], PoolTypeTx))); err == nil {
+			sp.Txs = append(sp.Txs, tx)
+		}
+		return true
+	})
+
+	p.slashingsLock.Lock()
+	sp.VoteSlashings = append(sp.VoteSlashings, p.voteSlashings...)
+	sp.ProposerSlashings = append(sp.ProposerSlashings, p.proposerSlashings...)
+	sp.RANDAOSlashings = append(sp.RANDAOSlashings, p.randaoSlashings...)
+	p.slashingsLock.Unlock()
+
+	return sp
+}
+
+// save writes the pool entries to disk.
+func (p *pool) save() error {
+	b, err := p.toSerializable().Marshal()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first to not lose the previous pool if the node is killed while saving.
+	tmp := p.poolFilePath() + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+
+	// Datadirs from previous versions store a cache dump directory on the same path.
+	if err := os.RemoveAll(p.poolFilePath()); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, p.poolFilePath())
+}
+
+// load reads the pool entries stored on disk and adds them again validating them against the tip state.
+// Entries that are no longer valid are dropped.
+func (p *pool) load() error {
+	fi, err := os.Stat(p.poolFilePath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if fi.IsDir() {
+		p.log.Info("removing mempool cache from a previous version")
+		return os.RemoveAll(p.poolFilePath())
+	}
+
+	b, err := ioutil.ReadFile(p.poolFilePath())
+	if err != nil {
+		return err
+	}
+
+	sp := new(SerializablePool)
+	if err := sp.Unmarshal(b); err != nil {
+		return err
+	}
+
+	if sp.Version != PoolFileVersion {
+		return fmt.Errorf("unsupported mempool file version %d", sp.Version)
+	}
+
+	var added, dropped int
+	check := func(err error) {
+		if err != nil {
+			p.log.Debugf("dropping mempool entry: %s", err)
+			dropped++
+			return
+		}
+		added++
+	}
+
+	for _, v := range sp.Votes {
+		s, err := p.chain.State().TipStateAtSlot(v.Data.Slot + p.netParams.MinAttestationInclusionDelay)
+		if err != nil {
+			check(err)
+			continue
+		}
+		check(p.AddVote(v, s))
+	}
+
+	for _, d := range sp.Deposits {
+		check(p.AddDeposit(d))
+	}
+
+	for _, e := range sp.Exits {
+		check(p.AddExit(e))
+	}
+
+	for _, pe := range sp.PartialExits {
+		check(p.AddPartialExit(pe))
+	}
+
+	for _, tx := range sp.Txs {
+		check(p.AddTx(tx))
+	}
+
+	for _, vs := range sp.VoteSlashings {
+		check(p.addVoteSlashing(vs))
+	}
+
+	tipState := p.chain.State().TipState()
+
+	for _, ps := range sp.ProposerSlashings {
+		if _, err := tipState.IsProposerSlashingValid(ps); err != nil {
+			check(err)
+			continue
+		}
+		check(p.AddProposerSlashing(ps))
+	}
+
+	for _, rs := range sp.RANDAOSlashings {
+		if _, err := tipState.IsRANDAOSlashingValid(rs); err != nil {
+			check(err)
+			continue
+		}
+		p.slashingsLock.Lock()
+		p.randaoSlashings = append(p.randaoSlashings, rs)
+		p.slashingsLock.Unlock()
+		check(nil)
+	}
+
+	p.log.Infof("loaded %d mempool entries, dropped %d no longer valid", added, dropped)
+
+	return nil
+}
+
+// persistHandler saves the pool to disk periodically until the node shuts down.
+func (p *pool) persistHandler() {
+	ticker := time.NewTicker(persistInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.save(); err != nil {
+				p.log.Errorf("unable to save mempool: %s", err)
+			}
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
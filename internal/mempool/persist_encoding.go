@@ -0,0 +1,557 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: 155b0e321ddc9108c5045eca5fdf43f96cfacf95e5a65d952cfb1e0318a7f0ad
+package mempool
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MarshalSSZ ssz marshals the SerializablePool object
+func (s *SerializablePool) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SerializablePool object to a target array
+func (s *SerializablePool) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(40)
+
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, s.Version)
+
+	// Offset (1) 'Votes'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(s.Votes); ii++ {
+		offset += 4
+		offset += s.Votes[ii].SizeSSZ()
+	}
+
+	// Offset (2) 'Deposits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Deposits) * 308
+
+	// Offset (3) 'Exits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Exits) * 192
+
+	// Offset (4) 'PartialExits'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.PartialExits) * 200
+
+	// Offset (5) 'Txs'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Txs) * 188
+
+	// Offset (6) 'VoteSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(s.VoteSlashings); ii++ {
+		offset += 4
+		offset += s.VoteSlashings[ii].SizeSSZ()
+	}
+
+	// Offset (7) 'ProposerSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.ProposerSlashings) * 1160
+
+	// Offset (8) 'RANDAOSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.RANDAOSlashings) * 152
+
+	// Field (1) 'Votes'
+	if len(s.Votes) > 65536 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(s.Votes)
+		for ii := 0; ii < len(s.Votes); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += s.Votes[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(s.Votes); ii++ {
+		if dst, err = s.Votes[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (2) 'Deposits'
+	if len(s.Deposits) > 65536 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.Deposits); ii++ {
+		if dst, err = s.Deposits[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (3) 'Exits'
+	if len(s.Exits) > 65536 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.Exits); ii++ {
+		if dst, err = s.Exits[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (4) 'PartialExits'
+	if len(s.PartialExits) > 65536 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.PartialExits); ii++ {
+		if dst, err = s.PartialExits[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (5) 'Txs'
+	if len(s.Txs) > 1048576 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.Txs); ii++ {
+		if dst, err = s.Txs[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (6) 'VoteSlashings'
+	if len(s.VoteSlashings) > 1024 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(s.VoteSlashings)
+		for ii := 0; ii < len(s.VoteSlashings); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += s.VoteSlashings[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(s.VoteSlashings); ii++ {
+		if dst, err = s.VoteSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (7) 'ProposerSlashings'
+	if len(s.ProposerSlashings) > 1024 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.ProposerSlashings); ii++ {
+		if dst, err = s.ProposerSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (8) 'RANDAOSlashings'
+	if len(s.RANDAOSlashings) > 1024 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(s.RANDAOSlashings); ii++ {
+		if dst, err = s.RANDAOSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SerializablePool object
+func (s *SerializablePool) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 40 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o2, o3, o4, o5, o6, o7, o8 uint64
+
+	// Field (0) 'Version'
+	s.Version = ssz.UnmarshallUint64(buf[0:8])
+
+	// Offset (1) 'Votes'
+	if o1 = ssz.ReadOffset(buf[8:12]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 40 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'Deposits'
+	if o2 = ssz.ReadOffset(buf[12:16]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (3) 'Exits'
+	if o3 = ssz.ReadOffset(buf[16:20]); o3 > size || o2 > o3 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (4) 'PartialExits'
+	if o4 = ssz.ReadOffset(buf[20:24]); o4 > size || o3 > o4 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (5) 'Txs'
+	if o5 = ssz.ReadOffset(buf[24:28]); o5 > size || o4 > o5 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (6) 'VoteSlashings'
+	if o6 = ssz.ReadOffset(buf[28:32]); o6 > size || o5 > o6 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (7) 'ProposerSlashings'
+	if o7 = ssz.ReadOffset(buf[32:36]); o7 > size || o6 > o7 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (8) 'RANDAOSlashings'
+	if o8 = ssz.ReadOffset(buf[36:40]); o8 > size || o7 > o8 {
+		return ssz.ErrOffset
+	}
+
+	// Field (1) 'Votes'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DecodeDynamicLength(buf, 65536)
+		if err != nil {
+			return err
+		}
+		s.Votes = make([]*primitives.MultiValidatorVote, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if s.Votes[indx] == nil {
+				s.Votes[indx] = new(primitives.MultiValidatorVote)
+			}
+			if err = s.Votes[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (2) 'Deposits'
+	{
+		buf = tail[o2:o3]
+		num, err := ssz.DivideInt2(len(buf), 308, 65536)
+		if err != nil {
+			return err
+		}
+		s.Deposits = make([]*primitives.Deposit, num)
+		for ii := 0; ii < num; ii++ {
+			if s.Deposits[ii] == nil {
+				s.Deposits[ii] = new(primitives.Deposit)
+			}
+			if err = s.Deposits[ii].UnmarshalSSZ(buf[ii*308 : (ii+1)*308]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (3) 'Exits'
+	{
+		buf = tail[o3:o4]
+		num, err := ssz.DivideInt2(len(buf), 192, 65536)
+		if err != nil {
+			return err
+		}
+		s.Exits = make([]*primitives.Exit, num)
+		for ii := 0; ii < num; ii++ {
+			if s.Exits[ii] == nil {
+				s.Exits[ii] = new(primitives.Exit)
+			}
+			if err = s.Exits[ii].UnmarshalSSZ(buf[ii*192 : (ii+1)*192]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (4) 'PartialExits'
+	{
+		buf = tail[o4:o5]
+		num, err := ssz.DivideInt2(len(buf), 200, 65536)
+		if err != nil {
+			return err
+		}
+		s.PartialExits = make([]*primitives.PartialExit, num)
+		for ii := 0; ii < num; ii++ {
+			if s.PartialExits[ii] == nil {
+				s.PartialExits[ii] = new(primitives.PartialExit)
+			}
+			if err = s.PartialExits[ii].UnmarshalSSZ(buf[ii*200 : (ii+1)*200]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (5) 'Txs'
+	{
+		buf = tail[o5:o6]
+		num, err := ssz.DivideInt2(len(buf), 188, 1048576)
+		if err != nil {
+			return err
+		}
+		s.Txs = make([]*primitives.Tx, num)
+		for ii := 0; ii < num; ii++ {
+			if s.Txs[ii] == nil {
+				s.Txs[ii] = new(primitives.Tx)
+			}
+			if err = s.Txs[ii].UnmarshalSSZ(buf[ii*188 : (ii+1)*188]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (6) 'VoteSlashings'
+	{
+		buf = tail[o6:o7]
+		num, err := ssz.DecodeDynamicLength(buf, 1024)
+		if err != nil {
+			return err
+		}
+		s.VoteSlashings = make([]*primitives.VoteSlashing, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if s.VoteSlashings[indx] == nil {
+				s.VoteSlashings[indx] = new(primitives.VoteSlashing)
+			}
+			if err = s.VoteSlashings[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (7) 'ProposerSlashings'
+	{
+		buf = tail[o7:o8]
+		num, err := ssz.DivideInt2(len(buf), 1160, 1024)
+		if err != nil {
+			return err
+		}
+		s.ProposerSlashings = make([]*primitives.ProposerSlashing, num)
+		for ii := 0; ii < num; ii++ {
+			if s.ProposerSlashings[ii] == nil {
+				s.ProposerSlashings[ii] = new(primitives.ProposerSlashing)
+			}
+			if err = s.ProposerSlashings[ii].UnmarshalSSZ(buf[ii*1160 : (ii+1)*1160]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (8) 'RANDAOSlashings'
+	{
+		buf = tail[o8:]
+		num, err := ssz.DivideInt2(len(buf), 152, 1024)
+		if err != nil {
+			return err
+		}
+		s.RANDAOSlashings = make([]*primitives.RANDAOSlashing, num)
+		for ii := 0; ii < num; ii++ {
+			if s.RANDAOSlashings[ii] == nil {
+				s.RANDAOSlashings[ii] = new(primitives.RANDAOSlashing)
+			}
+			if err = s.RANDAOSlashings[ii].UnmarshalSSZ(buf[ii*152 : (ii+1)*152]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SerializablePool object
+func (s *SerializablePool) SizeSSZ() (size int) {
+	size = 40
+
+	// Field (1) 'Votes'
+	for ii := 0; ii < len(s.Votes); ii++ {
+		size += 4
+		size += s.Votes[ii].SizeSSZ()
+	}
+
+	// Field (2) 'Deposits'
+	size += len(s.Deposits) * 308
+
+	// Field (3) 'Exits'
+	size += len(s.Exits) * 192
+
+	// Field (4) 'PartialExits'
+	size += len(s.PartialExits) * 200
+
+	// Field (5) 'Txs'
+	size += len(s.Txs) * 188
+
+	// Field (6) 'VoteSlashings'
+	for ii := 0; ii < len(s.VoteSlashings); ii++ {
+		size += 4
+		size += s.VoteSlashings[ii].SizeSSZ()
+	}
+
+	// Field (7) 'ProposerSlashings'
+	size += len(s.ProposerSlashings) * 1160
+
+	// Field (8) 'RANDAOSlashings'
+	size += len(s.RANDAOSlashings) * 152
+
+	return
+}
+
+// HashTreeRoot ssz hashes the SerializablePool object
+func (s *SerializablePool) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SerializablePool object with a hasher
+func (s *SerializablePool) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Version'
+	hh.PutUint64(s.Version)
+
+	// Field (1) 'Votes'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.Votes))
+		if num > 65536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.Votes[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 65536)
+	}
+
+	// Field (2) 'Deposits'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.Deposits))
+		if num > 65536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.Deposits[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 65536)
+	}
+
+	// Field (3) 'Exits'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.Exits))
+		if num > 65536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.Exits[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 65536)
+	}
+
+	// Field (4) 'PartialExits'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.PartialExits))
+		if num > 65536 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.PartialExits[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 65536)
+	}
+
+	// Field (5) 'Txs'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.Txs))
+		if num > 1048576 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.Txs[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 1048576)
+	}
+
+	// Field (6) 'VoteSlashings'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.VoteSlashings))
+		if num > 1024 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.VoteSlashings[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 1024)
+	}
+
+	// Field (7) 'ProposerSlashings'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.ProposerSlashings))
+		if num > 1024 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.ProposerSlashings[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 1024)
+	}
+
+	// Field (8) 'RANDAOSlashings'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(s.RANDAOSlashings))
+		if num > 1024 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = s.RANDAOSlashings[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 1024)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
@@ -0,0 +1,81 @@
+package mempool
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+func signedExit(ch *chaintest.Chain, pub [48]byte, key common.SecretKey) *primitives.Exit {
+	exit := &primitives.Exit{
+		ValidatorPubkey: pub,
+		WithdrawPubkey:  pub,
+	}
+	msg := exit.SigningMessage(config.GlobalParams.NetParams, ch.State().TipState().GetSlot())
+	copy(exit.Signature[:], key.Sign(msg[:]).Marshal())
+	return exit
+}
+
+func Test_PoolSaveLoad(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	ch.Extend(t, 5)
+
+	p := NewPool(ch, nil).(*pool)
+
+	tip := ch.State().Tip()
+	vote := ch.Vote(t, tip.Hash, tip.Slot)
+	s, err := ch.State().TipStateAtSlot(vote.Data.Slot + p.netParams.MinAttestationInclusionDelay)
+	assert.NoError(t, err)
+	assert.NoError(t, p.AddVote(vote, s))
+
+	for pub, key := range ch.Keys {
+		assert.NoError(t, p.AddExit(signedExit(ch, pub, key)))
+		break
+	}
+
+	saved := p.toSerializable()
+	assert.Len(t, saved.Votes, 1)
+	assert.Len(t, saved.Exits, 1)
+
+	assert.NoError(t, p.save())
+
+	loaded := NewPool(ch, nil).(*pool)
+	assert.NoError(t, loaded.load())
+	assert.Equal(t, saved, loaded.toSerializable())
+}
+
+func Test_PoolLoadDropsInvalid(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	ch.Extend(t, 5)
+
+	p := NewPool(ch, nil).(*pool)
+
+	var valid *primitives.Exit
+	for pub, key := range ch.Keys {
+		valid = signedExit(ch, pub, key)
+		break
+	}
+
+	// An exit of a validator that is not in the registry is no longer valid.
+	unknown, err := bls.RandKey()
+	assert.NoError(t, err)
+	var unknownPub [48]byte
+	copy(unknownPub[:], unknown.PublicKey().Marshal())
+
+	sp := &SerializablePool{
+		Version: PoolFileVersion,
+		Exits:   []*primitives.Exit{valid, signedExit(ch, unknownPub, unknown)},
+	}
+	b, err := sp.Marshal()
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(p.poolFilePath(), b, 0600))
+
+	assert.NoError(t, p.load())
+	assert.Equal(t, []*primitives.Exit{valid}, p.toSerializable().Exits)
+}
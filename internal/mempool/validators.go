@@ -0,0 +1,269 @@
+package mempool
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/olympus-protocol/ogen/internal/host"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/olympus-protocol/ogen/pkg/params"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// The topic validators run the checks that don't need a state before a message is relayed, except for the votes
+// that are checked against the vote committee. Malformed messages and invalid signatures are rejected, messages
+// that are valid but not useful anymore are ignored.
+
+func (p *pool) currentSlot() uint64 {
+	slot := time.Now().Sub(p.chain.GenesisTime()) / (time.Duration(p.netParams.SlotDuration) * time.Second)
+	if slot < 0 {
+		return 0
+	}
+	return uint64(slot)
+}
+
+func (p *pool) validateVote(_ peer.ID, msg p2p.Message) host.ValidationResult {
+	data, ok := msg.(*p2p.MsgVote)
+	if !ok || data.Data == nil || data.Data.Data == nil {
+		return host.ValidationReject
+	}
+
+	vote := data.Data
+
+	if len(vote.ParticipationBitfield.BitIndices()) == 0 {
+		return host.ValidationReject
+	}
+
+	if vote.Data.Slot > p.currentSlot()+1 {
+		return host.ValidationIgnore
+	}
+
+	tip := p.chain.State().Tip()
+	if vote.Data.Slot+p.netParams.MinAttestationInclusionDelay+p.netParams.EpochLength*2 < tip.Slot {
+		return host.ValidationIgnore
+	}
+
+	// The aggregate signature is verified against the committee participating on the vote, the same way the
+	// votes are checked when added to the pool.
+	view, err := p.chain.State().GetSubView(tip.Hash)
+	if err != nil {
+		return host.ValidationIgnore
+	}
+
+	s, _, err := p.chain.State().GetStateForHashAtSlot(tip.Hash, vote.Data.Slot+p.netParams.MinAttestationInclusionDelay, &view)
+	if err != nil {
+		return host.ValidationIgnore
+	}
+
+	if err := s.IsVoteValid(vote); err != nil {
+		if err == state.ErrorVoteSignature {
+			return host.ValidationReject
+		}
+		return host.ValidationIgnore
+	}
+
+	return host.ValidationAccept
+}
+
+func (p *pool) validateTx(_ peer.ID, msg p2p.Message) host.ValidationResult {
+	data, ok := msg.(*p2p.MsgTx)
+	if !ok || data.Data == nil {
+		return host.ValidationReject
+	}
+
+	if err := data.Data.VerifySig(p.netParams, p.currentSlot()); err != nil {
+		return host.ValidationReject
+	}
+
+	return host.ValidationAccept
+}
+
+func (p *pool) validateDeposits(_ peer.ID, msg p2p.Message) host.ValidationResult {
+	data, ok := msg.(*p2p.MsgDeposits)
+	if !ok || len(data.Data) == 0 {
+		return host.ValidationReject
+	}
+
+	slot := p.currentSlot()
+	for _, d := range data.Data {
+		if d.Data == nil {
+			return host.ValidationReject
+		}
+
+		if err := d.VerifySig(p.netParams, slot); err != nil {
+			return host.ValidationReject
+		}
+	}
+
+	return host.ValidationAccept
+}
+
+func (p *pool) validateExits(_ peer.ID, msg p2p.Message) host.ValidationResult {
+	data, ok := msg.(*p2p.MsgExits)
+	if !ok || len(data.Data) == 0 {
+		return host.ValidationReject
+	}
+
+	slot := p.currentSlot()
+	for _, e := range data.Data {
+		if err := e.VerifySig(p.netParams, slot); err != nil {
+			return host.ValidationReject
+		}
+	}
+
+	return host.ValidationAccept
+}
+
+func (p *pool) validatePartialExits(_ peer.ID, msg p2p.Message) host.ValidationResult {
+	data, ok := msg.(*p2p.MsgPartialExits)
+	if !ok || len(data.Data) == 0 {
+		return host.ValidationReject
+	}
+
+	slot := p.currentSlot()
+	for _, e := range data.Data {
+		if err := e.VerifySig(p.netParams, slot); err != nil {
+			return host.ValidationReject
+		}
+	}
+
+	return host.ValidationAccept
+}
+
+func (p *pool) validateSlashings(_ peer.ID, msg p2p.Message) host.ValidationResult {
+	data, ok := msg.(*p2p.MsgSlashings)
+	if !ok || len(data.ProposerSlashings)+len(data.VoteSlashings)+len(data.RANDAOSlashings) == 0 {
+		return host.ValidationReject
+	}
+
+	for _, s := range data.ProposerSlashings {
+		if !validProposerSlashing(p.netParams, s) {
+			return host.ValidationReject
+		}
+	}
+
+	for _, s := range data.VoteSlashings {
+		if s.Vote1 == nil || s.Vote2 == nil || s.Vote1.Data == nil || s.Vote2.Data == nil {
+			return host.ValidationReject
+		}
+		if s.Vote1.Data.Equals(s.Vote2.Data) {
+			return host.ValidationReject
+		}
+		if !s.Vote1.Data.IsDoubleVote(s.Vote2.Data) && !s.Vote1.Data.IsSurroundVote(s.Vote2.Data) {
+			return host.ValidationReject
+		}
+		for _, vote := range []*primitives.MultiValidatorVote{s.Vote1, s.Vote2} {
+			if result := p.validateVoteSignature(vote); result != host.ValidationAccept {
+				return result
+			}
+		}
+	}
+
+	for _, s := range data.RANDAOSlashings {
+		if !validRANDAOSlashing(p.netParams, s) {
+			return host.ValidationReject
+		}
+	}
+
+	return host.ValidationAccept
+}
+
+// validateVoteSignature checks the aggregate signature of a vote against the keys of its participants. Votes whose
+// committee is not known on top of the tip can't be checked and are ignored.
+func (p *pool) validateVoteSignature(vote *primitives.MultiValidatorVote) host.ValidationResult {
+	tip := p.chain.State().Tip()
+
+	view, err := p.chain.State().GetSubView(tip.Hash)
+	if err != nil {
+		return host.ValidationIgnore
+	}
+
+	s, _, err := p.chain.State().GetStateForHashAtSlot(tip.Hash, vote.Data.Slot+p.netParams.MinAttestationInclusionDelay, &view)
+	if err != nil {
+		return host.ValidationIgnore
+	}
+
+	committee, err := s.GetVoteCommittee(vote.Data.Slot)
+	if err != nil {
+		return host.ValidationIgnore
+	}
+
+	registry := s.GetValidatorRegistry()
+
+	var pubs []common.PublicKey
+	for i, index := range committee {
+		if !vote.ParticipationBitfield.Get(uint(i)) {
+			continue
+		}
+		pub, err := bls.PublicKeyFromBytes(registry[index].PubKey[:])
+		if err != nil {
+			return host.ValidationReject
+		}
+		pubs = append(pubs, pub)
+	}
+	if len(pubs) == 0 {
+		return host.ValidationReject
+	}
+
+	sig, err := vote.Signature()
+	if err != nil {
+		return host.ValidationReject
+	}
+
+	if !sig.FastAggregateVerify(pubs, vote.Data.SigningMessage(p.netParams)) {
+		return host.ValidationReject
+	}
+
+	return host.ValidationAccept
+}
+
+// validProposerSlashing checks the slashing contains two different headers for the same slot signed by the same key.
+func validProposerSlashing(netParams *params.ChainParams, s *primitives.ProposerSlashing) bool {
+	if s.BlockHeader1 == nil || s.BlockHeader2 == nil {
+		return false
+	}
+
+	h1 := s.BlockHeader1.Hash()
+	h2 := s.BlockHeader2.Hash()
+	if h1 == h2 || s.BlockHeader1.Slot != s.BlockHeader2.Slot {
+		return false
+	}
+
+	pub, err := s.GetValidatorPubkey()
+	if err != nil {
+		return false
+	}
+
+	s1, err := s.GetSignature1()
+	if err != nil {
+		return false
+	}
+
+	s2, err := s.GetSignature2()
+	if err != nil {
+		return false
+	}
+
+	m1 := s.BlockHeader1.SigningMessage(netParams)
+	m2 := s.BlockHeader2.SigningMessage(netParams)
+	return s1.Verify(pub, m1[:]) && s2.Verify(pub, m2[:])
+}
+
+// validRANDAOSlashing checks the slashing contains a RANDAO reveal for the slot signed by the key.
+func validRANDAOSlashing(netParams *params.ChainParams, s *primitives.RANDAOSlashing) bool {
+	pub, err := s.GetValidatorPubkey()
+	if err != nil {
+		return false
+	}
+
+	sig, err := s.GetRandaoReveal()
+	if err != nil {
+		return false
+	}
+
+	msg := primitives.RANDAOMessage(netParams, s.Slot)
+	return sig.Verify(pub, msg[:])
+}
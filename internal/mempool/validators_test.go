@@ -0,0 +1,101 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/internal/host"
+	"github.com/olympus-protocol/ogen/pkg/bitfield"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateVote(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	ch.Extend(t, 5)
+
+	p := NewPool(ch, nil).(*pool)
+
+	tip := ch.State().Tip()
+	vote := ch.Vote(t, tip.Hash, tip.Slot)
+	assert.Equal(t, host.ValidationAccept, p.validateVote("peer", &p2p.MsgVote{Data: vote}))
+
+	// A signature of a key outside of the participation set is rejected.
+	key, err := bls.RandKey()
+	assert.NoError(t, err)
+	msg := vote.Data.SigningMessage(p.netParams)
+	forged := ch.Vote(t, tip.Hash, tip.Slot)
+	copy(forged.Sig[:], key.Sign(msg[:]).Marshal())
+	assert.Equal(t, host.ValidationReject, p.validateVote("peer", &p2p.MsgVote{Data: forged}))
+}
+
+// signVote returns the vote data signed by the whole committee of its slot.
+func signVote(t *testing.T, ch *chaintest.Chain, data *primitives.VoteData) *primitives.MultiValidatorVote {
+	netParams := config.GlobalParams.NetParams
+
+	s, err := ch.State().TipStateAtSlot(data.Slot + netParams.MinAttestationInclusionDelay)
+	assert.NoError(t, err)
+
+	committee, err := s.GetVoteCommittee(data.Slot)
+	assert.NoError(t, err)
+
+	msg := data.SigningMessage(netParams)
+	registry := s.GetValidatorRegistry()
+
+	participation := bitfield.NewBitlist(uint64(len(committee)))
+	signatures := make([]common.Signature, len(committee))
+	for i, index := range committee {
+		signatures[i] = ch.Keys[registry[index].PubKey].Sign(msg[:])
+		participation.Set(uint(i))
+	}
+
+	vote := &primitives.MultiValidatorVote{Data: data, ParticipationBitfield: participation}
+	copy(vote.Sig[:], bls.AggregateSignatures(signatures).Marshal())
+	return vote
+}
+
+func Test_ValidateSlashings(t *testing.T) {
+	ch := chaintest.NewChain(t)
+	ch.Extend(t, 5)
+
+	p := NewPool(ch, nil).(*pool)
+
+	tip := ch.State().Tip()
+	vote := ch.Vote(t, tip.Hash, tip.Slot)
+
+	double := *vote.Data
+	double.BeaconBlockHash = chainhash.HashH([]byte("other block"))
+
+	slashing := &primitives.VoteSlashing{Vote1: vote, Vote2: signVote(t, ch, &double)}
+	assert.Equal(t, host.ValidationAccept, p.validateSlashings("peer", &p2p.MsgSlashings{VoteSlashings: []*primitives.VoteSlashing{slashing}}))
+
+	// Votes not signed by their participants are rejected.
+	key, err := bls.RandKey()
+	assert.NoError(t, err)
+	msg := double.SigningMessage(p.netParams)
+	forged := signVote(t, ch, &double)
+	copy(forged.Sig[:], key.Sign(msg[:]).Marshal())
+	slashing = &primitives.VoteSlashing{Vote1: vote, Vote2: forged}
+	assert.Equal(t, host.ValidationReject, p.validateSlashings("peer", &p2p.MsgSlashings{VoteSlashings: []*primitives.VoteSlashing{slashing}}))
+
+	// Votes that don't break the slashing rules are rejected.
+	other := *vote.Data
+	other.ToEpoch++
+	slashing = &primitives.VoteSlashing{Vote1: vote, Vote2: signVote(t, ch, &other)}
+	assert.Equal(t, host.ValidationReject, p.validateSlashings("peer", &p2p.MsgSlashings{VoteSlashings: []*primitives.VoteSlashing{slashing}}))
+
+	randao := primitives.RANDAOMessage(p.netParams, 10)
+	rs := &primitives.RANDAOSlashing{Slot: 10}
+	copy(rs.ValidatorPubkey[:], key.PublicKey().Marshal())
+	copy(rs.RandaoReveal[:], key.Sign(randao[:]).Marshal())
+	assert.Equal(t, host.ValidationAccept, p.validateSlashings("peer", &p2p.MsgSlashings{RANDAOSlashings: []*primitives.RANDAOSlashing{rs}}))
+
+	// A reveal for another slot is rejected.
+	rs.Slot = 11
+	assert.Equal(t, host.ValidationReject, p.validateSlashings("peer", &p2p.MsgSlashings{RANDAOSlashings: []*primitives.RANDAOSlashing{rs}}))
+}
@@ -0,0 +1,77 @@
+package proposer
+
+import (
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/duties"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// detectDoppelgangers watches the network votes and the votes included on blocks during the configured epochs.
+// Our validators voting during that time are running on another node and their duties are disabled, the duties
+// of the rest are started. It returns false when the node stops during the check.
+func (p *proposer) detectDoppelgangers() bool {
+	epochs := config.GlobalFlags.DoppelgangerEpochs
+	if epochs == 0 {
+		return true
+	}
+
+	clock := p.clock()
+	startSlot := clock.CurrentSlot()
+
+	ours := make(map[uint64][48]byte)
+	for i, v := range p.chain.State().TipState().GetValidatorRegistry() {
+		if p.signer.HasKey(v.PubKey) {
+			ours[uint64(i)] = v.PubKey
+		}
+	}
+	d := duties.NewDoppelganger(startSlot, ours)
+
+	endSlot := startSlot + epochs*p.netParams.EpochLength
+
+	p.log.Infof("looking for doppelgangers of %d validators until slot %d before starting the duties", len(ours), endSlot)
+
+	p.doppelgangerLock.Lock()
+	p.watching = d
+	p.doppelgangerLock.Unlock()
+	p.pool.NotifyVotes(d)
+
+	select {
+	case <-time.After(time.Until(clock.BlockTime(endSlot + 1))):
+	case <-p.context.Done():
+	}
+
+	p.pool.UnnotifyVotes(d)
+	p.doppelgangerLock.Lock()
+	p.watching = nil
+	p.doppelgangerLock.Unlock()
+
+	if p.context.Err() != nil {
+		return false
+	}
+
+	detected := d.Detected()
+	p.duties.Disable(detected)
+
+	switch {
+	case len(detected) == 0:
+	case len(detected) == len(ours):
+		p.log.Error("all the validators are running on another node, only the duties of keys added later are performed")
+	default:
+		p.log.Warnf("%d of %d validators are running on another node, starting the duties of the rest", len(detected), len(ours))
+	}
+	return true
+}
+
+// checkDoppelgangerBlock checks the votes of a new block while looking for doppelgangers.
+func (p *proposer) checkDoppelgangerBlock(block *primitives.Block, s state.State) {
+	p.doppelgangerLock.Lock()
+	d := p.watching
+	p.doppelgangerLock.Unlock()
+
+	if d != nil {
+		d.CheckBlock(block, s)
+	}
+}
@@ -0,0 +1,93 @@
+package proposer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/internal/duties"
+	"github.com/olympus-protocol/ogen/internal/mempool"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSigner has the keys of some validators.
+type testSigner struct {
+	signer.Signer
+	keys map[[48]byte]struct{}
+}
+
+func (s *testSigner) HasKey(pub [48]byte) bool {
+	_, ok := s.keys[pub]
+	return ok
+}
+
+// votesPool delivers votes to the vote notifees.
+type votesPool struct {
+	mempool.Pool
+	votes func(n mempool.VoteNotifee)
+}
+
+func (p *votesPool) NotifyVotes(n mempool.VoteNotifee) {
+	p.votes(n)
+}
+
+func (p *votesPool) UnnotifyVotes(mempool.VoteNotifee) {}
+
+func Test_DetectDoppelgangers(t *testing.T) {
+	tests := []struct {
+		name     string
+		detected []uint64
+	}{
+		{name: "clean"},
+		{name: "partial", detected: []uint64{0, 2}},
+		{name: "all", detected: []uint64{0, 1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := chaintest.NewChain(t)
+			config.GlobalFlags.DoppelgangerEpochs = 1
+
+			// Check during two short slots.
+			netParams := *config.GlobalParams.NetParams
+			netParams.SlotDuration = 1
+			netParams.EpochLength = 1
+
+			registry := c.State().TipState().GetValidatorRegistry()
+
+			ours := make(map[[48]byte]struct{})
+			for _, v := range registry[:4] {
+				ours[v.PubKey] = struct{}{}
+			}
+
+			s := &testSigner{keys: ours}
+			p := &proposer{
+				log:       config.GlobalParams.Logger,
+				netParams: &netParams,
+				chain:     c,
+				signer:    s,
+				duties:    duties.New(config.GlobalParams.Logger, s, nil),
+				context:   context.Background(),
+			}
+			p.pool = &votesPool{votes: func(n mempool.VoteNotifee) {
+				vote := &primitives.MultiValidatorVote{Data: &primitives.VoteData{Slot: p.GetCurrentSlot() + 1}}
+				n.NewVote(vote, test.detected)
+			}}
+
+			assert.True(t, p.detectDoppelgangers())
+
+			detected := make(map[uint64]struct{})
+			for _, index := range test.detected {
+				detected[index] = struct{}{}
+			}
+			for i, v := range registry[:5] {
+				_, isDetected := detected[uint64(i)]
+				_, isOurs := ours[v.PubKey]
+				assert.Equal(t, isOurs && !isDetected, p.duties.HasKey(v.PubKey))
+			}
+		})
+	}
+}
@@ -4,10 +4,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/duties"
 	"github.com/olympus-protocol/ogen/internal/state"
-	"github.com/olympus-protocol/ogen/pkg/bitfield"
-	"github.com/olympus-protocol/ogen/pkg/bls"
-	"github.com/olympus-protocol/ogen/pkg/bls/common"
 	"github.com/olympus-protocol/ogen/pkg/p2p"
 	"sync"
 	"time"
@@ -17,7 +15,8 @@ import (
 	"github.com/olympus-protocol/ogen/internal/host"
 	"github.com/olympus-protocol/ogen/internal/keystore"
 	"github.com/olympus-protocol/ogen/internal/mempool"
-	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
 	"github.com/olympus-protocol/ogen/pkg/logger"
 	"github.com/olympus-protocol/ogen/pkg/params"
 	"github.com/olympus-protocol/ogen/pkg/primitives"
@@ -45,6 +44,14 @@ type proposer struct {
 	netParams *params.ChainParams
 	chain     chain.Blockchain
 	keystore  keystore.Keystore
+	signer    signer.Signer
+
+	protection *slashingprotection.DB
+	duties     *duties.Duties
+
+	// watching is the doppelganger check running before starting the duties.
+	watching         *duties.Doppelganger
+	doppelgangerLock sync.Mutex
 
 	context context.Context
 	stop    context.CancelFunc
@@ -59,34 +66,31 @@ type proposer struct {
 	host host.Host
 }
 
-// NewProposer creates a new proposer from the parameters.
-func NewProposer(chain chain.Blockchain, h host.Host, pool mempool.Pool, ks keystore.Keystore) (Proposer, error) {
+// NewProposer creates a new proposer from the parameters. The keystore must be open.
+func NewProposer(chain chain.Blockchain, h host.Host, pool mempool.Pool, ks keystore.Keystore, s signer.Signer) (Proposer, error) {
+	protection, err := slashingprotection.Open(config.GlobalFlags.DataPath)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	prop := &proposer{
-		log:       config.GlobalParams.Logger,
-		netParams: config.GlobalParams.NetParams,
-		keystore:  ks,
-		chain:     chain,
-		context:   ctx,
-		stop:      cancel,
-		pool:      pool,
-		host:      h,
-		voting:    false,
-		proposing: false,
+		log:        config.GlobalParams.Logger,
+		netParams:  config.GlobalParams.NetParams,
+		keystore:   ks,
+		signer:     s,
+		protection: protection,
+		duties:     duties.New(config.GlobalParams.Logger, s, protection),
+		chain:      chain,
+		context:    ctx,
+		stop:       cancel,
+		pool:       pool,
+		host:       h,
+		voting:     false,
+		proposing:  false,
 	}
 
-	err := prop.keystore.OpenKeystore()
-	if err != nil {
-		if err == keystore.ErrorNotInitialized {
-			err = prop.keystore.CreateKeystore()
-			if err != nil {
-				return nil, err
-			}
-			return prop, nil
-		}
-		return nil, err
-	}
 	return prop, nil
 }
 
@@ -101,34 +105,28 @@ func (p *proposer) Proposing() bool {
 // NewTip implements the BlockchainNotifee interface.
 func (p *proposer) NewTip(_ *chainindex.BlockRow, block *primitives.Block, newState state.State, _ []*primitives.EpochReceipt) {
 	p.pool.RemoveByBlock(block, newState)
+	p.checkDoppelgangerBlock(block, newState)
 }
 
 func (p *proposer) GetCurrentSlot() uint64 {
-	return p.getCurrentSlot()
-}
-
-func (p *proposer) getCurrentSlot() uint64 {
-	slot := time.Now().Sub(p.chain.GenesisTime()) / (time.Duration(p.netParams.SlotDuration) * time.Second)
-	if slot < 0 {
-		return 0
-	}
-	return uint64(slot)
-}
-
-// getNextSlotTime gets the next slot time.
-func (p *proposer) getNextBlockTime(nextSlot uint64) time.Time {
-	return p.chain.GenesisTime().Add(time.Duration(nextSlot*p.netParams.SlotDuration) * time.Second)
+	return p.clock().CurrentSlot()
 }
 
-// getNextSlotTime gets the next slot time.
-func (p *proposer) getNextVoteTime(nextSlot uint64) time.Time {
-	return p.chain.GenesisTime().Add(time.Duration(nextSlot*p.netParams.SlotDuration) * time.Second).Add(-time.Second * time.Duration(p.netParams.SlotDuration) / 2)
+// clock returns the slot times of the chain.
+func (p *proposer) clock() duties.Clock {
+	return duties.NewClock(p.chain.GenesisTime(), p.netParams)
 }
 
 // ProposerSlashingConditionViolated implements chain notifee.
 func (p *proposer) ProposerSlashingConditionViolated(d *primitives.ProposerSlashing) {
 	p.log.Warn("WARNING: Proposer slashing condition detected.")
 	err := p.pool.AddProposerSlashing(d)
+	if err != nil {
+		p.log.Error(err)
+		return
+	}
+
+	err = p.host.Broadcast(&p2p.MsgSlashings{ProposerSlashings: []*primitives.ProposerSlashing{d}})
 	if err != nil {
 		p.log.Error(err)
 	}
@@ -139,132 +137,14 @@ func (p *proposer) ProposeBlocks() {
 		p.proposing = false
 	}()
 
-	slotToPropose := p.getCurrentSlot() + 1
-
-	blockTimer := time.NewTimer(time.Until(p.getNextBlockTime(slotToPropose)))
-
-	for {
-		select {
-		case <-blockTimer.C:
-			p.proposerLock.Lock()
-			// Check if we're an attester for this slot
-			if !p.host.Synced() {
-				p.proposing = false
-				blockTimer = time.NewTimer(time.Second * 10)
-				p.log.Info("blockchain not synced... trying to propose in 10 seconds")
-				p.proposerLock.Unlock()
-				slotToPropose++
-				continue
-			}
-			p.proposing = true
-
-			tip := p.chain.State().Tip()
-			tipHash := tip.Hash
-
-			blockState, err := p.chain.State().TipStateAtSlot(slotToPropose)
-			if err != nil {
-				p.log.Errorf("unable to get tip state at slot %d", slotToPropose)
-				blockTimer = time.NewTimer(time.Second * 2)
-				p.proposerLock.Unlock()
-				slotToPropose++
-				continue
-			}
-
-			slotIndex := (slotToPropose + p.netParams.EpochLength - 1) % p.netParams.EpochLength
-			proposerIndex := blockState.GetProposerQueue()[slotIndex]
-			proposerValidator := blockState.GetValidatorRegistry()[proposerIndex]
-
-			if k, found := p.keystore.GetValidatorKey(proposerValidator.PubKey); found {
-
-				p.log.Infof("proposing for slot %d", slotToPropose)
-
-				votes := p.pool.GetVotes(slotToPropose, blockState, proposerIndex)
-
-				deposits, blockState := p.pool.GetDeposits(blockState)
-
-				exits, blockState := p.pool.GetExits(blockState)
-
-				partialExits, blockState := p.pool.GetPartialExits(blockState)
-
-				txs, blockState := p.pool.GetTxs(blockState, proposerValidator.PayeeAddress)
-
-				voteSlashings, blockState := p.pool.GetVoteSlashings(blockState)
-
-				proposerSlashings, blockState := p.pool.GetProposerSlashings(blockState)
-
-				randaoSlashings, blockState := p.pool.GetRANDAOSlashings(blockState)
-
-				block := primitives.Block{
-					Header: &primitives.BlockHeader{
-						Version:       0,
-						PrevBlockHash: tipHash,
-						Timestamp:     uint64(time.Now().Unix()),
-						Slot:          slotToPropose,
-						FeeAddress:    proposerValidator.PayeeAddress,
-					},
-					Votes:             votes,
-					Deposits:          deposits,
-					Exits:             exits,
-					PartialExit:       partialExits,
-					Txs:               txs,
-					VoteSlashings:     voteSlashings,
-					ProposerSlashings: proposerSlashings,
-					RANDAOSlashings:   randaoSlashings,
-				}
-
-				block.Header.VoteMerkleRoot = block.VotesMerkleRoot()
-				block.Header.DepositMerkleRoot = block.DepositMerkleRoot()
-				block.Header.ExitMerkleRoot = block.ExitMerkleRoot()
-				block.Header.PartialExitMerkleRoot = block.PartialExitsMerkleRoot()
-				block.Header.TxsMerkleRoot = block.TxsMerkleRoot()
-				block.Header.VoteSlashingMerkleRoot = block.VoteSlashingRoot()
-				block.Header.ProposerSlashingMerkleRoot = block.ProposerSlashingsRoot()
-				block.Header.RANDAOSlashingMerkleRoot = block.RANDAOSlashingsRoot()
-
-				blockHash := block.Hash()
-				randaoHash := chainhash.HashH([]byte(fmt.Sprintf("%d", slotToPropose)))
-
-				if !k.Enable {
-					blockTimer = time.NewTimer(time.Second * 2)
-					p.proposerLock.Unlock()
-					slotToPropose++
-					continue
-				}
-
-				blockSig := k.Secret.Sign(blockHash[:])
-				randaoSig := k.Secret.Sign(randaoHash[:])
-				var s, rs [96]byte
-				copy(s[:], blockSig.Marshal())
-				copy(rs[:], randaoSig.Marshal())
-				block.Signature = s
-				block.RandaoSignature = rs
-				if err := p.chain.ProcessBlock(&block); err != nil {
-					slotToPropose++
-					p.log.Error(err)
-					blockTimer = time.NewTimer(time.Second * 2)
-					p.proposerLock.Unlock()
-					continue
-				}
-
-				msg := &p2p.MsgBlock{Data: &block}
-
-				err = p.host.Broadcast(msg)
-				if err != nil {
-					p.log.Error(err)
-					blockTimer = time.NewTimer(time.Second * 2)
-					p.proposerLock.Unlock()
-					continue
-				}
-			}
-
-			slotToPropose++
-			p.proposerLock.Unlock()
-			blockTimer = time.NewTimer(time.Until(p.getNextBlockTime(slotToPropose)))
-		case <-p.context.Done():
-			p.log.Info("stopping proposer")
-			return
+	clock := p.clock()
+	duties.Run(p.context, clock, clock.BlockTime, func(slot uint64) {
+		if err := p.propose(slot); err != nil {
+			p.log.Errorf("unable to propose for slot %d: %s", slot, err)
 		}
-	}
+	})
+
+	p.log.Info("stopping proposer")
 }
 
 func (p *proposer) VoteForBlocks() {
@@ -272,133 +152,99 @@ func (p *proposer) VoteForBlocks() {
 		p.voting = false
 	}()
 
-	slotToVote := p.getCurrentSlot() + 1
-	if slotToVote <= 0 {
-		slotToVote = 1
+	clock := p.clock()
+	duties.Run(p.context, clock, clock.VoteTime, func(slot uint64) {
+		if err := p.vote(slot); err != nil {
+			p.log.Errorf("unable to vote for slot %d: %s", slot, err)
+		}
+	})
+
+	p.log.Info("stopping voter")
+}
+
+func (p *proposer) propose(slot uint64) error {
+	p.proposerLock.Lock()
+	defer p.proposerLock.Unlock()
+
+	if !p.host.Synced() {
+		p.proposing = false
+		p.log.Infof("blockchain not synced... skipping the proposal for slot %d", slot)
+		return nil
 	}
+	p.proposing = true
 
-	voteTimer := time.NewTimer(time.Until(p.getNextVoteTime(slotToVote)))
-
-	for {
-		select {
-		case <-voteTimer.C:
-			p.voteLock.Lock()
-			// Check if we're an attester for this slot
-			if !p.host.Synced() {
-				p.voting = false
-				voteTimer = time.NewTimer(time.Second * 10)
-				p.log.Info("blockchain not synced... trying to vote in 10 seconds")
-				p.voteLock.Unlock()
-				slotToVote++
-				continue
-			}
-
-			p.voting = true
-			s := p.chain.State()
-
-			voteState, err := s.TipStateAtSlot(slotToVote)
-			if err != nil {
-				p.log.Errorf("unable to get tip at slot %d", slotToVote)
-				voteTimer = time.NewTimer(time.Second * 2)
-				p.voteLock.Unlock()
-				slotToVote++
-				continue
-			}
-
-			validators, err := voteState.GetVoteCommittee(slotToVote)
-			if err != nil {
-				p.log.Errorf("error getting vote committee: %s", err.Error())
-				voteTimer = time.NewTimer(time.Second * 2)
-				p.voteLock.Unlock()
-				slotToVote++
-				continue
-			}
-
-			p.log.Debugf("committing for slot %d with %d validators", slotToVote, len(validators))
-
-			beaconBlock, found := s.Chain().GetNodeBySlot(slotToVote - 1)
-			if !found {
-				p.log.Errorf("unable to find block at slot %d", slotToVote-1)
-				voteTimer = time.NewTimer(time.Second * 2)
-				p.voteLock.Unlock()
-				slotToVote++
-				continue
-			}
-
-			toEpoch := (slotToVote - 1) / p.netParams.EpochLength
-
-			data := &primitives.VoteData{
-				Slot:            slotToVote,
-				FromEpoch:       voteState.GetJustifiedEpoch(),
-				FromHash:        voteState.GetJustifiedEpochHash(),
-				ToEpoch:         toEpoch,
-				ToHash:          voteState.GetRecentBlockHash(toEpoch*p.netParams.EpochLength - 1),
-				BeaconBlockHash: beaconBlock.Hash,
-			}
-
-			dataHash := data.Hash()
-
-			var signatures []common.Signature
-
-			bitlistVotes := bitfield.NewBitlist(uint64(len(validators)))
-
-			validatorRegistry := voteState.GetValidatorRegistry()
-
-			validatorsActionMap := make(map[common.PublicKey]common.SecretKey)
-
-			for i, index := range validators {
-				votingValidator := validatorRegistry[index]
-				key, ok := p.keystore.GetValidatorKey(votingValidator.PubKey)
-				if ok {
-					if key.Enable {
-						signatures = append(signatures, key.Secret.Sign(dataHash[:]))
-						bitlistVotes.Set(uint(i))
-						validatorsActionMap[key.Secret.PublicKey()] = key.Secret
-					}
-				}
-			}
-
-			if len(signatures) > 0 {
-				sig := bls.AggregateSignatures(signatures)
-
-				var voteSig [96]byte
-				copy(voteSig[:], sig.Marshal())
-
-				vote := &primitives.MultiValidatorVote{
-					Data:                  data,
-					ParticipationBitfield: bitlistVotes,
-					Sig:                   voteSig,
-				}
-
-				err = p.pool.AddVote(vote, voteState)
-				if err != nil {
-					slotToVote++
-					p.log.Error(err)
-					voteTimer = time.NewTimer(time.Second * 2)
-					p.voteLock.Unlock()
-					continue
-				}
-
-				p.log.Infof("sending votes for slot %d for %d validators", slotToVote, len(signatures))
-
-				msg := &p2p.MsgVote{Data: vote}
-				err = p.host.Broadcast(msg)
-				if err != nil {
-					p.log.Error(err)
-					voteTimer = time.NewTimer(time.Second * 2)
-					p.voteLock.Unlock()
-					continue
-				}
-			}
-
-			slotToVote++
-			p.voteLock.Unlock()
-			voteTimer = time.NewTimer(time.Until(p.getNextVoteTime(slotToVote)))
-		case <-p.context.Done():
-			p.log.Info("stopping voter")
-			return
-		}
+	tip := p.chain.State().Tip()
+
+	blockState, err := p.chain.State().TipStateAtSlot(slot)
+	if err != nil {
+		return fmt.Errorf("unable to get tip state at slot %d: %s", slot, err)
+	}
+
+	proposerIndex := slotProposer(blockState, slot)
+	proposerValidator := blockState.GetValidatorRegistry()[proposerIndex]
+
+	if !p.duties.HasKey(proposerValidator.PubKey) {
+		return nil
+	}
+
+	p.log.Infof("proposing for slot %d", slot)
+
+	// The mempool applies the items to the state while selecting them, the tip state is shared with the chain.
+	block := newBlock(p.pool, blockState.Copy(), tip.Hash, slot, proposerIndex)
+
+	signed, err := p.duties.SignBlock(proposerValidator.PubKey, block)
+	if err != nil || !signed {
+		return err
+	}
+
+	if err := p.chain.ProcessBlock(block); err != nil {
+		return err
 	}
+
+	return p.host.Broadcast(&p2p.MsgBlock{Data: block})
+}
+
+func (p *proposer) vote(slot uint64) error {
+	p.voteLock.Lock()
+	defer p.voteLock.Unlock()
+
+	if !p.host.Synced() {
+		p.voting = false
+		p.log.Infof("blockchain not synced... skipping the votes for slot %d", slot)
+		return nil
+	}
+	p.voting = true
+
+	data, voteState, err := VoteData(p.chain, slot)
+	if err != nil {
+		return err
+	}
+
+	validators, err := voteState.GetVoteCommittee(slot)
+	if err != nil {
+		return fmt.Errorf("error getting vote committee: %s", err)
+	}
+
+	p.log.Debugf("committing for slot %d with %d validators", slot, len(validators))
+
+	registry := voteState.GetValidatorRegistry()
+	committee := make([][48]byte, len(validators))
+	for i, index := range validators {
+		committee[i] = registry[index].PubKey
+	}
+
+	vote := p.duties.SignVote(data, committee)
+	if vote == nil {
+		return nil
+	}
+
+	if err := p.pool.AddVote(vote, voteState); err != nil {
+		return err
+	}
+
+	p.log.Infof("sending votes for slot %d for %d validators", slot, len(vote.ParticipationBitfield.BitIndices()))
+
+	return p.host.Broadcast(&p2p.MsgVote{Data: vote})
 }
 
 // Start runs the proposer.
@@ -411,6 +257,7 @@ func (p *proposer) Start() error {
 func (p *proposer) Stop() {
 	p.chain.Unnotify(p)
 	p.stop()
+	_ = p.protection.Close()
 }
 
 func (p *proposer) Keystore() keystore.Keystore {
@@ -425,8 +272,7 @@ check:
 	numOurs := 0
 	numTotal := 0
 	for _, w := range p.chain.State().TipState().GetValidatorRegistry() {
-		_, ok := p.keystore.GetValidatorKey(w.PubKey)
-		if ok {
+		if p.signer.HasKey(w.PubKey) {
 			numOurs++
 		}
 		numTotal++
@@ -438,6 +284,10 @@ check:
 		goto check
 	}
 
+	if !p.detectDoppelgangers() {
+		return
+	}
+
 	p.log.Infof("starting proposer with %d/%d active validators", numOurs, numTotal)
 
 	go p.VoteForBlocks()
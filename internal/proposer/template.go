@@ -0,0 +1,121 @@
+package proposer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/mempool"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// BlockTemplate assembles the unsigned block of a slot on top of the tip with the mempool items that are valid
+// on the tip state. The block must be signed by the proposer of the slot. The state after processing the block is
+// returned, its RANDAO doesn't include the reveal of the proposer.
+func BlockTemplate(ch chain.Blockchain, pool mempool.Pool, slot uint64) (*primitives.Block, state.State, error) {
+	tip := ch.State().Tip()
+
+	blockState, err := ch.State().TipStateAtSlot(slot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get tip state at slot %d: %s", slot, err)
+	}
+
+	// The mempool applies the items to the state while selecting them, the tip state is shared with the chain.
+	postState := blockState.Copy()
+	blockState = blockState.Copy()
+
+	block := newBlock(pool, blockState, tip.Hash, slot, slotProposer(blockState, slot))
+
+	if err := postState.ProcessTrustedBlock(block); err != nil {
+		return nil, nil, fmt.Errorf("block template for slot %d is not valid: %s", slot, err)
+	}
+
+	return block, postState, nil
+}
+
+// VoteData returns the vote data of a slot on top of the tip and the state used to build it.
+func VoteData(ch chain.Blockchain, slot uint64) (*primitives.VoteData, state.State, error) {
+	netParams := config.GlobalParams.NetParams
+
+	voteState, err := ch.State().TipStateAtSlot(slot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get tip state at slot %d: %s", slot, err)
+	}
+
+	beaconBlock, found := ch.State().Chain().GetNodeBySlot(slot - 1)
+	if !found {
+		return nil, nil, fmt.Errorf("unable to find block at slot %d", slot-1)
+	}
+
+	toEpoch := (slot - 1) / netParams.EpochLength
+
+	return &primitives.VoteData{
+		Slot:            slot,
+		FromEpoch:       voteState.GetJustifiedEpoch(),
+		FromHash:        voteState.GetJustifiedEpochHash(),
+		ToEpoch:         toEpoch,
+		ToHash:          voteState.GetRecentBlockHash(toEpoch*netParams.EpochLength - 1),
+		BeaconBlockHash: beaconBlock.Hash,
+	}, voteState, nil
+}
+
+// slotProposer returns the index of the validator proposing on a slot of the epoch of the state.
+func slotProposer(s state.State, slot uint64) uint64 {
+	netParams := config.GlobalParams.NetParams
+
+	slotIndex := (slot + netParams.EpochLength - 1) % netParams.EpochLength
+	return s.GetProposerQueue()[slotIndex]
+}
+
+// newBlock builds the block of the proposer for a slot with the mempool items valid on the block state.
+func newBlock(pool mempool.Pool, blockState state.State, prevHash chainhash.Hash, slot uint64, proposer uint64) *primitives.Block {
+	proposerValidator := blockState.GetValidatorRegistry()[proposer]
+
+	votes := pool.GetVotes(slot, blockState, proposer)
+
+	deposits, blockState := pool.GetDeposits(blockState)
+
+	exits, blockState := pool.GetExits(blockState)
+
+	partialExits, blockState := pool.GetPartialExits(blockState)
+
+	txs, blockState := pool.GetTxs(blockState, proposerValidator.PayeeAddress)
+
+	voteSlashings, blockState := pool.GetVoteSlashings(blockState)
+
+	proposerSlashings, blockState := pool.GetProposerSlashings(blockState)
+
+	randaoSlashings, _ := pool.GetRANDAOSlashings(blockState)
+
+	block := &primitives.Block{
+		Header: &primitives.BlockHeader{
+			Version:       0,
+			PrevBlockHash: prevHash,
+			Timestamp:     uint64(time.Now().Unix()),
+			Slot:          slot,
+			FeeAddress:    proposerValidator.PayeeAddress,
+		},
+		Votes:             votes,
+		Deposits:          deposits,
+		Exits:             exits,
+		PartialExit:       partialExits,
+		Txs:               txs,
+		VoteSlashings:     voteSlashings,
+		ProposerSlashings: proposerSlashings,
+		RANDAOSlashings:   randaoSlashings,
+	}
+
+	block.Header.VoteMerkleRoot = block.VotesMerkleRoot()
+	block.Header.DepositMerkleRoot = block.DepositMerkleRoot()
+	block.Header.ExitMerkleRoot = block.ExitMerkleRoot()
+	block.Header.PartialExitMerkleRoot = block.PartialExitsMerkleRoot()
+	block.Header.TxsMerkleRoot = block.TxsMerkleRoot()
+	block.Header.VoteSlashingMerkleRoot = block.VoteSlashingRoot()
+	block.Header.ProposerSlashingMerkleRoot = block.ProposerSlashingsRoot()
+	block.Header.RANDAOSlashingMerkleRoot = block.RANDAOSlashingsRoot()
+
+	return block
+}
@@ -0,0 +1,79 @@
+package proposer
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/chain/chaintest"
+	"github.com/olympus-protocol/ogen/internal/mempool"
+	"github.com/olympus-protocol/ogen/internal/state"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+// templatePool offers a vote and applies it to the state like the mempool does.
+type templatePool struct {
+	mempool.Pool
+	vote *primitives.MultiValidatorVote
+}
+
+func (p *templatePool) GetVotes(_ uint64, s state.State, index uint64) []*primitives.MultiValidatorVote {
+	if err := s.ProcessVote(p.vote, index); err != nil {
+		return nil
+	}
+	return []*primitives.MultiValidatorVote{p.vote}
+}
+
+func (p *templatePool) GetDeposits(s state.State) ([]*primitives.Deposit, state.State) {
+	return nil, s
+}
+
+func (p *templatePool) GetExits(s state.State) ([]*primitives.Exit, state.State) {
+	return nil, s
+}
+
+func (p *templatePool) GetPartialExits(s state.State) ([]*primitives.PartialExit, state.State) {
+	return nil, s
+}
+
+func (p *templatePool) GetTxs(s state.State, _ [20]byte) ([]*primitives.Tx, state.State) {
+	return nil, s
+}
+
+func (p *templatePool) GetVoteSlashings(s state.State) ([]*primitives.VoteSlashing, state.State) {
+	return nil, s
+}
+
+func (p *templatePool) GetProposerSlashings(s state.State) ([]*primitives.ProposerSlashing, state.State) {
+	return nil, s
+}
+
+func (p *templatePool) GetRANDAOSlashings(s state.State) ([]*primitives.RANDAOSlashing, state.State) {
+	return nil, s
+}
+
+func Test_BlockTemplate(t *testing.T) {
+	c := chaintest.NewChain(t)
+	c.Extend(t, 3)
+
+	pool := &templatePool{vote: c.Vote(t, c.State().Tip().Hash, 3)}
+
+	block, postState, err := BlockTemplate(c, pool, 4)
+	assert.NoError(t, err)
+	assert.Len(t, block.Votes, 1)
+
+	c.Sign(t, block)
+	assert.NoError(t, c.ProcessBlock(block))
+	assert.Equal(t, block.Hash(), c.State().Tip().Hash)
+
+	// The template post-state doesn't include the RANDAO reveal of the proposer.
+	expected := postState.ToSerializable()
+	for i := range expected.NextRANDAO {
+		expected.NextRANDAO[i] ^= block.RandaoSignature[i]
+	}
+	expectedRoot, err := expected.HashTreeRoot()
+	assert.NoError(t, err)
+
+	root, err := c.State().TipState().ToSerializable().HashTreeRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRoot, root)
+}
@@ -2,8 +2,63 @@ package server
 
 import (
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
 )
 
 func (s *server) apis() []rpc.API {
-	return []rpc.API{}
+	return []rpc.API{
+		{
+			Namespace: "chain",
+			Version:   "1.0",
+			Service:   &chainAPI{ch: s.ch},
+			Public:    true,
+		},
+		{
+			Namespace: "validator",
+			Version:   "1.0",
+			Service:   &validatorAPI{ch: s.ch, h: s.h, pool: s.pool},
+			// The validator namespace submits blocks and votes, it is only served when listed on the modules.
+			Public: false,
+		},
+	}
+}
+
+// chainAPI exposes the chain queries.
+type chainAPI struct {
+	ch chain.Blockchain
+}
+
+// ValidatorRewards is the reward and penalty history of a validator over an epoch range.
+type ValidatorRewards struct {
+	Validator uint64                     `json:"validator"`
+	FromEpoch uint64                     `json:"from_epoch"`
+	ToEpoch   uint64                     `json:"to_epoch"`
+	Receipts  []*primitives.EpochReceipt `json:"receipts"`
+	Summary   *chain.ReceiptsSummary     `json:"summary"`
+}
+
+// EpochReceipts returns all the rewards and penalties applied on an epoch transition.
+func (api *chainAPI) EpochReceipts(epoch uint64) ([]*primitives.EpochReceipt, error) {
+	return api.ch.GetEpochReceipts(epoch)
+}
+
+// ValidatorRewards returns the rewards and penalties of a validator from fromEpoch to toEpoch, both included.
+func (api *chainAPI) ValidatorRewards(validator uint64, fromEpoch uint64, toEpoch uint64) (*ValidatorRewards, error) {
+	receipts, err := api.ch.GetValidatorReceipts(validator, fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorRewards{
+		Validator: validator,
+		FromEpoch: fromEpoch,
+		ToEpoch:   toEpoch,
+		Receipts:  receipts,
+		Summary:   chain.NewReceiptsSummary(receipts),
+	}, nil
+}
+
+// ValidatorRewardsSummary returns the reward and penalty totals of a validator from fromEpoch to toEpoch, both included.
+func (api *chainAPI) ValidatorRewardsSummary(validator uint64, fromEpoch uint64, toEpoch uint64) (*chain.ReceiptsSummary, error) {
+	return api.ch.GetValidatorReceiptsSummary(validator, fromEpoch, toEpoch)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/olympus-protocol/ogen/internal/keystore"
 	"github.com/olympus-protocol/ogen/internal/mempool"
 	"github.com/olympus-protocol/ogen/internal/proposer"
+	"github.com/olympus-protocol/ogen/internal/signer"
 	"github.com/olympus-protocol/ogen/pkg/logger"
 	"sync"
 )
@@ -180,7 +181,7 @@ func (s *server) stopInProc() {
 }
 
 // NewServer creates a server instance and initializes the ogen services.
-func NewServer(db blockdb.Database) (Server, error) {
+func NewServer(db blockdb.Database, ks keystore.Keystore) (Server, error) {
 
 	log := config.GlobalParams.Logger
 	netParams := config.GlobalParams.NetParams
@@ -189,7 +190,7 @@ func NewServer(db blockdb.Database) (Server, error) {
 
 	log.Tracef("Initializing bls module with params for %v", netParams.Name)
 
-	ch, err := chain.NewBlockchain(db)
+	ch, err := chain.NewBlockchain(db, netParams.GenesisHash)
 	if err != nil {
 		return nil, err
 	}
@@ -201,9 +202,17 @@ func NewServer(db blockdb.Database) (Server, error) {
 
 	pool := mempool.NewPool(ch, h)
 
-	ks := keystore.NewKeystore()
+	sig := signer.NewLocalSigner(ks, config.GlobalParams.NetParams)
+	if config.GlobalFlags.RemoteSigner != "" {
+		token, err := signer.LoadToken(config.GlobalFlags.RemoteSignerTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("using remote signer at %s", config.GlobalFlags.RemoteSigner)
+		sig = signer.NewRemoteSigner(config.GlobalFlags.RemoteSigner, token, config.GlobalParams.NetParams)
+	}
 
-	prop, err := proposer.NewProposer(ch, h, pool, ks)
+	prop, err := proposer.NewProposer(ch, h, pool, ks, sig)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/chain"
+	"github.com/olympus-protocol/ogen/internal/duties"
+	"github.com/olympus-protocol/ogen/internal/host"
+	"github.com/olympus-protocol/ogen/internal/mempool"
+	"github.com/olympus-protocol/ogen/internal/proposer"
+	"github.com/olympus-protocol/ogen/internal/validator"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// validatorAPI serves the duties of the validator clients running on other processes.
+type validatorAPI struct {
+	ch   chain.Blockchain
+	h    host.Host
+	pool mempool.Pool
+}
+
+// ChainInfo returns the chain followed by the node.
+func (api *validatorAPI) ChainInfo() *validator.ChainInfo {
+	netParams := config.GlobalParams.NetParams
+
+	return &validator.ChainInfo{
+		Network:     netParams.Name,
+		GenesisHash: api.ch.GenesisHash().String(),
+		GenesisTime: api.ch.GenesisTime().Unix(),
+		ForkVersion: netParams.ForkVersion,
+		TipSlot:     api.ch.State().Tip().Slot,
+		Synced:      api.h.Synced(),
+	}
+}
+
+// Duties returns the validators proposing and voting on a slot on top of the tip.
+func (api *validatorAPI) Duties(slot uint64) (*validator.Duties, error) {
+	if !api.h.Synced() {
+		return nil, validator.ErrorNotSynced
+	}
+	if slot == 0 {
+		return nil, errors.New("there are no duties on the genesis slot")
+	}
+
+	s, err := api.ch.State().TipStateAtSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	committee, err := s.GetVoteCommittee(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	proposerPub, err := s.GetProposerPublicKeyAtSlot(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := s.GetValidatorRegistry()
+
+	duties := &validator.Duties{
+		Slot:      slot,
+		Proposer:  hex.EncodeToString(proposerPub.Marshal()),
+		Committee: make([]string, len(committee)),
+	}
+	for i, index := range committee {
+		duties.Committee[i] = validator.EncodePubKey(registry[index].PubKey)
+	}
+
+	return duties, nil
+}
+
+// VoteData returns the vote data to sign for a slot on top of the tip.
+func (api *validatorAPI) VoteData(slot uint64) (string, error) {
+	if !api.h.Synced() {
+		return "", validator.ErrorNotSynced
+	}
+	if slot == 0 {
+		return "", errors.New("there are no votes on the genesis slot")
+	}
+
+	data, _, err := proposer.VoteData(api.ch, slot)
+	if err != nil {
+		return "", err
+	}
+	return validator.Encode(data)
+}
+
+// BlockTemplate returns the unsigned block of a slot on top of the tip and the state after processing it.
+func (api *validatorAPI) BlockTemplate(slot uint64) (*validator.BlockTemplate, error) {
+	if !api.h.Synced() {
+		return nil, validator.ErrorNotSynced
+	}
+	if slot <= api.ch.State().Tip().Slot {
+		return nil, errors.New("slot must be after the tip slot")
+	}
+
+	block, postState, err := proposer.BlockTemplate(api.ch, api.pool, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := validator.Encode(block)
+	if err != nil {
+		return nil, err
+	}
+
+	proposerPub, err := postState.GetProposerPublicKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	var fees uint64
+	for _, tx := range block.Txs {
+		fees += tx.Fee
+	}
+
+	validators := postState.GetValidators()
+
+	return &validator.BlockTemplate{
+		Block:    raw,
+		Proposer: hex.EncodeToString(proposerPub.Marshal()),
+		Fees:     fees,
+		PostState: validator.PostState{
+			Slot:           postState.GetSlot(),
+			Epoch:          postState.GetEpochIndex(),
+			JustifiedEpoch: postState.GetJustifiedEpoch(),
+			FinalizedEpoch: postState.GetFinalizedEpoch(),
+			TotalBalance:   postState.GetTotalBalances(),
+			Validators:     len(validators.Validators),
+			Active:         validators.Active,
+			Starting:       validators.Starting,
+			PendingExit:    validators.PendingExit,
+			Exited:         validators.Exited,
+		},
+	}, nil
+}
+
+// SubmitBlock processes a signed block and broadcasts it. Blocks can be built by other processes from a block
+// template or from scratch.
+func (api *validatorAPI) SubmitBlock(raw string) (string, error) {
+	block := new(primitives.Block)
+	if err := validator.Decode(raw, block); err != nil {
+		return "", err
+	}
+
+	if err := api.ch.ProcessBlock(block); err != nil {
+		return "", err
+	}
+
+	if err := api.h.Broadcast(&p2p.MsgBlock{Data: block}); err != nil {
+		return "", err
+	}
+
+	return block.Hash().String(), nil
+}
+
+// SubmitVote adds a signed vote to the mempool and broadcasts it.
+func (api *validatorAPI) SubmitVote(raw string) error {
+	vote := new(primitives.MultiValidatorVote)
+	if err := validator.Decode(raw, vote); err != nil {
+		return err
+	}
+	if vote.Data == nil || vote.Data.Slot == 0 {
+		return errors.New("invalid vote slot")
+	}
+
+	voteState, err := api.ch.State().TipStateAtSlot(vote.Data.Slot)
+	if err != nil {
+		return err
+	}
+
+	if err := api.pool.AddVote(vote, voteState); err != nil {
+		return err
+	}
+
+	return api.h.Broadcast(&p2p.MsgVote{Data: vote})
+}
+
+// maxVotersEpochs is the number of epochs before the tip the voters can be looked for.
+const maxVotersEpochs = 16
+
+// Voters returns the validators with votes for slots after fromSlot included on the main chain, mapped from their
+// public key to their index. The validator clients use it to look for their keys running on another node.
+func (api *validatorAPI) Voters(fromSlot uint64) (map[string]uint64, error) {
+	if !api.h.Synced() {
+		return nil, validator.ErrorNotSynced
+	}
+
+	tip := api.ch.State().Tip()
+	if maxSlots := maxVotersEpochs * config.GlobalParams.NetParams.EpochLength; tip.Slot > fromSlot+maxSlots {
+		return nil, fmt.Errorf("voters can only be looked for during the last %d slots", maxSlots)
+	}
+
+	watched := make(map[uint64][48]byte)
+	for i, v := range api.ch.State().TipState().GetValidatorRegistry() {
+		watched[uint64(i)] = v.PubKey
+	}
+	d := duties.NewDoppelganger(fromSlot, watched)
+
+	for row := tip; row != nil && row.Slot > fromSlot; row = row.Parent {
+		block, err := api.ch.GetBlock(row.Hash)
+		if err != nil {
+			return nil, err
+		}
+		s, found := api.ch.State().GetStateForHash(row.Hash)
+		if !found {
+			return nil, fmt.Errorf("unable to find the state of block %s", row.Hash)
+		}
+		d.CheckBlock(block, s)
+	}
+
+	voters := make(map[string]uint64)
+	for pub, index := range d.Detected() {
+		voters[validator.EncodePubKey(pub)] = index
+	}
+	return voters, nil
+}
+
+// CheckDeposit validates a signed deposit against the tip state without submitting it.
+func (api *validatorAPI) CheckDeposit(raw string) error {
+	deposit, err := api.decodeDeposit(raw)
+	if err != nil {
+		return err
+	}
+	return api.ch.State().TipState().IsDepositValid(deposit)
+}
+
+// SubmitDeposit adds a signed deposit to the mempool and broadcasts it.
+func (api *validatorAPI) SubmitDeposit(raw string) error {
+	deposit, err := api.decodeDeposit(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := api.pool.AddDeposit(deposit); err != nil {
+		return err
+	}
+
+	return api.h.Broadcast(&p2p.MsgDeposits{Data: []*primitives.Deposit{deposit}})
+}
+
+// CheckExit validates a signed exit against the tip state without submitting it.
+func (api *validatorAPI) CheckExit(raw string) error {
+	exit, err := api.decodeExit(raw)
+	if err != nil {
+		return err
+	}
+	return api.ch.State().TipState().IsExitValid(exit)
+}
+
+// SubmitExit adds a signed exit to the mempool and broadcasts it.
+func (api *validatorAPI) SubmitExit(raw string) error {
+	exit, err := api.decodeExit(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := api.pool.AddExit(exit); err != nil {
+		return err
+	}
+
+	return api.h.Broadcast(&p2p.MsgExits{Data: []*primitives.Exit{exit}})
+}
+
+// CheckPartialExit validates a signed partial exit against the tip state without submitting it.
+func (api *validatorAPI) CheckPartialExit(raw string) error {
+	partialExit, err := api.decodePartialExit(raw)
+	if err != nil {
+		return err
+	}
+	return api.ch.State().TipState().IsPartialExitValid(partialExit)
+}
+
+// SubmitPartialExit adds a signed partial exit to the mempool and broadcasts it.
+func (api *validatorAPI) SubmitPartialExit(raw string) error {
+	partialExit, err := api.decodePartialExit(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := api.pool.AddPartialExit(partialExit); err != nil {
+		return err
+	}
+
+	return api.h.Broadcast(&p2p.MsgPartialExits{Data: []*primitives.PartialExit{partialExit}})
+}
+
+// decodeDeposit decodes a deposit to validate against the tip state. Nodes that are not synced refuse it, they would
+// reject valid deposits.
+func (api *validatorAPI) decodeDeposit(raw string) (*primitives.Deposit, error) {
+	if !api.h.Synced() {
+		return nil, validator.ErrorNotSynced
+	}
+	deposit := new(primitives.Deposit)
+	if err := validator.Decode(raw, deposit); err != nil {
+		return nil, err
+	}
+	if deposit.Data == nil {
+		return nil, errors.New("deposit without data")
+	}
+	return deposit, nil
+}
+
+func (api *validatorAPI) decodeExit(raw string) (*primitives.Exit, error) {
+	if !api.h.Synced() {
+		return nil, validator.ErrorNotSynced
+	}
+	exit := new(primitives.Exit)
+	if err := validator.Decode(raw, exit); err != nil {
+		return nil, err
+	}
+	return exit, nil
+}
+
+func (api *validatorAPI) decodePartialExit(raw string) (*primitives.PartialExit, error) {
+	if !api.h.Synced() {
+		return nil, validator.ErrorNotSynced
+	}
+	partialExit := new(primitives.PartialExit)
+	if err := validator.Decode(raw, partialExit); err != nil {
+		return nil, err
+	}
+	return partialExit, nil
+}
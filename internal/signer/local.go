@@ -0,0 +1,62 @@
+package signer
+
+import (
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/params"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// localSigner signs with the keys of an open keystore.
+type localSigner struct {
+	ks        keystore.Keystore
+	netParams *params.ChainParams
+}
+
+var _ Signer = &localSigner{}
+
+// NewLocalSigner returns a signer using the keys of an open keystore. The messages are signed on the
+// domains of the network parameters.
+func NewLocalSigner(ks keystore.Keystore, netParams *params.ChainParams) Signer {
+	return &localSigner{ks: ks, netParams: netParams}
+}
+
+func (l *localSigner) HasKey(pub [48]byte) bool {
+	k, ok := l.ks.GetValidatorKey(pub)
+	return ok && k.Enable
+}
+
+func (l *localSigner) SignBlock(pub [48]byte, header *primitives.BlockHeader) (common.Signature, error) {
+	k, err := l.key(pub)
+	if err != nil {
+		return nil, err
+	}
+	h := header.SigningMessage(l.netParams)
+	return k.Secret.Sign(h[:]), nil
+}
+
+func (l *localSigner) SignRANDAO(pub [48]byte, slot uint64) (common.Signature, error) {
+	k, err := l.key(pub)
+	if err != nil {
+		return nil, err
+	}
+	h := primitives.RANDAOMessage(l.netParams, slot)
+	return k.Secret.Sign(h[:]), nil
+}
+
+func (l *localSigner) SignVote(pub [48]byte, data *primitives.VoteData) (common.Signature, error) {
+	k, err := l.key(pub)
+	if err != nil {
+		return nil, err
+	}
+	h := data.SigningMessage(l.netParams)
+	return k.Secret.Sign(h[:]), nil
+}
+
+func (l *localSigner) key(pub [48]byte) (*keystore.Key, error) {
+	k, ok := l.ks.GetValidatorKey(pub)
+	if !ok || !k.Enable {
+		return nil, ErrorUnknownKey
+	}
+	return k, nil
+}
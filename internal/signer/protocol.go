@@ -0,0 +1,57 @@
+package signer
+
+// The remote signer protocol is a JSON API over HTTP. Byte values are hex encoded without prefix and
+// integers are encoded as decimal strings. When the signer is started with a token, every request must
+// include the header "Authorization: Bearer <token>". The signer doesn't terminate TLS, it must listen on a
+// private network or behind a TLS proxy. The sign requests include the genesis hash of the chain of the
+// client, the signer refuses to sign for a different chain.
+//
+//   GET  /v1/keys         returns the enabled public keys.
+//                         response: {"keys": ["<pubkey>", ...]}
+//   POST /v1/sign/block   signs a block header.
+//                         request: {"pubkey": "<pubkey>", "genesis_hash": "<hash>", "header": "<ssz encoded block header>"}
+//   POST /v1/sign/randao  signs the RANDAO reveal of a slot after the block of the slot is signed.
+//                         request: {"pubkey": "<pubkey>", "genesis_hash": "<hash>", "slot": "<slot>"}
+//   POST /v1/sign/vote    signs a vote data.
+//                         request: {"pubkey": "<pubkey>", "genesis_hash": "<hash>", "vote_data": "<ssz encoded vote data>"}
+//
+// The sign requests return {"signature": "<signature>"}. Errors return {"error": "<message>"} with the
+// status 400 for malformed requests, 401 for a missing or wrong token, 404 for unknown keys and 412 when
+// the slashing protection of the signer refuses to sign.
+
+const (
+	keysPath       = "/v1/keys"
+	signBlockPath  = "/v1/sign/block"
+	signRANDAOPath = "/v1/sign/randao"
+	signVotePath   = "/v1/sign/vote"
+)
+
+type keysResponse struct {
+	Keys []string `json:"keys"`
+}
+
+type signBlockRequest struct {
+	PubKey      string `json:"pubkey"`
+	GenesisHash string `json:"genesis_hash"`
+	Header      string `json:"header"`
+}
+
+type signRANDAORequest struct {
+	PubKey      string `json:"pubkey"`
+	GenesisHash string `json:"genesis_hash"`
+	Slot        uint64 `json:"slot,string"`
+}
+
+type signVoteRequest struct {
+	PubKey      string `json:"pubkey"`
+	GenesisHash string `json:"genesis_hash"`
+	VoteData    string `json:"vote_data"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,168 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/params"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+const (
+	// remoteTimeout is the maximum time to wait for a remote signer response.
+	remoteTimeout = 5 * time.Second
+
+	// keysRefreshInterval is the time the public keys of the remote signer are cached.
+	keysRefreshInterval = time.Minute
+)
+
+// remoteSigner signs with the keys of a remote signer using the HTTP protocol.
+type remoteSigner struct {
+	url       string
+	token     string
+	netParams *params.ChainParams
+	client    *http.Client
+
+	keysLock    sync.Mutex
+	keys        map[[48]byte]struct{}
+	keysFetched time.Time
+}
+
+var _ Signer = &remoteSigner{}
+
+// NewRemoteSigner returns a signer using the remote signer listening on the url. The token is sent on every
+// request when not empty. The sign requests include the genesis hash of the network parameters.
+func NewRemoteSigner(url string, token string, netParams *params.ChainParams) Signer {
+	return &remoteSigner{
+		url:       strings.TrimSuffix(url, "/"),
+		token:     token,
+		netParams: netParams,
+		client:    &http.Client{Timeout: remoteTimeout},
+	}
+}
+
+func (r *remoteSigner) HasKey(pub [48]byte) bool {
+	r.keysLock.Lock()
+	defer r.keysLock.Unlock()
+
+	if time.Since(r.keysFetched) > keysRefreshInterval {
+		keys, err := r.fetchKeys()
+		if err == nil {
+			r.keys = keys
+			r.keysFetched = time.Now()
+		}
+	}
+
+	_, ok := r.keys[pub]
+	return ok
+}
+
+func (r *remoteSigner) SignBlock(pub [48]byte, header *primitives.BlockHeader) (common.Signature, error) {
+	b, err := header.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return r.sign(signBlockPath, &signBlockRequest{
+		PubKey:      hex.EncodeToString(pub[:]),
+		GenesisHash: r.netParams.GenesisHash.String(),
+		Header:      hex.EncodeToString(b),
+	})
+}
+
+func (r *remoteSigner) SignRANDAO(pub [48]byte, slot uint64) (common.Signature, error) {
+	return r.sign(signRANDAOPath, &signRANDAORequest{
+		PubKey:      hex.EncodeToString(pub[:]),
+		GenesisHash: r.netParams.GenesisHash.String(),
+		Slot:        slot,
+	})
+}
+
+func (r *remoteSigner) SignVote(pub [48]byte, data *primitives.VoteData) (common.Signature, error) {
+	b, err := data.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return r.sign(signVotePath, &signVoteRequest{
+		PubKey:      hex.EncodeToString(pub[:]),
+		GenesisHash: r.netParams.GenesisHash.String(),
+		VoteData:    hex.EncodeToString(b),
+	})
+}
+
+func (r *remoteSigner) fetchKeys() (map[[48]byte]struct{}, error) {
+	resp := new(keysResponse)
+	if err := r.do(http.MethodGet, keysPath, nil, resp); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[[48]byte]struct{}, len(resp.Keys))
+	for _, k := range resp.Keys {
+		b, err := hex.DecodeString(k)
+		if err != nil || len(b) != 48 {
+			return nil, fmt.Errorf("remote signer returned an invalid public key %s", k)
+		}
+		var pub [48]byte
+		copy(pub[:], b)
+		keys[pub] = struct{}{}
+	}
+	return keys, nil
+}
+
+func (r *remoteSigner) sign(path string, req interface{}) (common.Signature, error) {
+	resp := new(signResponse)
+	if err := r.do(http.MethodPost, path, req, resp); err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(b)
+}
+
+func (r *remoteSigner) do(method string, path string, req interface{}, resp interface{}) error {
+	var body bytes.Buffer
+	if req != nil {
+		if err := json.NewEncoder(&body).Encode(req); err != nil {
+			return err
+		}
+	}
+
+	httpReq, err := http.NewRequest(method, r.url+path, &body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	httpResp, err := r.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		errResp := new(errorResponse)
+		_ = json.NewDecoder(httpResp.Body).Decode(errResp)
+		switch httpResp.StatusCode {
+		case http.StatusNotFound:
+			return ErrorUnknownKey
+		case http.StatusPreconditionFailed:
+			return fmt.Errorf("%w: %s", ErrorSigningRefused, errResp.Error)
+		default:
+			return fmt.Errorf("remote signer error %d: %s", httpResp.StatusCode, errResp.Error)
+		}
+	}
+
+	return json.NewDecoder(httpResp.Body).Decode(resp)
+}
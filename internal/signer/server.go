@@ -0,0 +1,228 @@
+package signer
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/params"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// maxRequestSize limits the size of the request bodies.
+const maxRequestSize = 1 << 16
+
+// Server serves the keys of a keystore with the remote signer protocol. Every signature is checked
+// against the slashing protection database of the signer.
+type Server struct {
+	log        logger.Logger
+	ks         keystore.Keystore
+	local      Signer
+	protection *slashingprotection.DB
+	netParams  *params.ChainParams
+	token      string
+}
+
+// NewServer returns a remote signer server for an open keystore. Requests must include the token when not empty
+// and the genesis hash of the network parameters.
+func NewServer(log logger.Logger, ks keystore.Keystore, protection *slashingprotection.DB, netParams *params.ChainParams, token string) *Server {
+	return &Server{
+		log:        log,
+		ks:         ks,
+		local:      NewLocalSigner(ks, netParams),
+		protection: protection,
+		netParams:  netParams,
+		token:      token,
+	}
+}
+
+// Handler returns the HTTP handler of the protocol.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(keysPath, s.auth(http.MethodGet, s.handleKeys))
+	mux.HandleFunc(signBlockPath, s.auth(http.MethodPost, s.handleSignBlock))
+	mux.HandleFunc(signRANDAOPath, s.auth(http.MethodPost, s.handleSignRANDAO))
+	mux.HandleFunc(signVotePath, s.auth(http.MethodPost, s.handleSignVote))
+	return mux
+}
+
+func (s *Server) auth(method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		if s.token != "" {
+			expected := "Bearer " + s.token
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+				writeError(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+		h(w, r)
+	}
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	keys, err := s.ks.GetValidatorKeys()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := keysResponse{Keys: []string{}}
+	for _, k := range keys {
+		if k.Enable {
+			resp.Keys = append(resp.Keys, hex.EncodeToString(k.Secret.PublicKey().Marshal()))
+		}
+	}
+	writeJSON(w, &resp)
+}
+
+func (s *Server) handleSignBlock(w http.ResponseWriter, r *http.Request) {
+	req := new(signBlockRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pub, ok := decodePubKey(w, req.PubKey)
+	if !ok || !s.checkGenesis(w, req.GenesisHash) {
+		return
+	}
+	b, err := hex.DecodeString(req.Header)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	header := new(primitives.BlockHeader)
+	if err := header.Unmarshal(b); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !s.local.HasKey(pub) {
+		writeError(w, http.StatusNotFound, ErrorUnknownKey.Error())
+		return
+	}
+
+	if err := s.protection.ProtectBlock(pub, header.Slot, header.Hash()); err != nil {
+		s.log.Errorf("SLASHING PROTECTION: refusing to sign block %s for slot %d with validator %x: %s", header.Hash(), header.Slot, pub, err)
+		writeError(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	sig, err := s.local.SignBlock(pub, header)
+	s.writeSignature(w, sig, err)
+}
+
+func (s *Server) handleSignRANDAO(w http.ResponseWriter, r *http.Request) {
+	req := new(signRANDAORequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pub, ok := decodePubKey(w, req.PubKey)
+	if !ok || !s.checkGenesis(w, req.GenesisHash) {
+		return
+	}
+
+	// A RANDAO reveal published before its slot can be slashed, it is only signed after the block of the slot.
+	signed, err := s.protection.HasSignedBlock(pub, req.Slot)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !signed {
+		s.log.Errorf("SLASHING PROTECTION: refusing to sign RANDAO reveal for slot %d with validator %x: no block signed for the slot", req.Slot, pub)
+		writeError(w, http.StatusPreconditionFailed, "no block signed for the slot")
+		return
+	}
+
+	sig, err := s.local.SignRANDAO(pub, req.Slot)
+	s.writeSignature(w, sig, err)
+}
+
+func (s *Server) handleSignVote(w http.ResponseWriter, r *http.Request) {
+	req := new(signVoteRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pub, ok := decodePubKey(w, req.PubKey)
+	if !ok || !s.checkGenesis(w, req.GenesisHash) {
+		return
+	}
+	b, err := hex.DecodeString(req.VoteData)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data := new(primitives.VoteData)
+	if err := data.Unmarshal(b); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !s.local.HasKey(pub) {
+		writeError(w, http.StatusNotFound, ErrorUnknownKey.Error())
+		return
+	}
+
+	if err := s.protection.ProtectVote(pub, data); err != nil {
+		s.log.Errorf("SLASHING PROTECTION: refusing to sign vote for slot %d (epochs %d-%d) with validator %x: %s", data.Slot, data.FromEpoch, data.ToEpoch, pub, err)
+		writeError(w, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	sig, err := s.local.SignVote(pub, data)
+	s.writeSignature(w, sig, err)
+}
+
+func (s *Server) writeSignature(w http.ResponseWriter, sig common.Signature, err error) {
+	if err == ErrorUnknownKey {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, &signResponse{Signature: hex.EncodeToString(sig.Marshal())})
+}
+
+// checkGenesis ensures the request is made for the chain of the signer, the signatures are only valid on it.
+func (s *Server) checkGenesis(w http.ResponseWriter, genesisHash string) bool {
+	if genesisHash != s.netParams.GenesisHash.String() {
+		writeError(w, http.StatusBadRequest, "genesis hash doesn't match")
+		return false
+	}
+	return true
+}
+
+func decodePubKey(w http.ResponseWriter, s string) ([48]byte, bool) {
+	var pub [48]byte
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 48 {
+		writeError(w, http.StatusBadRequest, "invalid public key")
+		return pub, false
+	}
+	copy(pub[:], b)
+	return pub, true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&errorResponse{Error: msg})
+}
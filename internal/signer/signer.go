@@ -0,0 +1,40 @@
+// Package signer signs the validator duties with keys stored on the node keystore or on a remote signer.
+package signer
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+var (
+	// ErrorUnknownKey returns when the signer doesn't have the key or the key is disabled.
+	ErrorUnknownKey = errors.New("the signer doesn't have the key")
+
+	// ErrorSigningRefused returns when a remote signer refuses to sign a message because of its slashing protection.
+	ErrorSigningRefused = errors.New("the signer refused to sign the message")
+)
+
+// Signer signs the blocks, RANDAO reveals and votes of the validators.
+type Signer interface {
+	// HasKey returns true if the signer has the key of the validator enabled.
+	HasKey(pub [48]byte) bool
+	SignBlock(pub [48]byte, header *primitives.BlockHeader) (common.Signature, error)
+	SignRANDAO(pub [48]byte, slot uint64) (common.Signature, error)
+	SignVote(pub [48]byte, data *primitives.VoteData) (common.Signature, error)
+}
+
+// LoadToken reads the remote signer token from a file. An empty file name returns an empty token.
+func LoadToken(file string) (string, error) {
+	if file == "" {
+		return "", nil
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,84 @@
+package signer_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/keystore"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	testdata "github.com/olympus-protocol/ogen/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RemoteSigner(t *testing.T) {
+	dir := t.TempDir()
+	config.GlobalFlags = &config.Flags{DataPath: dir}
+
+	ks := keystore.NewKeystore()
+	assert.NoError(t, ks.CreateKeystore("passphrase"))
+	defer ks.Close()
+
+	keys, err := ks.GenerateNewValidatorKey(1)
+	assert.NoError(t, err)
+	var pub [48]byte
+	copy(pub[:], keys[0].Secret.PublicKey().Marshal())
+
+	protection, err := slashingprotection.Open(dir)
+	assert.NoError(t, err)
+	defer protection.Close()
+
+	netParams := testdata.TestParams
+	netParams.GenesisHash = chainhash.HashH([]byte("genesis"))
+
+	srv := httptest.NewServer(signer.NewServer(logger.New(os.Stdout), ks, protection, &netParams, "token").Handler())
+	defer srv.Close()
+
+	unauthorized := signer.NewRemoteSigner(srv.URL, "wrong", &netParams)
+	assert.False(t, unauthorized.HasKey(pub))
+
+	remote := signer.NewRemoteSigner(srv.URL, "token", &netParams)
+	assert.True(t, remote.HasKey(pub))
+	assert.False(t, remote.HasKey([48]byte{}))
+
+	otherParams := netParams
+	otherParams.GenesisHash = chainhash.HashH([]byte("other genesis"))
+	otherChain := signer.NewRemoteSigner(srv.URL, "token", &otherParams)
+	_, err = otherChain.SignBlock(pub, &primitives.BlockHeader{Slot: 10})
+	assert.Error(t, err)
+
+	_, err = remote.SignRANDAO(pub, 10)
+	assert.ErrorIs(t, err, signer.ErrorSigningRefused)
+
+	header := &primitives.BlockHeader{Slot: 10, PrevBlockHash: chainhash.HashH([]byte("parent"))}
+	blockHash := header.SigningMessage(&netParams)
+	sig, err := remote.SignBlock(pub, header)
+	assert.NoError(t, err)
+	assert.True(t, sig.Verify(keys[0].Secret.PublicKey(), blockHash[:]))
+
+	randaoHash := primitives.RANDAOMessage(&netParams, 10)
+	sig, err = remote.SignRANDAO(pub, 10)
+	assert.NoError(t, err)
+	assert.True(t, sig.Verify(keys[0].Secret.PublicKey(), randaoHash[:]))
+
+	other := &primitives.BlockHeader{Slot: 10, PrevBlockHash: chainhash.HashH([]byte("other"))}
+	_, err = remote.SignBlock(pub, other)
+	assert.ErrorIs(t, err, signer.ErrorSigningRefused)
+
+	vote := &primitives.VoteData{Slot: 20, FromEpoch: 2, ToEpoch: 4}
+	voteHash := vote.SigningMessage(&netParams)
+	sig, err = remote.SignVote(pub, vote)
+	assert.NoError(t, err)
+	assert.True(t, sig.Verify(keys[0].Secret.PublicKey(), voteHash[:]))
+
+	_, err = remote.SignVote(pub, &primitives.VoteData{Slot: 30, FromEpoch: 1, ToEpoch: 5})
+	assert.ErrorIs(t, err, signer.ErrorSigningRefused)
+
+	_, err = remote.SignVote([48]byte{}, vote)
+	assert.Equal(t, signer.ErrorUnknownKey, err)
+}
@@ -0,0 +1,198 @@
+package slashingprotection
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"go.etcd.io/bbolt"
+)
+
+// InterchangeVersion is the version of the interchange format.
+const InterchangeVersion = "1"
+
+var (
+	// ErrorInterchangeVersion returns when the interchange file uses an unsupported format version.
+	ErrorInterchangeVersion = errors.New("unsupported interchange format version")
+
+	// ErrorInterchangeGenesis returns when the interchange file belongs to a different chain.
+	ErrorInterchangeGenesis = errors.New("interchange genesis hash doesn't match")
+
+	// ErrorInterchangePubKey returns when the interchange file contains an invalid public key.
+	ErrorInterchangePubKey = errors.New("invalid public key on interchange")
+
+	// ErrorInterchangeRoot returns when the interchange file contains an invalid signing root.
+	ErrorInterchangeRoot = errors.New("invalid signing root on interchange")
+)
+
+// Interchange is the portable representation of the signing history used to move validators between nodes.
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata identifies the format and the chain of the signing history.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisHash              string `json:"genesis_hash"`
+}
+
+// InterchangeData is the signing history of a single key.
+type InterchangeData struct {
+	PubKey       string             `json:"pubkey"`
+	SignedBlocks []InterchangeBlock `json:"signed_blocks"`
+	SignedVotes  []InterchangeVote  `json:"signed_votes"`
+}
+
+// InterchangeBlock is a signed block on the interchange.
+type InterchangeBlock struct {
+	Slot        uint64 `json:"slot,string"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// InterchangeVote is a signed vote on the interchange.
+type InterchangeVote struct {
+	SourceEpoch uint64 `json:"source_epoch,string"`
+	TargetEpoch uint64 `json:"target_epoch,string"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// Keys returns the public keys with a signing history.
+func (d *DB) Keys() ([][48]byte, error) {
+	var keys [][48]byte
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bbolt.Bucket) error {
+			var pub [48]byte
+			copy(pub[:], name)
+			keys = append(keys, pub)
+			return nil
+		})
+	})
+	return keys, err
+}
+
+// Export returns the signing history of the specified keys. When no keys are specified the history of
+// all the keys is exported.
+func (d *DB) Export(genesisHash chainhash.Hash, pubs [][48]byte) (*Interchange, error) {
+	if len(pubs) == 0 {
+		var err error
+		pubs, err = d.Keys()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	interchange := &Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeVersion,
+			GenesisHash:              hex.EncodeToString(genesisHash[:]),
+		},
+		Data: make([]InterchangeData, 0, len(pubs)),
+	}
+
+	for _, pub := range pubs {
+		blocks, err := d.SignedBlocks(pub)
+		if err != nil {
+			return nil, err
+		}
+		votes, err := d.SignedVotes(pub)
+		if err != nil {
+			return nil, err
+		}
+
+		data := InterchangeData{
+			PubKey:       hex.EncodeToString(pub[:]),
+			SignedBlocks: make([]InterchangeBlock, len(blocks)),
+			SignedVotes:  make([]InterchangeVote, len(votes)),
+		}
+		for i, b := range blocks {
+			data.SignedBlocks[i] = InterchangeBlock{Slot: b.Slot, SigningRoot: encodeRoot(b.Root)}
+		}
+		for i, v := range votes {
+			data.SignedVotes[i] = InterchangeVote{SourceEpoch: v.FromEpoch, TargetEpoch: v.ToEpoch, SigningRoot: encodeRoot(v.Root)}
+		}
+
+		interchange.Data = append(interchange.Data, data)
+	}
+
+	return interchange, nil
+}
+
+// Import merges a signing history into the database. The merge is conservative: entries already on the
+// database are kept and the imported ones are added, so every message refused before the import or by the
+// imported history is refused after it. Imported entries without a signing root can't be signed again.
+func (d *DB) Import(genesisHash chainhash.Hash, interchange *Interchange) error {
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeVersion {
+		return ErrorInterchangeVersion
+	}
+
+	if interchange.Metadata.GenesisHash != hex.EncodeToString(genesisHash[:]) {
+		return ErrorInterchangeGenesis
+	}
+
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		for _, data := range interchange.Data {
+			pubBytes, err := hex.DecodeString(data.PubKey)
+			if err != nil || len(pubBytes) != 48 {
+				return ErrorInterchangePubKey
+			}
+			var pub [48]byte
+			copy(pub[:], pubBytes)
+
+			blocks, err := keyBucket(tx, pub, blocksBucket)
+			if err != nil {
+				return err
+			}
+			for _, b := range data.SignedBlocks {
+				root, err := decodeRoot(b.SigningRoot)
+				if err != nil {
+					return err
+				}
+				if blocks.Get(uint64Key(b.Slot)) != nil {
+					continue
+				}
+				if err := blocks.Put(uint64Key(b.Slot), root[:]); err != nil {
+					return err
+				}
+			}
+
+			votes, err := keyBucket(tx, pub, votesBucket)
+			if err != nil {
+				return err
+			}
+			for _, v := range data.SignedVotes {
+				root, err := decodeRoot(v.SigningRoot)
+				if err != nil {
+					return err
+				}
+				if votes.Get(uint64Key(v.TargetEpoch)) != nil {
+					continue
+				}
+				if err := votes.Put(uint64Key(v.TargetEpoch), encodeVote(v.SourceEpoch, root)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func encodeRoot(root chainhash.Hash) string {
+	if root.IsEqual(&chainhash.Hash{}) {
+		return ""
+	}
+	return hex.EncodeToString(root[:])
+}
+
+func decodeRoot(s string) (chainhash.Hash, error) {
+	var root chainhash.Hash
+	if s == "" {
+		return root, nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != chainhash.HashSize {
+		return root, ErrorInterchangeRoot
+	}
+	copy(root[:], b)
+	return root, nil
+}
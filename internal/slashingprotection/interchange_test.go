@@ -0,0 +1,54 @@
+package slashingprotection_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Interchange(t *testing.T) {
+	genesis := chainhash.HashH([]byte("genesis"))
+
+	src, err := slashingprotection.Open(t.TempDir())
+	assert.NoError(t, err)
+	defer src.Close()
+
+	var pub [48]byte
+	pub[0] = 1
+
+	blockRoot := chainhash.HashH([]byte("block"))
+	assert.NoError(t, src.ProtectBlock(pub, 12, blockRoot))
+	assert.NoError(t, src.ProtectVote(pub, &primitives.VoteData{Slot: 20, FromEpoch: 2, ToEpoch: 4}))
+
+	interchange, err := src.Export(genesis, nil)
+	assert.NoError(t, err)
+	assert.Len(t, interchange.Data, 1)
+
+	b, err := json.Marshal(interchange)
+	assert.NoError(t, err)
+
+	decoded := new(slashingprotection.Interchange)
+	assert.NoError(t, json.Unmarshal(b, decoded))
+	assert.Equal(t, interchange, decoded)
+
+	dst, err := slashingprotection.Open(t.TempDir())
+	assert.NoError(t, err)
+	defer dst.Close()
+
+	assert.NoError(t, dst.ProtectBlock(pub, 8, chainhash.HashH([]byte("old"))))
+
+	assert.Equal(t, slashingprotection.ErrorInterchangeGenesis, dst.Import(chainhash.Hash{}, decoded))
+	assert.NoError(t, dst.Import(genesis, decoded))
+
+	assert.NoError(t, dst.ProtectBlock(pub, 12, blockRoot))
+	assert.Equal(t, slashingprotection.ErrorDoubleProposal, dst.ProtectBlock(pub, 11, chainhash.HashH([]byte("other"))))
+	assert.Equal(t, slashingprotection.ErrorSurroundVote, dst.ProtectVote(pub, &primitives.VoteData{Slot: 30, FromEpoch: 1, ToEpoch: 5}))
+
+	blocks, err := dst.SignedBlocks(pub)
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 2)
+}
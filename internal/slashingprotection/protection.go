@@ -0,0 +1,210 @@
+// Package slashingprotection keeps the history of the blocks and votes signed by the validator keys and
+// refuses to sign messages that could get the validators slashed.
+package slashingprotection
+
+import (
+	"encoding/binary"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// ErrorDoubleProposal returns when a block is signed for a slot lower or equal than an already signed block.
+	ErrorDoubleProposal = errors.New("slashing protection: block slot already signed")
+
+	// ErrorDoubleVote returns when a different vote was already signed for the same target epoch.
+	ErrorDoubleVote = errors.New("slashing protection: double vote")
+
+	// ErrorSurroundVote returns when a vote surrounds or is surrounded by an already signed vote.
+	ErrorSurroundVote = errors.New("slashing protection: surround vote")
+)
+
+// openTimeout is the time to wait for the database lock, it is held by a running node.
+const openTimeout = 5 * time.Second
+
+var (
+	blocksBucket = []byte("blocks")
+	votesBucket  = []byte("votes")
+)
+
+// DB is the signing history of the validator keys. Every key has a bucket with the signed blocks by slot
+// and the signed votes by target epoch.
+type DB struct {
+	db *bbolt.DB
+}
+
+// SignedBlock is a block signed by a validator key.
+type SignedBlock struct {
+	Slot uint64
+	Root chainhash.Hash
+}
+
+// SignedVote is a vote signed by a validator key.
+type SignedVote struct {
+	FromEpoch uint64
+	ToEpoch   uint64
+	Root      chainhash.Hash
+}
+
+// Open opens the signing history stored on the data folder.
+func Open(datapath string) (*DB, error) {
+	db, err := bbolt.Open(path.Join(datapath, "slashing_protection.db"), 0600, &bbolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+	return &DB{db: db}, nil
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// ProtectBlock checks a block can be signed by the key and records it. Signing the same block again is
+// allowed, any other block for a slot lower or equal than the highest signed slot is refused.
+func (d *DB) ProtectBlock(pub [48]byte, slot uint64, root chainhash.Hash) error {
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		bkt, err := keyBucket(tx, pub, blocksBucket)
+		if err != nil {
+			return err
+		}
+
+		k, v := bkt.Cursor().Last()
+		if k != nil {
+			last := SignedBlock{Slot: binary.BigEndian.Uint64(k)}
+			copy(last.Root[:], v)
+			if last.Slot == slot && last.Root == root {
+				return nil
+			}
+			if last.Slot >= slot {
+				return ErrorDoubleProposal
+			}
+		}
+
+		return bkt.Put(uint64Key(slot), root[:])
+	})
+}
+
+// ProtectVote checks a vote can be signed by the key and records it. A vote is refused when a different vote
+// was signed for the same target epoch or when it surrounds or is surrounded by a signed vote.
+func (d *DB) ProtectVote(pub [48]byte, data *primitives.VoteData) error {
+	root := data.Hash()
+
+	return d.db.Update(func(tx *bbolt.Tx) error {
+		bkt, err := keyBucket(tx, pub, votesBucket)
+		if err != nil {
+			return err
+		}
+
+		err = bkt.ForEach(func(k, v []byte) error {
+			signed := decodeVote(k, v)
+			if signed.ToEpoch == data.ToEpoch {
+				if signed.Root != root {
+					return ErrorDoubleVote
+				}
+				return nil
+			}
+
+			old := &primitives.VoteData{FromEpoch: signed.FromEpoch, ToEpoch: signed.ToEpoch}
+			if data.IsSurroundVote(old) || old.IsSurroundVote(data) {
+				return ErrorSurroundVote
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return bkt.Put(uint64Key(data.ToEpoch), encodeVote(data.FromEpoch, root))
+	})
+}
+
+// HasSignedBlock returns true if the key signed a block for the slot.
+func (d *DB) HasSignedBlock(pub [48]byte, slot uint64) (bool, error) {
+	found := false
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		bkt := readBucket(tx, pub, blocksBucket)
+		found = bkt != nil && bkt.Get(uint64Key(slot)) != nil
+		return nil
+	})
+	return found, err
+}
+
+// SignedBlocks returns the blocks signed by a key sorted by slot.
+func (d *DB) SignedBlocks(pub [48]byte) ([]SignedBlock, error) {
+	var blocks []SignedBlock
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		bkt := readBucket(tx, pub, blocksBucket)
+		if bkt == nil {
+			return nil
+		}
+		return bkt.ForEach(func(k, v []byte) error {
+			b := SignedBlock{Slot: binary.BigEndian.Uint64(k)}
+			copy(b.Root[:], v)
+			blocks = append(blocks, b)
+			return nil
+		})
+	})
+	return blocks, err
+}
+
+// SignedVotes returns the votes signed by a key sorted by target epoch.
+func (d *DB) SignedVotes(pub [48]byte) ([]SignedVote, error) {
+	var votes []SignedVote
+	err := d.db.View(func(tx *bbolt.Tx) error {
+		bkt := readBucket(tx, pub, votesBucket)
+		if bkt == nil {
+			return nil
+		}
+		return bkt.ForEach(func(k, v []byte) error {
+			votes = append(votes, decodeVote(k, v))
+			return nil
+		})
+	})
+	return votes, err
+}
+
+// keyBucket returns the bucket of a key for a type of message, creating it if needed.
+func keyBucket(tx *bbolt.Tx, pub [48]byte, name []byte) (*bbolt.Bucket, error) {
+	kb, err := tx.CreateBucketIfNotExists(pub[:])
+	if err != nil {
+		return nil, err
+	}
+	return kb.CreateBucketIfNotExists(name)
+}
+
+// readBucket returns the bucket of a key for a type of message or nil if nothing was signed.
+func readBucket(tx *bbolt.Tx, pub [48]byte, name []byte) *bbolt.Bucket {
+	kb := tx.Bucket(pub[:])
+	if kb == nil {
+		return nil
+	}
+	return kb.Bucket(name)
+}
+
+func uint64Key(n uint64) []byte {
+	var k [8]byte
+	binary.BigEndian.PutUint64(k[:], n)
+	return k[:]
+}
+
+func encodeVote(fromEpoch uint64, root chainhash.Hash) []byte {
+	v := make([]byte, 40)
+	binary.LittleEndian.PutUint64(v[:8], fromEpoch)
+	copy(v[8:], root[:])
+	return v
+}
+
+func decodeVote(k, v []byte) SignedVote {
+	vote := SignedVote{
+		ToEpoch:   binary.BigEndian.Uint64(k),
+		FromEpoch: binary.LittleEndian.Uint64(v[:8]),
+	}
+	copy(vote.Root[:], v[8:])
+	return vote
+}
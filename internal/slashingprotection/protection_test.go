@@ -0,0 +1,65 @@
+package slashingprotection_test
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ProtectBlock(t *testing.T) {
+	db, err := slashingprotection.Open(t.TempDir())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var pub [48]byte
+	pub[0] = 1
+
+	rootA := chainhash.HashH([]byte("a"))
+	rootB := chainhash.HashH([]byte("b"))
+
+	assert.NoError(t, db.ProtectBlock(pub, 10, rootA))
+	assert.NoError(t, db.ProtectBlock(pub, 10, rootA))
+	assert.Equal(t, slashingprotection.ErrorDoubleProposal, db.ProtectBlock(pub, 10, rootB))
+	assert.Equal(t, slashingprotection.ErrorDoubleProposal, db.ProtectBlock(pub, 9, rootB))
+	assert.NoError(t, db.ProtectBlock(pub, 11, rootB))
+
+	var other [48]byte
+	assert.NoError(t, db.ProtectBlock(other, 5, rootA))
+
+	blocks, err := db.SignedBlocks(pub)
+	assert.NoError(t, err)
+	assert.Equal(t, []slashingprotection.SignedBlock{{Slot: 10, Root: rootA}, {Slot: 11, Root: rootB}}, blocks)
+}
+
+func Test_ProtectVote(t *testing.T) {
+	db, err := slashingprotection.Open(t.TempDir())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var pub [48]byte
+
+	vote := &primitives.VoteData{Slot: 20, FromEpoch: 2, ToEpoch: 4}
+	assert.NoError(t, db.ProtectVote(pub, vote))
+	assert.NoError(t, db.ProtectVote(pub, vote))
+
+	double := &primitives.VoteData{Slot: 21, FromEpoch: 2, ToEpoch: 4}
+	assert.Equal(t, slashingprotection.ErrorDoubleVote, db.ProtectVote(pub, double))
+
+	surrounding := &primitives.VoteData{Slot: 30, FromEpoch: 1, ToEpoch: 5}
+	assert.Equal(t, slashingprotection.ErrorSurroundVote, db.ProtectVote(pub, surrounding))
+
+	surrounded := &primitives.VoteData{Slot: 16, FromEpoch: 3, ToEpoch: 3}
+	assert.Equal(t, slashingprotection.ErrorSurroundVote, db.ProtectVote(pub, surrounded))
+
+	next := &primitives.VoteData{Slot: 25, FromEpoch: 4, ToEpoch: 5}
+	assert.NoError(t, db.ProtectVote(pub, next))
+
+	votes, err := db.SignedVotes(pub)
+	assert.NoError(t, err)
+	assert.Len(t, votes, 2)
+	assert.Equal(t, uint64(4), votes[0].ToEpoch)
+	assert.Equal(t, uint64(5), votes[1].ToEpoch)
+}
@@ -2,19 +2,18 @@ package state
 
 import (
 	"bytes"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/olympus-protocol/ogen/cmd/ogen/config"
 	"github.com/olympus-protocol/ogen/pkg/bitfield"
 	"github.com/olympus-protocol/ogen/pkg/bls"
 	"github.com/olympus-protocol/ogen/pkg/bls/common"
-	"github.com/olympus-protocol/ogen/pkg/chainhash"
 	"github.com/olympus-protocol/ogen/pkg/primitives"
 )
 
 // ApplyMultiTransactionSingle applies multiple single Tx to the state
 func (s *state) ApplyMultiTransactionSingle(txs []*primitives.Tx, blockWithdrawalAddress [20]byte) error {
+	netParams := config.GlobalParams.NetParams
 
 	u := s.CoinsState
 
@@ -49,13 +48,23 @@ func (s *state) ApplyMultiTransactionSingle(txs []*primitives.Tx, blockWithdrawa
 		}
 
 		txsSigs[i] = sig
-		txsMsgs[i] = tx.SignatureMessage()
+		txsMsgs[i] = tx.SigningMessage(netParams, s.Slot)
 		txsPubs[i] = pub
 	}
 
 	sig := bls.AggregateSignatures(txsSigs)
 
 	valid := sig.AggregateVerify(txsPubs, txsMsgs)
+	if !valid && netParams.InDomainGrace(s.Slot) {
+		// Some transactions may be signed over the message used before the domain fork.
+		valid = true
+		for _, tx := range txs {
+			if tx.VerifySig(netParams, s.Slot) != nil {
+				valid = false
+				break
+			}
+		}
+	}
 	if !valid {
 		return errors.New("invalid txs signatures")
 	}
@@ -89,7 +98,7 @@ func (s *state) ApplyTransactionSingle(tx *primitives.Tx, blockWithdrawalAddress
 		return fmt.Errorf("nonce is too small (already processed: %d, trying: %d)", u.Nonces[pkh], tx.Nonce)
 	}
 
-	if err := tx.VerifySig(); err != nil {
+	if err := tx.VerifySig(config.GlobalParams.NetParams, s.Slot); err != nil {
 		return err
 	}
 
@@ -103,6 +112,7 @@ func (s *state) ApplyTransactionSingle(tx *primitives.Tx, blockWithdrawalAddress
 
 // IsProposerSlashingValid checks if a given proposer slashing is valid.
 func (s *state) IsProposerSlashingValid(ps *primitives.ProposerSlashing) (uint64, error) {
+	netParams := config.GlobalParams.NetParams
 
 	h1 := ps.BlockHeader1.Hash()
 	h2 := ps.BlockHeader2.Hash()
@@ -126,11 +136,14 @@ func (s *state) IsProposerSlashingValid(ps *primitives.ProposerSlashing) (uint64
 	if err != nil {
 		return 0, err
 	}
-	if !s1.Verify(pub, h1[:]) {
+	m1 := ps.BlockHeader1.SigningMessage(netParams)
+	m2 := ps.BlockHeader2.SigningMessage(netParams)
+
+	if !s1.Verify(pub, m1[:]) {
 		return 0, fmt.Errorf("proposer-slashing: signature does not validate for block header 1")
 	}
 
-	if !s2.Verify(pub, h2[:]) {
+	if !s2.Verify(pub, m2[:]) {
 		return 0, fmt.Errorf("proposer-slashing: signature does not validate for block header 2")
 	}
 
@@ -162,6 +175,7 @@ func (s *state) ApplyProposerSlashing(ps *primitives.ProposerSlashing) error {
 
 // IsVoteSlashingValid checks if the vote slashing is valid.
 func (s *state) IsVoteSlashingValid(vs *primitives.VoteSlashing) ([]uint64, error) {
+	netParams := config.GlobalParams.NetParams
 
 	if vs.Vote1.Data.Equals(vs.Vote2.Data) {
 		return nil, fmt.Errorf("vote-slashing: votes are not distinct")
@@ -222,7 +236,7 @@ func (s *state) IsVoteSlashingValid(vs *primitives.VoteSlashing) ([]uint64, erro
 	if err != nil {
 		return nil, err
 	}
-	if !v1Sig.FastAggregateVerify(aggPubs1, vs.Vote1.Data.Hash()) {
+	if !v1Sig.FastAggregateVerify(aggPubs1, vs.Vote1.Data.SigningMessage(netParams)) {
 		return nil, fmt.Errorf("vote-slashing: vote 1 does not validate")
 	}
 
@@ -234,7 +248,7 @@ func (s *state) IsVoteSlashingValid(vs *primitives.VoteSlashing) ([]uint64, erro
 	if err != nil {
 		return nil, err
 	}
-	if !v2Sig.FastAggregateVerify(aggPubs2, vs.Vote2.Data.Hash()) {
+	if !v2Sig.FastAggregateVerify(aggPubs2, vs.Vote2.Data.SigningMessage(netParams)) {
 		return nil, fmt.Errorf("vote-slashing: vote 2 does not validate")
 	}
 
@@ -264,7 +278,7 @@ func (s *state) IsRANDAOSlashingValid(rs *primitives.RANDAOSlashing) (uint64, er
 		return 0, fmt.Errorf("randao-slashing: RANDAO was already assumed to be revealed")
 	}
 
-	slotHash := chainhash.HashH([]byte(fmt.Sprintf("%d", rs.Slot)))
+	slotHash := primitives.RANDAOMessage(config.GlobalParams.NetParams, rs.Slot)
 	pub, err := rs.GetValidatorPubkey()
 	if err != nil {
 		return 0, err
@@ -328,19 +342,14 @@ func (s *state) GetVoteCommittee(slot uint64) ([]uint64, error) {
 
 // IsExitValid checks if an exit is valid.
 func (s *state) IsExitValid(exit *primitives.Exit) error {
-	msgHash := chainhash.HashH(exit.ValidatorPubkey[:])
-	wPubKey, err := exit.GetWithdrawPubKey()
-	if err != nil {
+	if err := exit.VerifySig(config.GlobalParams.NetParams, s.Slot); err != nil {
 		return err
 	}
-	sig, err := exit.GetSignature()
+
+	wPubKey, err := exit.GetWithdrawPubKey()
 	if err != nil {
 		return err
 	}
-	valid := sig.Verify(wPubKey, msgHash[:])
-	if !valid {
-		return fmt.Errorf("exit signature is not valid")
-	}
 
 	pkh, err := wPubKey.Hash()
 	if err != nil {
@@ -374,22 +383,15 @@ func (s *state) IsPartialExitValid(p *primitives.PartialExit) error {
 		return errors.New("partial exit tries to unlock a very little amount of coins")
 	}
 
-	msgHash := chainhash.HashH(p.ValidatorPubkey[:])
-	wPubKey, err := p.GetWithdrawPubKey()
-	if err != nil {
+	if err := p.VerifySig(params, s.Slot); err != nil {
 		return err
 	}
 
-	sig, err := p.GetSignature()
+	wPubKey, err := p.GetWithdrawPubKey()
 	if err != nil {
 		return err
 	}
 
-	valid := sig.Verify(wPubKey, msgHash[:])
-	if !valid {
-		return fmt.Errorf("exit signature is not valid")
-	}
-
 	pkh, err := wPubKey.Hash()
 	if err != nil {
 		return err
@@ -489,13 +491,11 @@ func (s *state) AreDepositsValid(deposits []*primitives.Deposit) error {
 			}
 		}
 
-		buf, err := d.Data.Marshal()
+		msgs[i], err = d.SigningMessage(netParams, s.Slot)
 		if err != nil {
 			return err
 		}
-
-		msgs[i] = chainhash.HashH(buf)
-		pMsgs[i] = chainhash.HashH(d.Data.PublicKey[:])
+		pMsgs[i] = d.Data.ProofOfPossessionMessage(netParams, s.Slot)
 
 		proofPub, err := d.Data.GetPublicKey()
 		if err != nil {
@@ -530,11 +530,24 @@ func (s *state) AreDepositsValid(deposits []*primitives.Deposit) error {
 	pSig := bls.AggregateSignatures(pSigs)
 
 	valid1 := sig.AggregateVerify(pubs, msgs)
+	valid2 := pSig.AggregateVerify(pPubs, pMsgs)
+	if (!valid1 || !valid2) && netParams.InDomainGrace(s.Slot) {
+		// Some deposits may be signed over the messages used before the domain fork.
+		valid1, valid2 = true, true
+		for _, d := range deposits {
+			if d.VerifySig(netParams, s.Slot) != nil {
+				valid1 = false
+			}
+			if d.Data.VerifySig(netParams, s.Slot) != nil {
+				valid2 = false
+			}
+		}
+	}
+
 	if !valid1 {
 		return errors.New("deposit signatures don't verify")
 	}
 
-	valid2 := pSig.AggregateVerify(pPubs, pMsgs)
 	if !valid2 {
 		return errors.New("proof-of-possession signatures don't verify")
 	}
@@ -560,22 +573,10 @@ func (s *state) IsDepositValid(deposit *primitives.Deposit) error {
 		return fmt.Errorf("balance is too low for deposit (got: %d, expected at least: %d)", s.CoinsState.Balances[pkh], netParams.DepositAmount*netParams.UnitsPerCoin)
 	}
 
-	buf, err := deposit.Data.Marshal()
-	if err != nil {
+	if err := deposit.VerifySig(netParams, s.Slot); err != nil {
 		return err
 	}
 
-	depositHash := chainhash.HashH(buf)
-	dSig, err := deposit.GetSignature()
-	if err != nil {
-		return err
-	}
-
-	valid := dSig.Verify(dPub, depositHash[:])
-	if !valid {
-		return errors.New("deposit signature is not valid")
-	}
-
 	validatorPubkey := deposit.Data.PublicKey
 
 	// now, ensure we don't already have this validator
@@ -585,24 +586,7 @@ func (s *state) IsDepositValid(deposit *primitives.Deposit) error {
 		}
 	}
 
-	pubkeyHash := chainhash.HashH(validatorPubkey[:])
-
-	dataSig, err := deposit.Data.GetSignature()
-	if err != nil {
-		return err
-	}
-
-	dataPub, err := deposit.Data.GetPublicKey()
-	if err != nil {
-		return err
-	}
-
-	valid = dataSig.Verify(dataPub, pubkeyHash[:])
-	if !valid {
-		return errors.New("proof-of-possession is not valid")
-	}
-
-	return nil
+	return deposit.Data.VerifySig(netParams, s.Slot)
 }
 
 // ApplyMultiDeposit applies multiple deposits to the state
@@ -724,7 +708,7 @@ func (s *state) IsVoteValid(v *primitives.MultiValidatorVote) error {
 		aggPubs = append(aggPubs, pub)
 	}
 
-	h := v.Data.Hash()
+	h := v.Data.SigningMessage(config.GlobalParams.NetParams)
 	vSig, err := v.Signature()
 	if err != nil {
 		return err
@@ -796,10 +780,47 @@ func (s *state) GetProposerPublicKey(b *primitives.Block) (common.PublicKey, err
 	return bls.PublicKeyFromBytes(proposer.PubKey[:])
 }
 
+// ErrorProposerUnknown returns when the proposer of a slot can't be determined from the state.
+var ErrorProposerUnknown = errors.New("proposer for slot not known by the state")
+
+// GetProposerPublicKeyAtSlot gets the public key of the proposer scheduled for a slot. Only the proposers
+// of the current and the next epoch are known.
+func (s *state) GetProposerPublicKeyAtSlot(slot uint64) (common.PublicKey, error) {
+	netParams := config.GlobalParams.NetParams
+
+	if slot == 0 {
+		return nil, ErrorProposerUnknown
+	}
+
+	slotIndex := (slot + netParams.EpochLength - 1) % netParams.EpochLength
+
+	var queue []uint64
+	switch (slot - 1) / netParams.EpochLength {
+	case s.EpochIndex:
+		queue = s.ProposerQueue
+	case s.EpochIndex + 1:
+		queue = s.NextProposerQueue
+	default:
+		return nil, ErrorProposerUnknown
+	}
+
+	if slotIndex >= uint64(len(queue)) {
+		return nil, ErrorProposerUnknown
+	}
+
+	proposerIndex := queue[slotIndex]
+	if proposerIndex >= uint64(len(s.ValidatorRegistry)) {
+		return nil, ErrorProposerUnknown
+	}
+
+	return bls.PublicKeyFromBytes(s.ValidatorRegistry[proposerIndex].PubKey[:])
+}
+
 // CheckBlockSignature checks the block signature.
 func (s *state) CheckBlockSignature(b *primitives.Block) error {
+	netParams := config.GlobalParams.NetParams
 
-	blockHash := b.Hash()
+	blockHash := b.Header.SigningMessage(netParams)
 	blockSig, err := bls.SignatureFromBytes(b.Signature[:])
 	if err != nil {
 		return err
@@ -820,7 +841,7 @@ func (s *state) CheckBlockSignature(b *primitives.Block) error {
 		return errors.New("error validating signature for block")
 	}
 
-	slotHash := chainhash.HashH([]byte(fmt.Sprintf("%d", b.Header.Slot)))
+	slotHash := primitives.RANDAOMessage(netParams, b.Header.Slot)
 
 	valid = randaoSig.Verify(validatorPub, slotHash[:])
 	if !valid {
@@ -832,55 +853,32 @@ func (s *state) CheckBlockSignature(b *primitives.Block) error {
 
 // ProcessBlock runs a block transition on the state and mutates state.
 func (s *state) ProcessBlock(b *primitives.Block) error {
+	return s.processBlock(b, true)
+}
+
+// ProcessTrustedBlock runs a block transition on the state without checking the
+// proposer and RANDAO signatures. The signatures of the votes, transactions, deposits,
+// exits and slashings are still verified. It should only be used for blocks from a trusted
+// source or whose proposer signatures were already checked.
+func (s *state) ProcessTrustedBlock(b *primitives.Block) error {
+	return s.processBlock(b, false)
+}
+
+func (s *state) processBlock(b *primitives.Block, checkSignature bool) error {
 	netParams := config.GlobalParams.NetParams
 
 	if b.Header.Slot != s.Slot {
 		return fmt.Errorf("state is not updated to slot %d, instead got %d", b.Header.Slot, s.Slot)
 	}
 
-	if err := s.CheckBlockSignature(b); err != nil {
-		return err
-	}
-
-	voteMerkleRoot := b.VotesMerkleRoot()
-	depositMerkleRoot := b.DepositMerkleRoot()
-	exitMerkleRoot := b.ExitMerkleRoot()
-	partialExitsMerkleRoot := b.PartialExitsMerkleRoot()
-	txsMerkleRoot := b.TxsMerkleRoot()
-	voteSlashingMerkleRoot := b.VoteSlashingRoot()
-	proposerSlashingMerkleRoot := b.ProposerSlashingsRoot()
-	randaoSlashingMerkleRoot := b.RANDAOSlashingsRoot()
-
-	if !bytes.Equal(depositMerkleRoot[:], b.Header.DepositMerkleRoot[:]) {
-		return fmt.Errorf("expected deposit merkle root to be %s but got %s", hex.EncodeToString(depositMerkleRoot[:]), hex.EncodeToString(b.Header.DepositMerkleRoot[:]))
-	}
-
-	if !bytes.Equal(exitMerkleRoot[:], b.Header.ExitMerkleRoot[:]) {
-		return fmt.Errorf("expected exit merkle root to be %s but got %s", hex.EncodeToString(exitMerkleRoot[:]), hex.EncodeToString(b.Header.ExitMerkleRoot[:]))
-	}
-
-	if !bytes.Equal(voteMerkleRoot[:], b.Header.VoteMerkleRoot[:]) {
-		return fmt.Errorf("expected vote merkle root to be %s but got %s", hex.EncodeToString(voteMerkleRoot[:]), hex.EncodeToString(b.Header.VoteMerkleRoot[:]))
-	}
-
-	if !bytes.Equal(partialExitsMerkleRoot[:], b.Header.PartialExitMerkleRoot[:]) {
-		return fmt.Errorf("expected partial exits merkle root to be %s but got %s", hex.EncodeToString(partialExitsMerkleRoot[:]), hex.EncodeToString(b.Header.PartialExitMerkleRoot[:]))
-	}
-
-	if !bytes.Equal(txsMerkleRoot[:], b.Header.TxsMerkleRoot[:]) {
-		return fmt.Errorf("expected transaction merkle root to be %s but got %s", hex.EncodeToString(txsMerkleRoot[:]), hex.EncodeToString(b.Header.TxsMerkleRoot[:]))
-	}
-
-	if !bytes.Equal(voteSlashingMerkleRoot[:], b.Header.VoteSlashingMerkleRoot[:]) {
-		return fmt.Errorf("expected vote slashing merkle root to be %s but got %s", hex.EncodeToString(voteSlashingMerkleRoot[:]), hex.EncodeToString(b.Header.VoteSlashingMerkleRoot[:]))
-	}
-
-	if !bytes.Equal(proposerSlashingMerkleRoot[:], b.Header.ProposerSlashingMerkleRoot[:]) {
-		return fmt.Errorf("expected proposer slashing merkle root to be %s but got %s", hex.EncodeToString(proposerSlashingMerkleRoot[:]), hex.EncodeToString(b.Header.ProposerSlashingMerkleRoot[:]))
+	if checkSignature {
+		if err := s.CheckBlockSignature(b); err != nil {
+			return err
+		}
 	}
 
-	if !bytes.Equal(randaoSlashingMerkleRoot[:], b.Header.RANDAOSlashingMerkleRoot[:]) {
-		return fmt.Errorf("expected randao slashing merkle root to be %s but got %s", hex.EncodeToString(randaoSlashingMerkleRoot[:]), hex.EncodeToString(b.Header.RANDAOSlashingMerkleRoot[:]))
+	if err := b.CheckMerkleRoots(); err != nil {
+		return err
 	}
 
 	if uint64(len(b.Votes)) > primitives.MaxVotesPerBlock {
@@ -270,6 +270,7 @@ func (s *state) ProcessEpochTransition() ([]*primitives.EpochReceipt, error) {
 			Validator: index,
 			Amount:    int64(reward),
 			Type:      why,
+			Epoch:     s.EpochIndex,
 		})
 	}
 
@@ -282,6 +283,7 @@ func (s *state) ProcessEpochTransition() ([]*primitives.EpochReceipt, error) {
 			Validator: index,
 			Amount:    -int64(penalty),
 			Type:      why,
+			Epoch:     s.EpochIndex,
 		})
 	}
 
@@ -10,10 +10,12 @@ type State interface {
 	ProcessSlot(previousBlockRoot chainhash.Hash)
 	ProcessSlots(requestedSlot uint64, view BlockView) ([]*primitives.EpochReceipt, error)
 	ProcessBlock(b *primitives.Block) error
+	ProcessTrustedBlock(b *primitives.Block) error
 	ProcessVote(v *primitives.MultiValidatorVote, proposerIndex uint64) error
 	ProcessEpochTransition() ([]*primitives.EpochReceipt, error)
 
 	CheckBlockSignature(b *primitives.Block) error
+	GetProposerPublicKeyAtSlot(slot uint64) (common.PublicKey, error)
 	IsProposerSlashingValid(ps *primitives.ProposerSlashing) (uint64, error)
 	IsVoteSlashingValid(vs *primitives.VoteSlashing) ([]uint64, error)
 	IsRANDAOSlashingValid(rs *primitives.RANDAOSlashing) (uint64, error)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/olympus-protocol/ogen/cmd/ogen/initialization"
+	"github.com/olympus-protocol/ogen/pkg/bitfield"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
 	"github.com/olympus-protocol/ogen/pkg/params"
 	"github.com/olympus-protocol/ogen/pkg/primitives"
@@ -111,6 +112,8 @@ func (s *state) ToSerializable() *primitives.SerializableState {
 		PreviousJustifiedEpoch:        s.PreviousJustifiedEpoch,
 		PreviousJustifiedEpochHash:    s.PreviousJustifiedEpochHash,
 		PreviousEpochVotes:            s.PreviousEpochVotes,
+		// The governance is not part of the state yet, an empty bitlist still needs its length bit to be decoded.
+		ManagerReplacement: bitfield.NewBitlist(0),
 	}
 	return ser
 }
@@ -349,7 +352,8 @@ func GetGenesisStateWithInitializationParameters(genesisHash chainhash.Hash, ip
 		}
 	}
 
-	_, premineAddr, err := bech32.Decode(ip.PremineAddress)
+	var premineAddr []byte
+	var err error
 	if err != nil {
 		return nil, err
 	}
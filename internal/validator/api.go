@@ -0,0 +1,143 @@
+// Package validator runs the validator duties of the keys of a signer against the RPC API of one or more nodes.
+package validator
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// The validator API is served by the nodes on the "validator" RPC namespace. The namespace is not public, the
+// nodes only serve it when it is listed on the RPC modules. Byte values and primitives are hex encoded without
+// prefix, the primitives are SSZ encoded.
+//
+//   validator_chainInfo               returns the ChainInfo of the node.
+//   validator_duties(slot)            returns the Duties of a slot on top of the tip.
+//   validator_voteData(slot)          returns the vote data to sign for a slot on top of the tip.
+//   validator_blockTemplate(slot)     returns the BlockTemplate of a slot on top of the tip.
+//   validator_submitBlock(block)      processes and broadcasts a signed block, returns its hash.
+//   validator_submitVote(vote)        adds a signed vote to the mempool and broadcasts it.
+//   validator_voters(slot)            returns the validators with votes after a slot included on the chain.
+//   validator_checkDeposit(deposit)   validates a signed deposit against the tip state.
+//   validator_submitDeposit(deposit)  adds a signed deposit to the mempool and broadcasts it.
+//   validator_checkExit(exit)         validates a signed exit against the tip state.
+//   validator_submitExit(exit)        adds a signed exit to the mempool and broadcasts it.
+//   validator_checkPartialExit(exit)  validates a signed partial exit against the tip state.
+//   validator_submitPartialExit(exit) adds a signed partial exit to the mempool and broadcasts it.
+
+// ErrorCodeNotSynced is the RPC error code returned when the node is not synced. The clients fall back to
+// another node when they get it.
+const ErrorCodeNotSynced = -32001
+
+// ErrorNotSynced returns when the node is not synced and it can't serve the validator duties.
+var ErrorNotSynced = &rpcError{code: ErrorCodeNotSynced, msg: "node is not synced"}
+
+type rpcError struct {
+	code int
+	msg  string
+}
+
+func (e *rpcError) Error() string {
+	return e.msg
+}
+
+// ErrorCode implements the RPC error interface.
+func (e *rpcError) ErrorCode() int {
+	return e.code
+}
+
+// ChainInfo describes the chain followed by a node.
+type ChainInfo struct {
+	Network     string `json:"network"`
+	GenesisHash string `json:"genesis_hash"`
+	GenesisTime int64  `json:"genesis_time"`
+	ForkVersion uint32 `json:"fork_version"`
+	TipSlot     uint64 `json:"tip_slot"`
+	Synced      bool   `json:"synced"`
+}
+
+// Duties are the validators proposing and voting on a slot.
+type Duties struct {
+	Slot uint64 `json:"slot"`
+	// Proposer is the public key of the proposer of the slot.
+	Proposer string `json:"proposer"`
+	// Committee are the public keys of the validators voting on the slot in the order of the participation bitfield.
+	Committee []string `json:"committee"`
+}
+
+// BlockTemplate is the unsigned block of a slot built by a node. External builders can sign it with the key of
+// the proposer and submit it to any node.
+type BlockTemplate struct {
+	// Block is the unsigned block with the merkle roots already computed.
+	Block string `json:"block"`
+	// Proposer is the public key of the validator that must sign the block.
+	Proposer string `json:"proposer"`
+	// Fees is the sum of the fees of the block transactions.
+	Fees uint64 `json:"fees"`
+	// PostState describes the state after processing the block.
+	PostState PostState `json:"post_state"`
+}
+
+// PostState describes the state resulting from a block template.
+type PostState struct {
+	Slot           uint64 `json:"slot"`
+	Epoch          uint64 `json:"epoch"`
+	JustifiedEpoch uint64 `json:"justified_epoch"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	TotalBalance   uint64 `json:"total_balance"`
+	Validators     int    `json:"validators"`
+	Active         int64  `json:"active"`
+	Starting       int64  `json:"starting"`
+	PendingExit    int64  `json:"pending_exit"`
+	Exited         int64  `json:"exited"`
+}
+
+// EncodePubKey encodes a public key for the API.
+func EncodePubKey(pub [48]byte) string {
+	return hex.EncodeToString(pub[:])
+}
+
+// DecodePubKey decodes a public key of the API.
+func DecodePubKey(s string) ([48]byte, error) {
+	var pub [48]byte
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return pub, err
+	}
+	if len(b) != 48 {
+		return pub, fmt.Errorf("invalid public key length %d", len(b))
+	}
+	copy(pub[:], b)
+	return pub, nil
+}
+
+// marshaler is a primitive with SSZ encoding.
+type marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+// unmarshaler is a primitive with SSZ decoding.
+type unmarshaler interface {
+	Unmarshal(b []byte) error
+}
+
+// Encode encodes a primitive for the API.
+func Encode(m marshaler) (string, error) {
+	b, err := m.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Decode decodes a primitive of the API.
+func Decode(s string, u unmarshaler) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 {
+		return errors.New("empty value")
+	}
+	return u.Unmarshal(b)
+}
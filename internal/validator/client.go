@@ -0,0 +1,255 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/params"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// callTimeout is the maximum time to wait for a node response.
+const callTimeout = 5 * time.Second
+
+// ErrorNoNodes returns when none of the nodes answered a call.
+var ErrorNoNodes = errors.New("no node available")
+
+// Client calls the validator API of a list of nodes. The calls go to the first node answering them, when a
+// node is unreachable or not synced the next one is used.
+type Client struct {
+	log  logger.Logger
+	urls []string
+
+	lock    sync.Mutex
+	clients []*rpc.Client
+	current int
+}
+
+// NewClient returns a client for the nodes listening on the urls, in order of preference.
+func NewClient(log logger.Logger, urls []string) *Client {
+	return &Client{
+		log:     log,
+		urls:    urls,
+		clients: make([]*rpc.Client, len(urls)),
+	}
+}
+
+// Close closes the connections to the nodes.
+func (c *Client) Close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, cl := range c.clients {
+		if cl != nil {
+			cl.Close()
+			c.clients[i] = nil
+		}
+	}
+}
+
+// call runs the method on the current node and falls back to the next nodes when it fails. Errors returned
+// by a synced node are returned without trying the other nodes.
+func (c *Client) call(result interface{}, method string, args ...interface{}) error {
+	c.lock.Lock()
+	current := c.current
+	c.lock.Unlock()
+
+	for tries := 0; tries < len(c.urls); tries++ {
+		i := (current + tries) % len(c.urls)
+
+		err := c.callNode(i, result, method, args...)
+		if err == nil {
+			c.lock.Lock()
+			if i != c.current {
+				c.log.Infof("using node %s", c.urls[i])
+				c.current = i
+			}
+			c.lock.Unlock()
+			return nil
+		}
+
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() != ErrorCodeNotSynced {
+			return err
+		}
+
+		c.log.Warnf("node %s failed: %s", c.urls[i], err)
+	}
+
+	return ErrorNoNodes
+}
+
+func (c *Client) callNode(i int, result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+	defer cancel()
+
+	cl, err := c.node(ctx, i)
+	if err != nil {
+		return err
+	}
+
+	return cl.CallContext(ctx, result, method, args...)
+}
+
+// node returns the connection to a node, connecting to it if needed.
+func (c *Client) node(ctx context.Context, i int) (*rpc.Client, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.clients[i] == nil {
+		cl, err := rpc.DialContext(ctx, c.urls[i])
+		if err != nil {
+			return nil, err
+		}
+		c.clients[i] = cl
+	}
+	return c.clients[i], nil
+}
+
+// ChainInfo returns the chain followed by the nodes.
+func (c *Client) ChainInfo() (*ChainInfo, error) {
+	info := new(ChainInfo)
+	if err := c.call(info, "validator_chainInfo"); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// UseChain checks the nodes follow the network of the params and sets the genesis hash of the params to the one of
+// the chain followed by the nodes, the signing domains commit to it.
+func (c *Client) UseChain(p *params.ChainParams) (*ChainInfo, error) {
+	info, err := c.ChainInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Network != p.Name || info.ForkVersion != p.ForkVersion {
+		return nil, fmt.Errorf("node follows network %s with fork version %d, expected %s with fork version %d", info.Network, info.ForkVersion, p.Name, p.ForkVersion)
+	}
+
+	genesisHash, err := chainhash.NewHashFromStr(info.GenesisHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node genesis hash: %s", err)
+	}
+	p.GenesisHash = genesisHash
+
+	return info, nil
+}
+
+// Duties returns the validators proposing and voting on a slot.
+func (c *Client) Duties(slot uint64) (*Duties, error) {
+	duties := new(Duties)
+	if err := c.call(duties, "validator_duties", slot); err != nil {
+		return nil, err
+	}
+	return duties, nil
+}
+
+// VoteData returns the vote data to sign for a slot.
+func (c *Client) VoteData(slot uint64) (*primitives.VoteData, error) {
+	var s string
+	if err := c.call(&s, "validator_voteData", slot); err != nil {
+		return nil, err
+	}
+	data := new(primitives.VoteData)
+	if err := Decode(s, data); err != nil {
+		return nil, fmt.Errorf("invalid vote data: %s", err)
+	}
+	return data, nil
+}
+
+// BlockTemplate returns the unsigned block of a slot and the state after processing it.
+func (c *Client) BlockTemplate(slot uint64) (*primitives.Block, *BlockTemplate, error) {
+	template := new(BlockTemplate)
+	if err := c.call(template, "validator_blockTemplate", slot); err != nil {
+		return nil, nil, err
+	}
+	block := new(primitives.Block)
+	if err := Decode(template.Block, block); err != nil {
+		return nil, nil, fmt.Errorf("invalid block template: %s", err)
+	}
+	return block, template, nil
+}
+
+// SubmitBlock submits a signed block to the nodes.
+func (c *Client) SubmitBlock(block *primitives.Block) error {
+	s, err := Encode(block)
+	if err != nil {
+		return err
+	}
+	var hash string
+	if err := c.call(&hash, "validator_submitBlock", s); err != nil {
+		return err
+	}
+	if hash != block.Hash().String() {
+		return fmt.Errorf("node processed block %s instead of %s", hash, block.Hash())
+	}
+	return nil
+}
+
+// SubmitVote submits a signed vote to the nodes.
+func (c *Client) SubmitVote(vote *primitives.MultiValidatorVote) error {
+	return c.callEncoded("validator_submitVote", vote)
+}
+
+// Voters returns the validators with votes for slots after fromSlot included on the chain of the nodes.
+func (c *Client) Voters(fromSlot uint64) (map[[48]byte]uint64, error) {
+	var encoded map[string]uint64
+	if err := c.call(&encoded, "validator_voters", fromSlot); err != nil {
+		return nil, err
+	}
+	voters := make(map[[48]byte]uint64, len(encoded))
+	for s, index := range encoded {
+		pub, err := DecodePubKey(s)
+		if err != nil {
+			return nil, err
+		}
+		voters[pub] = index
+	}
+	return voters, nil
+}
+
+// CheckDeposit validates a signed deposit against the tip state of the nodes.
+func (c *Client) CheckDeposit(deposit *primitives.Deposit) error {
+	return c.callEncoded("validator_checkDeposit", deposit)
+}
+
+// SubmitDeposit submits a signed deposit to the nodes.
+func (c *Client) SubmitDeposit(deposit *primitives.Deposit) error {
+	return c.callEncoded("validator_submitDeposit", deposit)
+}
+
+// CheckExit validates a signed exit against the tip state of the nodes.
+func (c *Client) CheckExit(exit *primitives.Exit) error {
+	return c.callEncoded("validator_checkExit", exit)
+}
+
+// SubmitExit submits a signed exit to the nodes.
+func (c *Client) SubmitExit(exit *primitives.Exit) error {
+	return c.callEncoded("validator_submitExit", exit)
+}
+
+// CheckPartialExit validates a signed partial exit against the tip state of the nodes.
+func (c *Client) CheckPartialExit(partialExit *primitives.PartialExit) error {
+	return c.callEncoded("validator_checkPartialExit", partialExit)
+}
+
+// SubmitPartialExit submits a signed partial exit to the nodes.
+func (c *Client) SubmitPartialExit(partialExit *primitives.PartialExit) error {
+	return c.callEncoded("validator_submitPartialExit", partialExit)
+}
+
+// callEncoded calls a method without result taking an encoded primitive.
+func (c *Client) callEncoded(method string, m marshaler) error {
+	s, err := Encode(m)
+	if err != nil {
+		return err
+	}
+	return c.call(nil, method, s)
+}
@@ -0,0 +1,85 @@
+package validator_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/olympus-protocol/ogen/internal/validator"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAPI struct {
+	name   string
+	synced bool
+}
+
+func (api *testAPI) ChainInfo() (*validator.ChainInfo, error) {
+	if !api.synced {
+		return nil, validator.ErrorNotSynced
+	}
+	return &validator.ChainInfo{Network: api.name, Synced: true}, nil
+}
+
+func (api *testAPI) VoteData(slot uint64) (string, error) {
+	if slot == 0 {
+		return "", errors.New("there are no votes on the genesis slot")
+	}
+	return validator.Encode(&primitives.VoteData{Slot: slot})
+}
+
+func (api *testAPI) Voters(fromSlot uint64) (map[string]uint64, error) {
+	return map[string]uint64{validator.EncodePubKey([48]byte{byte(fromSlot)}): fromSlot}, nil
+}
+
+func newTestNode(t *testing.T, api *testAPI) *httptest.Server {
+	srv := rpc.NewServer()
+	assert.NoError(t, srv.RegisterName("validator", api))
+	return httptest.NewServer(srv)
+}
+
+func Test_ClientFailover(t *testing.T) {
+	down := newTestNode(t, &testAPI{name: "down", synced: true})
+	down.Close()
+
+	notSynced := newTestNode(t, &testAPI{name: "not synced"})
+	defer notSynced.Close()
+
+	synced := newTestNode(t, &testAPI{name: "synced", synced: true})
+	defer synced.Close()
+
+	client := validator.NewClient(logger.New(os.Stdout), []string{down.URL, notSynced.URL, synced.URL})
+	defer client.Close()
+
+	info, err := client.ChainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "synced", info.Network)
+
+	data, err := client.VoteData(10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), data.Slot)
+
+	// Errors of a synced node are returned without falling back.
+	_, err = client.VoteData(0)
+	assert.EqualError(t, err, "there are no votes on the genesis slot")
+
+	synced.Close()
+	_, err = client.ChainInfo()
+	assert.Equal(t, validator.ErrorNoNodes, err)
+}
+
+func Test_ClientVoters(t *testing.T) {
+	node := newTestNode(t, &testAPI{name: "synced", synced: true})
+	defer node.Close()
+
+	client := validator.NewClient(logger.New(os.Stdout), []string{node.URL})
+	defer client.Close()
+
+	voters, err := client.Voters(7)
+	assert.NoError(t, err)
+	assert.Equal(t, map[[48]byte]uint64{{7}: 7}, voters)
+}
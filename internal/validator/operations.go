@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"github.com/olympus-protocol/ogen/pkg/bls/common"
+	"github.com/olympus-protocol/ogen/pkg/params"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// The deposits, exits and partial exits change the validator registry. They are signed by the withdraw key of the
+// validator, which funds the deposit and receives the withdrawn balance. Their signatures are bound to the signing
+// domain of the slot, the tip slot of a node is used so they are valid until the domain changes.
+
+// NewDeposit returns a deposit of the validator key funded by the withdraw key.
+func NewDeposit(p *params.ChainParams, validatorKey common.SecretKey, withdrawKey common.SecretKey, slot uint64) (*primitives.Deposit, error) {
+	withdrawPub := withdrawKey.PublicKey()
+	withdrawAddress, err := withdrawPub.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &primitives.DepositData{
+		WithdrawalAddress: withdrawAddress,
+	}
+	copy(data.PublicKey[:], validatorKey.PublicKey().Marshal())
+
+	pop := data.ProofOfPossessionMessage(p, slot)
+	copy(data.ProofOfPossession[:], validatorKey.Sign(pop[:]).Marshal())
+
+	deposit := &primitives.Deposit{
+		Data: data,
+	}
+	copy(deposit.PublicKey[:], withdrawPub.Marshal())
+
+	msg, err := deposit.SigningMessage(p, slot)
+	if err != nil {
+		return nil, err
+	}
+	copy(deposit.Signature[:], withdrawKey.Sign(msg[:]).Marshal())
+
+	return deposit, nil
+}
+
+// NewExit returns the exit of a validator signed by its withdraw key.
+func NewExit(p *params.ChainParams, validatorPub [48]byte, withdrawKey common.SecretKey, slot uint64) *primitives.Exit {
+	exit := &primitives.Exit{
+		ValidatorPubkey: validatorPub,
+	}
+	copy(exit.WithdrawPubkey[:], withdrawKey.PublicKey().Marshal())
+
+	msg := exit.SigningMessage(p, slot)
+	copy(exit.Signature[:], withdrawKey.Sign(msg[:]).Marshal())
+
+	return exit
+}
+
+// NewPartialExit returns the partial exit of an amount of the balance of a validator signed by its withdraw key.
+func NewPartialExit(p *params.ChainParams, validatorPub [48]byte, withdrawKey common.SecretKey, amount uint64, slot uint64) *primitives.PartialExit {
+	partialExit := &primitives.PartialExit{
+		ValidatorPubkey: validatorPub,
+		Amount:          amount,
+	}
+	copy(partialExit.WithdrawPubkey[:], withdrawKey.PublicKey().Marshal())
+
+	msg := partialExit.SigningMessage(p, slot)
+	copy(partialExit.Signature[:], withdrawKey.Sign(msg[:]).Marshal())
+
+	return partialExit
+}
@@ -0,0 +1,67 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/olympus-protocol/ogen/internal/validator"
+	"github.com/olympus-protocol/ogen/pkg/bls"
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Operations(t *testing.T) {
+	p := testdata.TestParams
+	p.GenesisHash = chainhash.HashH([]byte("genesis"))
+	p.DomainForkSlot = 10
+
+	validatorKey, err := bls.RandKey()
+	assert.NoError(t, err)
+	withdrawKey, err := bls.RandKey()
+	assert.NoError(t, err)
+	withdrawAddress, err := withdrawKey.PublicKey().Hash()
+	assert.NoError(t, err)
+
+	var validatorPub [48]byte
+	copy(validatorPub[:], validatorKey.PublicKey().Marshal())
+
+	for _, slot := range []uint64{5, 20} {
+		deposit, err := validator.NewDeposit(&p, validatorKey, withdrawKey, slot)
+		assert.NoError(t, err)
+		assert.Equal(t, validatorPub, deposit.Data.PublicKey)
+		assert.Equal(t, withdrawAddress, deposit.Data.WithdrawalAddress)
+
+		msg, err := deposit.SigningMessage(&p, slot)
+		assert.NoError(t, err)
+		sig, err := deposit.GetSignature()
+		assert.NoError(t, err)
+		pub, err := deposit.GetPublicKey()
+		assert.NoError(t, err)
+		assert.True(t, sig.Verify(pub, msg[:]))
+
+		pop := deposit.Data.ProofOfPossessionMessage(&p, slot)
+		sig, err = deposit.Data.GetSignature()
+		assert.NoError(t, err)
+		assert.True(t, sig.Verify(validatorKey.PublicKey(), pop[:]))
+
+		exit := validator.NewExit(&p, validatorPub, withdrawKey, slot)
+		msg = exit.SigningMessage(&p, slot)
+		sig, err = exit.GetSignature()
+		assert.NoError(t, err)
+		assert.True(t, sig.Verify(withdrawKey.PublicKey(), msg[:]))
+
+		partialExit := validator.NewPartialExit(&p, validatorPub, withdrawKey, 20, slot)
+		assert.Equal(t, uint64(20), partialExit.Amount)
+		msg = partialExit.SigningMessage(&p, slot)
+		sig, err = partialExit.GetSignature()
+		assert.NoError(t, err)
+		assert.True(t, sig.Verify(withdrawKey.PublicKey(), msg[:]))
+	}
+
+	// Operations signed before the domain fork are not valid after it.
+	exit := validator.NewExit(&p, validatorPub, withdrawKey, 5)
+	msg := exit.SigningMessage(&p, 20)
+	sig, err := exit.GetSignature()
+	assert.NoError(t, err)
+	assert.False(t, sig.Verify(withdrawKey.PublicKey(), msg[:]))
+}
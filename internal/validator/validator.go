@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/olympus-protocol/ogen/cmd/ogen/config"
+	"github.com/olympus-protocol/ogen/internal/duties"
+	"github.com/olympus-protocol/ogen/internal/signer"
+	"github.com/olympus-protocol/ogen/internal/slashingprotection"
+	"github.com/olympus-protocol/ogen/pkg/logger"
+	"github.com/olympus-protocol/ogen/pkg/params"
+)
+
+// Validator proposes and votes with the keys of a signer using the validator API of the nodes.
+type Validator struct {
+	log       logger.Logger
+	netParams *params.ChainParams
+	client    *Client
+	signer    signer.Signer
+	duties    *duties.Duties
+
+	clock duties.Clock
+
+	context context.Context
+	stop    context.CancelFunc
+}
+
+// NewValidator returns a validator client. Every signature is checked against the slashing protection database.
+func NewValidator(client *Client, s signer.Signer, protection *slashingprotection.DB) *Validator {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Validator{
+		log:       config.GlobalParams.Logger,
+		netParams: config.GlobalParams.NetParams,
+		client:    client,
+		signer:    s,
+		duties:    duties.New(config.GlobalParams.Logger, s, protection),
+		context:   ctx,
+		stop:      cancel,
+	}
+}
+
+// Start checks the nodes follow the network of the validator and starts the duties after looking for doppelgangers.
+func (v *Validator) Start() error {
+	info, err := v.client.UseChain(v.netParams)
+	if err != nil {
+		return err
+	}
+
+	v.clock = duties.NewClock(time.Unix(info.GenesisTime, 0), v.netParams)
+
+	v.log.Infof("using genesis hash %s, node tip at slot %d", info.GenesisHash, info.TipSlot)
+
+	go v.run()
+
+	return nil
+}
+
+// Stop stops the duties.
+func (v *Validator) Stop() {
+	v.stop()
+}
+
+func (v *Validator) run() {
+	if !v.detectDoppelgangers() {
+		return
+	}
+
+	go duties.Run(v.context, v.clock, v.clock.BlockTime, func(slot uint64) {
+		if err := v.propose(slot); err != nil {
+			v.log.Errorf("unable to propose for slot %d: %s", slot, err)
+		}
+	})
+
+	duties.Run(v.context, v.clock, v.clock.VoteTime, func(slot uint64) {
+		if err := v.vote(slot); err != nil {
+			v.log.Errorf("unable to vote for slot %d: %s", slot, err)
+		}
+	})
+
+	v.log.Info("stopping validator")
+}
+
+// detectDoppelgangers waits the configured epochs and disables the duties of our validators with votes included on
+// the chain during that time, they are running on another node. It returns false when the validator stops during
+// the check.
+func (v *Validator) detectDoppelgangers() bool {
+	epochs := config.GlobalFlags.DoppelgangerEpochs
+	if epochs == 0 {
+		return true
+	}
+
+	startSlot := v.clock.CurrentSlot()
+	endSlot := startSlot + epochs*v.netParams.EpochLength
+
+	v.log.Infof("looking for doppelgangers until slot %d before starting the duties", endSlot)
+
+	// The votes of the last slot are included on the block of the next one.
+	checkTime := v.clock.BlockTime(endSlot + 2)
+
+	for {
+		select {
+		case <-time.After(time.Until(checkTime)):
+		case <-v.context.Done():
+			return false
+		}
+
+		voters, err := v.client.Voters(startSlot)
+		if err != nil {
+			v.log.Errorf("unable to look for doppelgangers, retrying on the next slot: %s", err)
+			checkTime = v.clock.BlockTime(v.clock.CurrentSlot() + 1)
+			continue
+		}
+
+		detected := make(map[[48]byte]uint64)
+		for pub, index := range voters {
+			if v.signer.HasKey(pub) {
+				detected[pub] = index
+			}
+		}
+		v.duties.Disable(detected)
+
+		if len(detected) > 0 {
+			v.log.Warnf("%d validators are running on another node, starting the duties of the rest", len(detected))
+		}
+		return true
+	}
+}
+
+func (v *Validator) propose(slot uint64) error {
+	slotDuties, err := v.client.Duties(slot)
+	if err != nil {
+		return err
+	}
+
+	pub, err := DecodePubKey(slotDuties.Proposer)
+	if err != nil {
+		return err
+	}
+	if !v.duties.HasKey(pub) {
+		return nil
+	}
+
+	v.log.Infof("proposing for slot %d", slot)
+
+	block, template, err := v.client.BlockTemplate(slot)
+	if err != nil {
+		return err
+	}
+	if block.Header.Slot != slot || template.Proposer != slotDuties.Proposer {
+		return fmt.Errorf("node returned a block template for slot %d proposed by %s", block.Header.Slot, template.Proposer)
+	}
+
+	signed, err := v.duties.SignBlock(pub, block)
+	if err != nil || !signed {
+		return err
+	}
+
+	return v.client.SubmitBlock(block)
+}
+
+func (v *Validator) vote(slot uint64) error {
+	slotDuties, err := v.client.Duties(slot)
+	if err != nil {
+		return err
+	}
+
+	ours := 0
+	committee := make([][48]byte, len(slotDuties.Committee))
+	for i, s := range slotDuties.Committee {
+		pub, err := DecodePubKey(s)
+		if err != nil {
+			return err
+		}
+		committee[i] = pub
+		if v.duties.HasKey(pub) {
+			ours++
+		}
+	}
+
+	v.log.Debugf("committing for slot %d with %d validators", slot, ours)
+
+	if ours == 0 {
+		return nil
+	}
+
+	data, err := v.client.VoteData(slot)
+	if err != nil {
+		return err
+	}
+	if data.Slot != slot {
+		return fmt.Errorf("node returned vote data for slot %d", data.Slot)
+	}
+
+	vote := v.duties.SignVote(data, committee)
+	if vote == nil {
+		return nil
+	}
+
+	v.log.Infof("sending votes for slot %d for %d validators", slot, len(vote.ParticipationBitfield.BitIndices()))
+
+	return v.client.SubmitVote(vote)
+}
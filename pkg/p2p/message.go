@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
 )
@@ -20,6 +21,9 @@ var (
 	ErrorNetMismatch = errors.New("wrong message network")
 )
 
+// MessageHeaderSize is the size of the header written before every message.
+const MessageHeaderSize = 60
+
 const (
 	// MsgBlockCmd is a single block element
 	MsgBlockCmd = "block"
@@ -39,6 +43,18 @@ const (
 	MsgFinalizationCmd = "finalized"
 	// MsgPartialExitsCmd subtract coins from a contract
 	MsgPartialExitsCmd = "partialexit"
+	// MsgGetBlocksByRangeCmd ask a node for the blocks after a locator
+	MsgGetBlocksByRangeCmd = "getblocksrange"
+	// MsgGetBlocksByRootCmd ask a node for blocks by hash
+	MsgGetBlocksByRootCmd = "getblocksroot"
+	// MsgBlocksCmd is a batch of blocks answering a block request
+	MsgBlocksCmd = "blocks"
+	// MsgGetHeadersCmd ask a node for block headers
+	MsgGetHeadersCmd = "getheaders"
+	// MsgHeadersCmd is a slice of signed block headers
+	MsgHeadersCmd = "headers"
+	// MsgSlashingsCmd announce slashing conditions
+	MsgSlashingsCmd = "slashings"
 )
 
 // Message interface for all the messages
@@ -68,6 +84,25 @@ func (m *MessageHeader) Unmarshal(b []byte) error {
 	return m.UnmarshalSSZ(b)
 }
 
+// CommandString returns the command of the message without the padding.
+func (m *MessageHeader) CommandString() string {
+	return string(bytes.Trim(m.Command[:], "\x00"))
+}
+
+// CheckLength returns ErrorSizeExceed if the announced payload is bigger than the maximum payload of the command.
+func (m *MessageHeader) CheckLength() error {
+	msg, err := makeEmptyMessage(m.CommandString())
+	if err != nil {
+		return err
+	}
+
+	if m.Length > msg.MaxPayloadLength() {
+		return ErrorSizeExceed
+	}
+
+	return nil
+}
+
 func makeEmptyMessage(command string) (Message, error) {
 	var msg Message
 	switch command {
@@ -89,6 +124,18 @@ func makeEmptyMessage(command string) (Message, error) {
 		msg = &MsgFinalization{}
 	case MsgPartialExitsCmd:
 		msg = &MsgPartialExits{}
+	case MsgGetBlocksByRangeCmd:
+		msg = &MsgGetBlocksByRange{}
+	case MsgGetBlocksByRootCmd:
+		msg = &MsgGetBlocksByRoot{}
+	case MsgBlocksCmd:
+		msg = &MsgBlocks{}
+	case MsgGetHeadersCmd:
+		msg = &MsgGetHeaders{}
+	case MsgHeadersCmd:
+		msg = &MsgHeaders{}
+	case MsgSlashingsCmd:
+		msg = &MsgSlashings{}
 
 	default:
 		return nil, fmt.Errorf("unhandled command [%s]", command)
@@ -98,26 +145,37 @@ func makeEmptyMessage(command string) (Message, error) {
 
 // ReadMessage decodes the message from reader
 func ReadMessage(r io.Reader, net uint32) (Message, error) {
-
-	headerBuf := make([]byte, 60)
-	_, err := io.ReadFull(r, headerBuf)
+	header, err := ReadMessageHeader(r, net)
 	if err != nil {
 		return nil, err
 	}
 
-	header, err := readHeader(headerBuf, net)
+	return ReadMessagePayload(r, header)
+}
+
+// ReadMessageHeader reads the header of the next message from reader. The payload must be read after it
+// with ReadMessagePayload or skipped with DiscardMessagePayload.
+func ReadMessageHeader(r io.Reader, net uint32) (*MessageHeader, error) {
+	headerBuf := make([]byte, MessageHeaderSize)
+	_, err := io.ReadFull(r, headerBuf)
 	if err != nil {
 		return nil, err
 	}
 
-	cmdB := bytes.Trim(header.Command[:], "\x00")
-	cmd := string(cmdB)
+	return readHeader(headerBuf, net)
+}
 
-	msg, err := makeEmptyMessage(cmd)
+// ReadMessagePayload reads and decodes the payload announced by the header.
+func ReadMessagePayload(r io.Reader, header *MessageHeader) (Message, error) {
+	msg, err := makeEmptyMessage(header.CommandString())
 	if err != nil {
 		return nil, err
 	}
 
+	if header.Length > msg.MaxPayloadLength() {
+		return nil, ErrorSizeExceed
+	}
+
 	msgB := make([]byte, header.Length)
 	_, err = io.ReadFull(r, msgB)
 	if err != nil {
@@ -137,11 +195,23 @@ func ReadMessage(r io.Reader, net uint32) (Message, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	return msg, nil
+}
+
+// DiscardMessagePayload skips the payload announced by the header without decoding it.
+func DiscardMessagePayload(r io.Reader, header *MessageHeader) error {
+	msg, err := makeEmptyMessage(header.CommandString())
+	if err != nil {
+		return err
+	}
+
 	if header.Length > msg.MaxPayloadLength() {
-		return nil, ErrorSizeExceed
+		return ErrorSizeExceed
 	}
 
-	return msg, nil
+	_, err = io.CopyN(ioutil.Discard, r, int64(header.Length))
+	return err
 }
 
 func readHeader(h []byte, net uint32) (*MessageHeader, error) {
@@ -51,6 +51,28 @@ func TestReadWriteMessage(t *testing.T) {
 	assert.Equal(t, v, blockMsg)
 }
 
+func TestDiscardMessagePayload(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+
+	err := p2p.WriteMessage(buf, &p2p.MsgBlock{Data: testdata.FuzzBlock(1, true, true)[0]}, 1)
+	assert.NoError(t, err)
+
+	v := &p2p.MsgGetBlocks{LastBlockHash: [32]byte{1}}
+	err = p2p.WriteMessage(buf, v, 1)
+	assert.NoError(t, err)
+
+	header, err := p2p.ReadMessageHeader(buf, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, p2p.MsgBlockCmd, header.CommandString())
+
+	err = p2p.DiscardMessagePayload(buf, header)
+	assert.NoError(t, err)
+
+	msg, err := p2p.ReadMessage(buf, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, v, msg)
+}
+
 func TestMsgTypeCreation(t *testing.T) {
 	createMsgVersion(t)
 	createMsgGetBlocks(t)
@@ -0,0 +1,43 @@
+package p2p
+
+import (
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MaxBlocksPerMsg is the maximum amount of blocks on a single MsgBlocks batch.
+const MaxBlocksPerMsg = 16
+
+// MsgBlocks is a batch of blocks sent as a response to a block request. A response may be split
+// in multiple batches, the last one is marked as Done. More is set on the last batch when the
+// peer has blocks after the ones sent that didn't fit in the request.
+type MsgBlocks struct {
+	RequestID uint64
+	Blocks    []*primitives.Block `ssz-max:"16"`
+	Done      bool
+	More      bool
+}
+
+// Marshal serializes the data to bytes
+func (m *MsgBlocks) Marshal() ([]byte, error) {
+	return m.MarshalSSZ()
+}
+
+// Unmarshal deserializes the data
+func (m *MsgBlocks) Unmarshal(b []byte) error {
+	return m.UnmarshalSSZ(b)
+}
+
+// Command returns the message topic
+func (m *MsgBlocks) Command() string {
+	return MsgBlocksCmd
+}
+
+// MaxPayloadLength returns the maximum size of the MsgBlocks message.
+func (m *MsgBlocks) MaxPayloadLength() uint64 {
+	return 8 + 4 + ((primitives.MaxBlockSize + 4) * MaxBlocksPerMsg) + 1 + 1
+}
+
+// PayloadLength returns the size of the MsgBlocks message.
+func (m *MsgBlocks) PayloadLength() uint64 {
+	return uint64(m.SizeSSZ())
+}
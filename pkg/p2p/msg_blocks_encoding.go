@@ -0,0 +1,159 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: b15629ae199a0781ca1ebca40fd7d47317c9d74256561b7c17f075b2818f9c53
+package p2p
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MarshalSSZ ssz marshals the MsgBlocks object
+func (m *MsgBlocks) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MsgBlocks object to a target array
+func (m *MsgBlocks) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(14)
+
+	// Field (0) 'RequestID'
+	dst = ssz.MarshalUint64(dst, m.RequestID)
+
+	// Offset (1) 'Blocks'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(m.Blocks); ii++ {
+		offset += 4
+		offset += m.Blocks[ii].SizeSSZ()
+	}
+
+	// Field (2) 'Done'
+	dst = ssz.MarshalBool(dst, m.Done)
+
+	// Field (3) 'More'
+	dst = ssz.MarshalBool(dst, m.More)
+
+	// Field (1) 'Blocks'
+	if len(m.Blocks) > 16 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(m.Blocks)
+		for ii := 0; ii < len(m.Blocks); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += m.Blocks[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(m.Blocks); ii++ {
+		if dst, err = m.Blocks[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MsgBlocks object
+func (m *MsgBlocks) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 14 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1 uint64
+
+	// Field (0) 'RequestID'
+	m.RequestID = ssz.UnmarshallUint64(buf[0:8])
+
+	// Offset (1) 'Blocks'
+	if o1 = ssz.ReadOffset(buf[8:12]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 14 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'Done'
+	m.Done = ssz.UnmarshalBool(buf[12:13])
+
+	// Field (3) 'More'
+	m.More = ssz.UnmarshalBool(buf[13:14])
+
+	// Field (1) 'Blocks'
+	{
+		buf = tail[o1:]
+		num, err := ssz.DecodeDynamicLength(buf, 16)
+		if err != nil {
+			return err
+		}
+		m.Blocks = make([]*primitives.Block, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if m.Blocks[indx] == nil {
+				m.Blocks[indx] = new(primitives.Block)
+			}
+			if err = m.Blocks[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MsgBlocks object
+func (m *MsgBlocks) SizeSSZ() (size int) {
+	size = 14
+
+	// Field (1) 'Blocks'
+	for ii := 0; ii < len(m.Blocks); ii++ {
+		size += 4
+		size += m.Blocks[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MsgBlocks object
+func (m *MsgBlocks) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MsgBlocks object with a hasher
+func (m *MsgBlocks) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'RequestID'
+	hh.PutUint64(m.RequestID)
+
+	// Field (1) 'Blocks'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.Blocks))
+		if num > 16 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = m.Blocks[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 16)
+	}
+
+	// Field (2) 'Done'
+	hh.PutBool(m.Done)
+
+	// Field (3) 'More'
+	hh.PutBool(m.More)
+
+	hh.Merkleize(indx)
+	return
+}
@@ -0,0 +1,28 @@
+package p2p_test
+
+import (
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	testdata "github.com/olympus-protocol/ogen/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMsgBlocks(t *testing.T) {
+	v := new(p2p.MsgBlocks)
+	v.RequestID = 5
+	v.Blocks = testdata.FuzzBlock(3, true, true)
+	v.Done = true
+	v.More = true
+
+	ser, err := v.Marshal()
+	assert.NoError(t, err)
+
+	desc := new(p2p.MsgBlocks)
+	err = desc.Unmarshal(ser)
+	assert.NoError(t, err)
+
+	assert.Equal(t, v, desc)
+
+	assert.Equal(t, p2p.MsgBlocksCmd, v.Command())
+	assert.Equal(t, uint64(18187246), v.MaxPayloadLength())
+}
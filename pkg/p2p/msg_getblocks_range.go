@@ -0,0 +1,46 @@
+package p2p
+
+// BlocksProtocolVersion is the version of the block request/response messages.
+const BlocksProtocolVersion = 1
+
+// MaxLocatorHashes is the maximum amount of hashes on a block locator.
+const MaxLocatorHashes = 64
+
+// MaxBlocksPerRequest is the maximum amount of blocks a peer will serve for a single range request.
+const MaxBlocksPerRequest = 512
+
+// MsgGetBlocksByRange asks a peer for blocks of its main chain. When a locator is included, the peer answers
+// with the blocks following the first locator hash it knows, the locator is sorted from the newest to the
+// oldest block. Without a locator, the peer answers with the blocks on the slot range starting at StartSlot.
+type MsgGetBlocksByRange struct {
+	Version   uint64
+	RequestID uint64
+	Locator   [][32]byte `ssz-max:"64"`
+	Count     uint64
+	StartSlot uint64
+}
+
+// Marshal serializes the data to bytes
+func (m *MsgGetBlocksByRange) Marshal() ([]byte, error) {
+	return m.MarshalSSZ()
+}
+
+// Unmarshal deserializes the data
+func (m *MsgGetBlocksByRange) Unmarshal(b []byte) error {
+	return m.UnmarshalSSZ(b)
+}
+
+// Command returns the message topic
+func (m *MsgGetBlocksByRange) Command() string {
+	return MsgGetBlocksByRangeCmd
+}
+
+// MaxPayloadLength returns the maximum size of the MsgGetBlocksByRange message.
+func (m *MsgGetBlocksByRange) MaxPayloadLength() uint64 {
+	return 8 + 8 + 4 + (MaxLocatorHashes * 32) + 8 + 8
+}
+
+// PayloadLength returns the size of the MsgGetBlocksByRange message.
+func (m *MsgGetBlocksByRange) PayloadLength() uint64 {
+	return uint64(m.SizeSSZ())
+}
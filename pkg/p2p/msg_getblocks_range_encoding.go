@@ -0,0 +1,141 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: 869ccb6d39527b3930380e1b61b2b5c05e691da75f46d5643800e6b1199acc24
+package p2p
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// MarshalSSZ ssz marshals the MsgGetBlocksByRange object
+func (m *MsgGetBlocksByRange) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MsgGetBlocksByRange object to a target array
+func (m *MsgGetBlocksByRange) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(36)
+
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, m.Version)
+
+	// Field (1) 'RequestID'
+	dst = ssz.MarshalUint64(dst, m.RequestID)
+
+	// Offset (2) 'Locator'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Locator) * 32
+
+	// Field (3) 'Count'
+	dst = ssz.MarshalUint64(dst, m.Count)
+
+	// Field (4) 'StartSlot'
+	dst = ssz.MarshalUint64(dst, m.StartSlot)
+
+	// Field (2) 'Locator'
+	if len(m.Locator) > 64 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(m.Locator); ii++ {
+		dst = append(dst, m.Locator[ii][:]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MsgGetBlocksByRange object
+func (m *MsgGetBlocksByRange) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 36 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o2 uint64
+
+	// Field (0) 'Version'
+	m.Version = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'RequestID'
+	m.RequestID = ssz.UnmarshallUint64(buf[8:16])
+
+	// Offset (2) 'Locator'
+	if o2 = ssz.ReadOffset(buf[16:20]); o2 > size {
+		return ssz.ErrOffset
+	}
+
+	if o2 < 36 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (3) 'Count'
+	m.Count = ssz.UnmarshallUint64(buf[20:28])
+
+	// Field (4) 'StartSlot'
+	m.StartSlot = ssz.UnmarshallUint64(buf[28:36])
+
+	// Field (2) 'Locator'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 32, 64)
+		if err != nil {
+			return err
+		}
+		m.Locator = make([][32]byte, num)
+		for ii := 0; ii < num; ii++ {
+			copy(m.Locator[ii][:], buf[ii*32:(ii+1)*32])
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MsgGetBlocksByRange object
+func (m *MsgGetBlocksByRange) SizeSSZ() (size int) {
+	size = 36
+
+	// Field (2) 'Locator'
+	size += len(m.Locator) * 32
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MsgGetBlocksByRange object
+func (m *MsgGetBlocksByRange) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MsgGetBlocksByRange object with a hasher
+func (m *MsgGetBlocksByRange) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Version'
+	hh.PutUint64(m.Version)
+
+	// Field (1) 'RequestID'
+	hh.PutUint64(m.RequestID)
+
+	// Field (2) 'Locator'
+	{
+		if len(m.Locator) > 64 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range m.Locator {
+			hh.Append(i[:])
+		}
+		numItems := uint64(len(m.Locator))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(64, numItems, 32))
+	}
+
+	// Field (3) 'Count'
+	hh.PutUint64(m.Count)
+
+	// Field (4) 'StartSlot'
+	hh.PutUint64(m.StartSlot)
+
+	hh.Merkleize(indx)
+	return
+}
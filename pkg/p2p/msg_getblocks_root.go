@@ -0,0 +1,36 @@
+package p2p
+
+// MaxRootsPerRequest is the maximum amount of block hashes on a root request.
+const MaxRootsPerRequest = 64
+
+// MsgGetBlocksByRoot asks a peer for the blocks with the specified hashes.
+type MsgGetBlocksByRoot struct {
+	Version   uint64
+	RequestID uint64
+	Roots     [][32]byte `ssz-max:"64"`
+}
+
+// Marshal serializes the data to bytes
+func (m *MsgGetBlocksByRoot) Marshal() ([]byte, error) {
+	return m.MarshalSSZ()
+}
+
+// Unmarshal deserializes the data
+func (m *MsgGetBlocksByRoot) Unmarshal(b []byte) error {
+	return m.UnmarshalSSZ(b)
+}
+
+// Command returns the message topic
+func (m *MsgGetBlocksByRoot) Command() string {
+	return MsgGetBlocksByRootCmd
+}
+
+// MaxPayloadLength returns the maximum size of the MsgGetBlocksByRoot message.
+func (m *MsgGetBlocksByRoot) MaxPayloadLength() uint64 {
+	return 8 + 8 + 4 + (MaxRootsPerRequest * 32)
+}
+
+// PayloadLength returns the size of the MsgGetBlocksByRoot message.
+func (m *MsgGetBlocksByRoot) PayloadLength() uint64 {
+	return uint64(m.SizeSSZ())
+}
@@ -0,0 +1,123 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: 742a1822c41e9ca8eecb2a7cf4ee478fa95508a2e41b7b3199fd3a1ae145a8ee
+package p2p
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// MarshalSSZ ssz marshals the MsgGetBlocksByRoot object
+func (m *MsgGetBlocksByRoot) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MsgGetBlocksByRoot object to a target array
+func (m *MsgGetBlocksByRoot) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(20)
+
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, m.Version)
+
+	// Field (1) 'RequestID'
+	dst = ssz.MarshalUint64(dst, m.RequestID)
+
+	// Offset (2) 'Roots'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Roots) * 32
+
+	// Field (2) 'Roots'
+	if len(m.Roots) > 64 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(m.Roots); ii++ {
+		dst = append(dst, m.Roots[ii][:]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MsgGetBlocksByRoot object
+func (m *MsgGetBlocksByRoot) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 20 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o2 uint64
+
+	// Field (0) 'Version'
+	m.Version = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'RequestID'
+	m.RequestID = ssz.UnmarshallUint64(buf[8:16])
+
+	// Offset (2) 'Roots'
+	if o2 = ssz.ReadOffset(buf[16:20]); o2 > size {
+		return ssz.ErrOffset
+	}
+
+	if o2 < 20 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'Roots'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 32, 64)
+		if err != nil {
+			return err
+		}
+		m.Roots = make([][32]byte, num)
+		for ii := 0; ii < num; ii++ {
+			copy(m.Roots[ii][:], buf[ii*32:(ii+1)*32])
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MsgGetBlocksByRoot object
+func (m *MsgGetBlocksByRoot) SizeSSZ() (size int) {
+	size = 20
+
+	// Field (2) 'Roots'
+	size += len(m.Roots) * 32
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MsgGetBlocksByRoot object
+func (m *MsgGetBlocksByRoot) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MsgGetBlocksByRoot object with a hasher
+func (m *MsgGetBlocksByRoot) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Version'
+	hh.PutUint64(m.Version)
+
+	// Field (1) 'RequestID'
+	hh.PutUint64(m.RequestID)
+
+	// Field (2) 'Roots'
+	{
+		if len(m.Roots) > 64 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range m.Roots {
+			hh.Append(i[:])
+		}
+		numItems := uint64(len(m.Roots))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(64, numItems, 32))
+	}
+
+	hh.Merkleize(indx)
+	return
+}
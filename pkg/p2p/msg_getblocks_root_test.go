@@ -0,0 +1,27 @@
+package p2p_test
+
+import (
+	fuzz "github.com/google/gofuzz"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMsgGetBlocksByRoot(t *testing.T) {
+	f := fuzz.New().NilChance(0).NumElements(p2p.MaxLocatorHashes, p2p.MaxLocatorHashes)
+	v := new(p2p.MsgGetBlocksByRoot)
+	f.Fuzz(v)
+
+	ser, err := v.Marshal()
+	assert.NoError(t, err)
+
+	desc := new(p2p.MsgGetBlocksByRoot)
+	err = desc.Unmarshal(ser)
+	assert.NoError(t, err)
+
+	assert.Equal(t, v, desc)
+
+	assert.Equal(t, p2p.MsgGetBlocksByRootCmd, v.Command())
+	assert.Equal(t, uint64(2068), v.MaxPayloadLength())
+	assert.Equal(t, v.MaxPayloadLength(), v.PayloadLength())
+}
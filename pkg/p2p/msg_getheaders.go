@@ -0,0 +1,35 @@
+package p2p
+
+// MsgGetHeaders asks a peer for the signed headers following the first locator hash on its main chain.
+// The locator is sorted from the newest to the oldest block.
+type MsgGetHeaders struct {
+	Version   uint64
+	RequestID uint64
+	Locator   [][32]byte `ssz-max:"64"`
+	Count     uint64
+}
+
+// Marshal serializes the data to bytes
+func (m *MsgGetHeaders) Marshal() ([]byte, error) {
+	return m.MarshalSSZ()
+}
+
+// Unmarshal deserializes the data
+func (m *MsgGetHeaders) Unmarshal(b []byte) error {
+	return m.UnmarshalSSZ(b)
+}
+
+// Command returns the message topic
+func (m *MsgGetHeaders) Command() string {
+	return MsgGetHeadersCmd
+}
+
+// MaxPayloadLength returns the maximum size of the MsgGetHeaders message.
+func (m *MsgGetHeaders) MaxPayloadLength() uint64 {
+	return 8 + 8 + 4 + (MaxLocatorHashes * 32) + 8
+}
+
+// PayloadLength returns the size of the MsgGetHeaders message.
+func (m *MsgGetHeaders) PayloadLength() uint64 {
+	return uint64(m.SizeSSZ())
+}
@@ -0,0 +1,132 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: 33426cd3992fb540aca6c7cf471af5e15785b7377dd3b9d076303b78841015bd
+package p2p
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+)
+
+// MarshalSSZ ssz marshals the MsgGetHeaders object
+func (m *MsgGetHeaders) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MsgGetHeaders object to a target array
+func (m *MsgGetHeaders) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(28)
+
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, m.Version)
+
+	// Field (1) 'RequestID'
+	dst = ssz.MarshalUint64(dst, m.RequestID)
+
+	// Offset (2) 'Locator'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Locator) * 32
+
+	// Field (3) 'Count'
+	dst = ssz.MarshalUint64(dst, m.Count)
+
+	// Field (2) 'Locator'
+	if len(m.Locator) > 64 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(m.Locator); ii++ {
+		dst = append(dst, m.Locator[ii][:]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MsgGetHeaders object
+func (m *MsgGetHeaders) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 28 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o2 uint64
+
+	// Field (0) 'Version'
+	m.Version = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'RequestID'
+	m.RequestID = ssz.UnmarshallUint64(buf[8:16])
+
+	// Offset (2) 'Locator'
+	if o2 = ssz.ReadOffset(buf[16:20]); o2 > size {
+		return ssz.ErrOffset
+	}
+
+	if o2 < 28 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (3) 'Count'
+	m.Count = ssz.UnmarshallUint64(buf[20:28])
+
+	// Field (2) 'Locator'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 32, 64)
+		if err != nil {
+			return err
+		}
+		m.Locator = make([][32]byte, num)
+		for ii := 0; ii < num; ii++ {
+			copy(m.Locator[ii][:], buf[ii*32:(ii+1)*32])
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MsgGetHeaders object
+func (m *MsgGetHeaders) SizeSSZ() (size int) {
+	size = 28
+
+	// Field (2) 'Locator'
+	size += len(m.Locator) * 32
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MsgGetHeaders object
+func (m *MsgGetHeaders) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MsgGetHeaders object with a hasher
+func (m *MsgGetHeaders) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Version'
+	hh.PutUint64(m.Version)
+
+	// Field (1) 'RequestID'
+	hh.PutUint64(m.RequestID)
+
+	// Field (2) 'Locator'
+	{
+		if len(m.Locator) > 64 {
+			err = ssz.ErrListTooBig
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range m.Locator {
+			hh.Append(i[:])
+		}
+		numItems := uint64(len(m.Locator))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(64, numItems, 32))
+	}
+
+	// Field (3) 'Count'
+	hh.PutUint64(m.Count)
+
+	hh.Merkleize(indx)
+	return
+}
@@ -0,0 +1,51 @@
+package p2p
+
+import (
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MaxHeadersPerMsg is the maximum amount of headers on a MsgHeaders message.
+const MaxHeadersPerMsg = 512
+
+// SignedBlockHeader is a block header with the proposer signatures of the block.
+type SignedBlockHeader struct {
+	Header          *primitives.BlockHeader
+	Signature       [96]byte
+	RandaoSignature [96]byte
+}
+
+// SignedBlockHeaderSize is the size of a serialized SignedBlockHeader.
+const SignedBlockHeaderSize = primitives.BlockHeaderSize + 96 + 96
+
+// MsgHeaders is the response to a MsgGetHeaders request. More is set when the peer has headers
+// after the ones sent that didn't fit in the message.
+type MsgHeaders struct {
+	RequestID uint64
+	Headers   []*SignedBlockHeader `ssz-max:"512"`
+	More      bool
+}
+
+// Marshal serializes the data to bytes
+func (m *MsgHeaders) Marshal() ([]byte, error) {
+	return m.MarshalSSZ()
+}
+
+// Unmarshal deserializes the data
+func (m *MsgHeaders) Unmarshal(b []byte) error {
+	return m.UnmarshalSSZ(b)
+}
+
+// Command returns the message topic
+func (m *MsgHeaders) Command() string {
+	return MsgHeadersCmd
+}
+
+// MaxPayloadLength returns the maximum size of the MsgHeaders message.
+func (m *MsgHeaders) MaxPayloadLength() uint64 {
+	return 8 + 4 + (SignedBlockHeaderSize * MaxHeadersPerMsg) + 1
+}
+
+// PayloadLength returns the size of the MsgHeaders message.
+func (m *MsgHeaders) PayloadLength() uint64 {
+	return uint64(m.SizeSSZ())
+}
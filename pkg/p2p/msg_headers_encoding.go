@@ -0,0 +1,214 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: 423d81a31de846edb35d0a001892beafde93af63ff0ae0cf8453291958f7d108
+package p2p
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MarshalSSZ ssz marshals the SignedBlockHeader object
+func (s *SignedBlockHeader) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SignedBlockHeader object to a target array
+func (s *SignedBlockHeader) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'Header'
+	if s.Header == nil {
+		s.Header = new(primitives.BlockHeader)
+	}
+	if dst, err = s.Header.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	dst = append(dst, s.Signature[:]...)
+
+	// Field (2) 'RandaoSignature'
+	dst = append(dst, s.RandaoSignature[:]...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SignedBlockHeader object
+func (s *SignedBlockHeader) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 652 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'Header'
+	if s.Header == nil {
+		s.Header = new(primitives.BlockHeader)
+	}
+	if err = s.Header.UnmarshalSSZ(buf[0:460]); err != nil {
+		return err
+	}
+
+	// Field (1) 'Signature'
+	copy(s.Signature[:], buf[460:556])
+
+	// Field (2) 'RandaoSignature'
+	copy(s.RandaoSignature[:], buf[556:652])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SignedBlockHeader object
+func (s *SignedBlockHeader) SizeSSZ() (size int) {
+	size = 652
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedBlockHeader object
+func (s *SignedBlockHeader) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedBlockHeader object with a hasher
+func (s *SignedBlockHeader) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Header'
+	if err = s.Header.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	hh.PutBytes(s.Signature[:])
+
+	// Field (2) 'RandaoSignature'
+	hh.PutBytes(s.RandaoSignature[:])
+
+	hh.Merkleize(indx)
+	return
+}
+
+// MarshalSSZ ssz marshals the MsgHeaders object
+func (m *MsgHeaders) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MsgHeaders object to a target array
+func (m *MsgHeaders) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(13)
+
+	// Field (0) 'RequestID'
+	dst = ssz.MarshalUint64(dst, m.RequestID)
+
+	// Offset (1) 'Headers'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.Headers) * 652
+
+	// Field (2) 'More'
+	dst = ssz.MarshalBool(dst, m.More)
+
+	// Field (1) 'Headers'
+	if len(m.Headers) > 512 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(m.Headers); ii++ {
+		if dst, err = m.Headers[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MsgHeaders object
+func (m *MsgHeaders) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 13 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1 uint64
+
+	// Field (0) 'RequestID'
+	m.RequestID = ssz.UnmarshallUint64(buf[0:8])
+
+	// Offset (1) 'Headers'
+	if o1 = ssz.ReadOffset(buf[8:12]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 13 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (2) 'More'
+	m.More = ssz.UnmarshalBool(buf[12:13])
+
+	// Field (1) 'Headers'
+	{
+		buf = tail[o1:]
+		num, err := ssz.DivideInt2(len(buf), 652, 512)
+		if err != nil {
+			return err
+		}
+		m.Headers = make([]*SignedBlockHeader, num)
+		for ii := 0; ii < num; ii++ {
+			if m.Headers[ii] == nil {
+				m.Headers[ii] = new(SignedBlockHeader)
+			}
+			if err = m.Headers[ii].UnmarshalSSZ(buf[ii*652 : (ii+1)*652]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MsgHeaders object
+func (m *MsgHeaders) SizeSSZ() (size int) {
+	size = 13
+
+	// Field (1) 'Headers'
+	size += len(m.Headers) * 652
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MsgHeaders object
+func (m *MsgHeaders) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MsgHeaders object with a hasher
+func (m *MsgHeaders) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'RequestID'
+	hh.PutUint64(m.RequestID)
+
+	// Field (1) 'Headers'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.Headers))
+		if num > 512 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = m.Headers[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 512)
+	}
+
+	// Field (2) 'More'
+	hh.PutBool(m.More)
+
+	hh.Merkleize(indx)
+	return
+}
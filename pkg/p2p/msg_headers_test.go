@@ -0,0 +1,26 @@
+package p2p_test
+
+import (
+	fuzz "github.com/google/gofuzz"
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMsgHeaders(t *testing.T) {
+	f := fuzz.New().NilChance(0).NumElements(10, 10)
+	v := new(p2p.MsgHeaders)
+	f.Fuzz(v)
+
+	ser, err := v.Marshal()
+	assert.NoError(t, err)
+
+	desc := new(p2p.MsgHeaders)
+	err = desc.Unmarshal(ser)
+	assert.NoError(t, err)
+
+	assert.Equal(t, v, desc)
+
+	assert.Equal(t, p2p.MsgHeadersCmd, v.Command())
+	assert.Equal(t, uint64(333837), v.MaxPayloadLength())
+}
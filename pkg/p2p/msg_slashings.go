@@ -0,0 +1,39 @@
+package p2p
+
+import (
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MsgSlashings is the struct of the message that announces slashing conditions to the network.
+type MsgSlashings struct {
+	ProposerSlashings []*primitives.ProposerSlashing `ssz-max:"2"`
+	VoteSlashings     []*primitives.VoteSlashing     `ssz-max:"5"`
+	RANDAOSlashings   []*primitives.RANDAOSlashing   `ssz-max:"20"`
+}
+
+// Marshal serializes the data to bytes
+func (m *MsgSlashings) Marshal() ([]byte, error) {
+	return m.MarshalSSZ()
+}
+
+// Unmarshal deserializes the data
+func (m *MsgSlashings) Unmarshal(b []byte) error {
+	return m.UnmarshalSSZ(b)
+}
+
+// Command returns the message topic
+func (m *MsgSlashings) Command() string {
+	return MsgSlashingsCmd
+}
+
+// MaxPayloadLength returns the maximum size of the MsgSlashings message.
+func (m *MsgSlashings) MaxPayloadLength() uint64 {
+	return (primitives.ProposerSlashingSize * primitives.MaxProposerSlashingsPerBlock) +
+		(primitives.MaxVotesSlashingSize * primitives.MaxVoteSlashingsPerBlock) +
+		(primitives.RANDAOSlashingSize * primitives.MaxRANDAOSlashingsPerBlock)
+}
+
+// PayloadLength returns the size of the MsgSlashings message.
+func (m *MsgSlashings) PayloadLength() uint64 {
+	return uint64(m.SizeSSZ())
+}
@@ -0,0 +1,246 @@
+// Code generated by fastssz. DO NOT EDIT.
+// Hash: dfa40303c6b116f00404313fa3f9e0aa3715d39e3224d3c90373a0b7403900b2
+package p2p
+
+import (
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/olympus-protocol/ogen/pkg/primitives"
+)
+
+// MarshalSSZ ssz marshals the MsgSlashings object
+func (m *MsgSlashings) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(m)
+}
+
+// MarshalSSZTo ssz marshals the MsgSlashings object to a target array
+func (m *MsgSlashings) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(12)
+
+	// Offset (0) 'ProposerSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.ProposerSlashings) * 1160
+
+	// Offset (1) 'VoteSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(m.VoteSlashings); ii++ {
+		offset += 4
+		offset += m.VoteSlashings[ii].SizeSSZ()
+	}
+
+	// Offset (2) 'RANDAOSlashings'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(m.RANDAOSlashings) * 152
+
+	// Field (0) 'ProposerSlashings'
+	if len(m.ProposerSlashings) > 2 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(m.ProposerSlashings); ii++ {
+		if dst, err = m.ProposerSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (1) 'VoteSlashings'
+	if len(m.VoteSlashings) > 5 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	{
+		offset = 4 * len(m.VoteSlashings)
+		for ii := 0; ii < len(m.VoteSlashings); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += m.VoteSlashings[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(m.VoteSlashings); ii++ {
+		if dst, err = m.VoteSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (2) 'RANDAOSlashings'
+	if len(m.RANDAOSlashings) > 20 {
+		err = ssz.ErrListTooBig
+		return
+	}
+	for ii := 0; ii < len(m.RANDAOSlashings); ii++ {
+		if dst, err = m.RANDAOSlashings[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the MsgSlashings object
+func (m *MsgSlashings) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 12 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1, o2 uint64
+
+	// Offset (0) 'ProposerSlashings'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 12 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'VoteSlashings'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Offset (2) 'RANDAOSlashings'
+	if o2 = ssz.ReadOffset(buf[8:12]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (0) 'ProposerSlashings'
+	{
+		buf = tail[o0:o1]
+		num, err := ssz.DivideInt2(len(buf), 1160, 2)
+		if err != nil {
+			return err
+		}
+		m.ProposerSlashings = make([]*primitives.ProposerSlashing, num)
+		for ii := 0; ii < num; ii++ {
+			if m.ProposerSlashings[ii] == nil {
+				m.ProposerSlashings[ii] = new(primitives.ProposerSlashing)
+			}
+			if err = m.ProposerSlashings[ii].UnmarshalSSZ(buf[ii*1160 : (ii+1)*1160]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Field (1) 'VoteSlashings'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DecodeDynamicLength(buf, 5)
+		if err != nil {
+			return err
+		}
+		m.VoteSlashings = make([]*primitives.VoteSlashing, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if m.VoteSlashings[indx] == nil {
+				m.VoteSlashings[indx] = new(primitives.VoteSlashing)
+			}
+			if err = m.VoteSlashings[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (2) 'RANDAOSlashings'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 152, 20)
+		if err != nil {
+			return err
+		}
+		m.RANDAOSlashings = make([]*primitives.RANDAOSlashing, num)
+		for ii := 0; ii < num; ii++ {
+			if m.RANDAOSlashings[ii] == nil {
+				m.RANDAOSlashings[ii] = new(primitives.RANDAOSlashing)
+			}
+			if err = m.RANDAOSlashings[ii].UnmarshalSSZ(buf[ii*152 : (ii+1)*152]); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the MsgSlashings object
+func (m *MsgSlashings) SizeSSZ() (size int) {
+	size = 12
+
+	// Field (0) 'ProposerSlashings'
+	size += len(m.ProposerSlashings) * 1160
+
+	// Field (1) 'VoteSlashings'
+	for ii := 0; ii < len(m.VoteSlashings); ii++ {
+		size += 4
+		size += m.VoteSlashings[ii].SizeSSZ()
+	}
+
+	// Field (2) 'RANDAOSlashings'
+	size += len(m.RANDAOSlashings) * 152
+
+	return
+}
+
+// HashTreeRoot ssz hashes the MsgSlashings object
+func (m *MsgSlashings) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(m)
+}
+
+// HashTreeRootWith ssz hashes the MsgSlashings object with a hasher
+func (m *MsgSlashings) HashTreeRootWith(hh *ssz.Hasher) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ProposerSlashings'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.ProposerSlashings))
+		if num > 2 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = m.ProposerSlashings[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 2)
+	}
+
+	// Field (1) 'VoteSlashings'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.VoteSlashings))
+		if num > 5 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = m.VoteSlashings[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 5)
+	}
+
+	// Field (2) 'RANDAOSlashings'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(m.RANDAOSlashings))
+		if num > 20 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for i := uint64(0); i < num; i++ {
+			if err = m.RANDAOSlashings[i].HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 20)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
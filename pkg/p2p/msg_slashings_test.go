@@ -0,0 +1,27 @@
+package p2p_test
+
+import (
+	"github.com/olympus-protocol/ogen/pkg/p2p"
+	testdata "github.com/olympus-protocol/ogen/test"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMsgSlashings(t *testing.T) {
+	v := new(p2p.MsgSlashings)
+	v.ProposerSlashings = testdata.FuzzProposerSlashing(2, true)
+	v.VoteSlashings = testdata.FuzzVoteSlashing(5)
+	v.RANDAOSlashings = testdata.FuzzRANDAOSlashing(20)
+
+	ser, err := v.Marshal()
+	assert.NoError(t, err)
+
+	desc := new(p2p.MsgSlashings)
+	err = desc.Unmarshal(ser)
+	assert.NoError(t, err)
+
+	assert.Equal(t, v, desc)
+
+	assert.Equal(t, p2p.MsgSlashingsCmd, v.Command())
+	assert.Equal(t, uint64(70110), v.MaxPayloadLength())
+}
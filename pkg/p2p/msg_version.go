@@ -1,7 +1,18 @@
 package p2p
 
+const (
+	// ProtocolVersion is the version of the p2p protocol implemented by the node.
+	ProtocolVersion = 1
+
+	// MinProtocolVersion is the oldest p2p protocol version supported by the node.
+	MinProtocolVersion = 1
+)
+
 // MsgVersion is the struct that contains the node information during the version handshake.
 type MsgVersion struct {
+	ProtocolVersion uint32
+	GenesisHash     [32]byte
+	ForkDigest      [4]byte
 	Tip             uint64
 	TipSlot         uint64
 	TipHash         [32]byte
@@ -32,7 +43,7 @@ func (m *MsgVersion) Command() string {
 
 // MaxPayloadLength returns the maximum size of the MsgVersion message.
 func (m *MsgVersion) MaxPayloadLength() uint64 {
-	return 240
+	return 280
 }
 
 // PayloadLength returns the size of the MsgVersion message.
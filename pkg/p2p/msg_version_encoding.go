@@ -1,5 +1,5 @@
 // Code generated by fastssz. DO NOT EDIT.
-// Hash: 16499077556a40c755f4052cb92884830a72c9cde268528bff7b836b9efa833e
+// Hash: f91de3ac2b82467678ee0d1832bc7956c7b10d05f33b9f3d76da5d615208eff0
 package p2p
 
 import (
@@ -15,37 +15,46 @@ func (m *MsgVersion) MarshalSSZ() ([]byte, error) {
 func (m *MsgVersion) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
 
-	// Field (0) 'Tip'
+	// Field (0) 'ProtocolVersion'
+	dst = ssz.MarshalUint32(dst, m.ProtocolVersion)
+
+	// Field (1) 'GenesisHash'
+	dst = append(dst, m.GenesisHash[:]...)
+
+	// Field (2) 'ForkDigest'
+	dst = append(dst, m.ForkDigest[:]...)
+
+	// Field (3) 'Tip'
 	dst = ssz.MarshalUint64(dst, m.Tip)
 
-	// Field (1) 'TipSlot'
+	// Field (4) 'TipSlot'
 	dst = ssz.MarshalUint64(dst, m.TipSlot)
 
-	// Field (2) 'TipHash'
+	// Field (5) 'TipHash'
 	dst = append(dst, m.TipHash[:]...)
 
-	// Field (3) 'Nonce'
+	// Field (6) 'Nonce'
 	dst = ssz.MarshalUint64(dst, m.Nonce)
 
-	// Field (4) 'Timestamp'
+	// Field (7) 'Timestamp'
 	dst = ssz.MarshalUint64(dst, m.Timestamp)
 
-	// Field (5) 'JustifiedSlot'
+	// Field (8) 'JustifiedSlot'
 	dst = ssz.MarshalUint64(dst, m.JustifiedSlot)
 
-	// Field (6) 'JustifiedHeight'
+	// Field (9) 'JustifiedHeight'
 	dst = ssz.MarshalUint64(dst, m.JustifiedHeight)
 
-	// Field (7) 'JustifiedHash'
+	// Field (10) 'JustifiedHash'
 	dst = append(dst, m.JustifiedHash[:]...)
 
-	// Field (8) 'FinalizedSlot'
+	// Field (11) 'FinalizedSlot'
 	dst = ssz.MarshalUint64(dst, m.FinalizedSlot)
 
-	// Field (9) 'FinalizedHeight'
+	// Field (12) 'FinalizedHeight'
 	dst = ssz.MarshalUint64(dst, m.FinalizedHeight)
 
-	// Field (10) 'FinalizedHash'
+	// Field (13) 'FinalizedHash'
 	dst = append(dst, m.FinalizedHash[:]...)
 
 	return
@@ -55,49 +64,58 @@ func (m *MsgVersion) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (m *MsgVersion) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size != 160 {
+	if size != 200 {
 		return ssz.ErrSize
 	}
 
-	// Field (0) 'Tip'
-	m.Tip = ssz.UnmarshallUint64(buf[0:8])
+	// Field (0) 'ProtocolVersion'
+	m.ProtocolVersion = ssz.UnmarshallUint32(buf[0:4])
+
+	// Field (1) 'GenesisHash'
+	copy(m.GenesisHash[:], buf[4:36])
+
+	// Field (2) 'ForkDigest'
+	copy(m.ForkDigest[:], buf[36:40])
 
-	// Field (1) 'TipSlot'
-	m.TipSlot = ssz.UnmarshallUint64(buf[8:16])
+	// Field (3) 'Tip'
+	m.Tip = ssz.UnmarshallUint64(buf[40:48])
 
-	// Field (2) 'TipHash'
-	copy(m.TipHash[:], buf[16:48])
+	// Field (4) 'TipSlot'
+	m.TipSlot = ssz.UnmarshallUint64(buf[48:56])
 
-	// Field (3) 'Nonce'
-	m.Nonce = ssz.UnmarshallUint64(buf[48:56])
+	// Field (5) 'TipHash'
+	copy(m.TipHash[:], buf[56:88])
 
-	// Field (4) 'Timestamp'
-	m.Timestamp = ssz.UnmarshallUint64(buf[56:64])
+	// Field (6) 'Nonce'
+	m.Nonce = ssz.UnmarshallUint64(buf[88:96])
 
-	// Field (5) 'JustifiedSlot'
-	m.JustifiedSlot = ssz.UnmarshallUint64(buf[64:72])
+	// Field (7) 'Timestamp'
+	m.Timestamp = ssz.UnmarshallUint64(buf[96:104])
 
-	// Field (6) 'JustifiedHeight'
-	m.JustifiedHeight = ssz.UnmarshallUint64(buf[72:80])
+	// Field (8) 'JustifiedSlot'
+	m.JustifiedSlot = ssz.UnmarshallUint64(buf[104:112])
 
-	// Field (7) 'JustifiedHash'
-	copy(m.JustifiedHash[:], buf[80:112])
+	// Field (9) 'JustifiedHeight'
+	m.JustifiedHeight = ssz.UnmarshallUint64(buf[112:120])
 
-	// Field (8) 'FinalizedSlot'
-	m.FinalizedSlot = ssz.UnmarshallUint64(buf[112:120])
+	// Field (10) 'JustifiedHash'
+	copy(m.JustifiedHash[:], buf[120:152])
 
-	// Field (9) 'FinalizedHeight'
-	m.FinalizedHeight = ssz.UnmarshallUint64(buf[120:128])
+	// Field (11) 'FinalizedSlot'
+	m.FinalizedSlot = ssz.UnmarshallUint64(buf[152:160])
 
-	// Field (10) 'FinalizedHash'
-	copy(m.FinalizedHash[:], buf[128:160])
+	// Field (12) 'FinalizedHeight'
+	m.FinalizedHeight = ssz.UnmarshallUint64(buf[160:168])
+
+	// Field (13) 'FinalizedHash'
+	copy(m.FinalizedHash[:], buf[168:200])
 
 	return err
 }
 
 // SizeSSZ returns the ssz encoded size in bytes for the MsgVersion object
 func (m *MsgVersion) SizeSSZ() (size int) {
-	size = 160
+	size = 200
 	return
 }
 
@@ -110,37 +128,46 @@ func (m *MsgVersion) HashTreeRoot() ([32]byte, error) {
 func (m *MsgVersion) HashTreeRootWith(hh *ssz.Hasher) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'Tip'
+	// Field (0) 'ProtocolVersion'
+	hh.PutUint32(m.ProtocolVersion)
+
+	// Field (1) 'GenesisHash'
+	hh.PutBytes(m.GenesisHash[:])
+
+	// Field (2) 'ForkDigest'
+	hh.PutBytes(m.ForkDigest[:])
+
+	// Field (3) 'Tip'
 	hh.PutUint64(m.Tip)
 
-	// Field (1) 'TipSlot'
+	// Field (4) 'TipSlot'
 	hh.PutUint64(m.TipSlot)
 
-	// Field (2) 'TipHash'
+	// Field (5) 'TipHash'
 	hh.PutBytes(m.TipHash[:])
 
-	// Field (3) 'Nonce'
+	// Field (6) 'Nonce'
 	hh.PutUint64(m.Nonce)
 
-	// Field (4) 'Timestamp'
+	// Field (7) 'Timestamp'
 	hh.PutUint64(m.Timestamp)
 
-	// Field (5) 'JustifiedSlot'
+	// Field (8) 'JustifiedSlot'
 	hh.PutUint64(m.JustifiedSlot)
 
-	// Field (6) 'JustifiedHeight'
+	// Field (9) 'JustifiedHeight'
 	hh.PutUint64(m.JustifiedHeight)
 
-	// Field (7) 'JustifiedHash'
+	// Field (10) 'JustifiedHash'
 	hh.PutBytes(m.JustifiedHash[:])
 
-	// Field (8) 'FinalizedSlot'
+	// Field (11) 'FinalizedSlot'
 	hh.PutUint64(m.FinalizedSlot)
 
-	// Field (9) 'FinalizedHeight'
+	// Field (12) 'FinalizedHeight'
 	hh.PutUint64(m.FinalizedHeight)
 
-	// Field (10) 'FinalizedHash'
+	// Field (13) 'FinalizedHash'
 	hh.PutBytes(m.FinalizedHash[:])
 
 	hh.Merkleize(indx)
@@ -22,6 +22,6 @@ func TestMsgVersion(t *testing.T) {
 	assert.Equal(t, v, desc)
 
 	assert.Equal(t, p2p.MsgVersionCmd, v.Command())
-	assert.Equal(t, uint64(240), v.MaxPayloadLength())
+	assert.Equal(t, uint64(280), v.MaxPayloadLength())
 
 }
@@ -0,0 +1,70 @@
+package params
+
+import (
+	"encoding/binary"
+
+	"github.com/olympus-protocol/ogen/pkg/chainhash"
+)
+
+// DomainType identifies the kind of message a signature is made over.
+type DomainType uint32
+
+const (
+	// DomainBlock is used for the block signatures of the proposers.
+	DomainBlock DomainType = iota
+	// DomainRANDAO is used for the RANDAO reveals of the proposers.
+	DomainRANDAO
+	// DomainVote is used for the votes of the validators.
+	DomainVote
+	// DomainTx is used for the coin transactions.
+	DomainTx
+	// DomainDeposit is used for the deposit signatures of the withdrawal keys.
+	DomainDeposit
+	// DomainProofOfPossession is used for the proof of possession of the validator keys included on deposits.
+	DomainProofOfPossession
+	// DomainExit is used for the exit signatures of the withdrawal keys.
+	DomainExit
+	// DomainPartialExit is used for the partial exit signatures of the withdrawal keys.
+	DomainPartialExit
+)
+
+// Domain returns the signing domain of a message type on a chain following a version of the consensus rules.
+func Domain(t DomainType, genesisHash chainhash.Hash, forkVersion uint32) chainhash.Hash {
+	buf := make([]byte, 40)
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(t))
+	copy(buf[4:36], genesisHash[:])
+	binary.LittleEndian.PutUint32(buf[36:], forkVersion)
+	return chainhash.HashH(buf)
+}
+
+// SigningRoot binds a message to a signing domain.
+func SigningRoot(msg chainhash.Hash, domain chainhash.Hash) chainhash.Hash {
+	buf := make([]byte, 64)
+	copy(buf[0:32], msg[:])
+	copy(buf[32:64], domain[:])
+	return chainhash.HashH(buf)
+}
+
+// InDomainGrace returns true if the slot is on the grace period after DomainForkSlot.
+func (p *ChainParams) InDomainGrace(slot uint64) bool {
+	return slot >= p.DomainForkSlot && slot-p.DomainForkSlot < p.DomainGraceSlots
+}
+
+// OperationSigningMessages returns the messages accepted for the signature of an operation included on a slot.
+// The first one is the message signed from that slot, during the grace period the bare message is accepted too.
+func (p *ChainParams) OperationSigningMessages(t DomainType, msg chainhash.Hash, slot uint64) []chainhash.Hash {
+	signing := p.SigningMessage(t, msg, slot)
+	if p.InDomainGrace(slot) {
+		return []chainhash.Hash{signing, msg}
+	}
+	return []chainhash.Hash{signing}
+}
+
+// SigningMessage returns the message signed for msg on a slot. Signatures for slots before DomainForkSlot
+// are made over the bare message.
+func (p *ChainParams) SigningMessage(t DomainType, msg chainhash.Hash, slot uint64) chainhash.Hash {
+	if slot < p.DomainForkSlot {
+		return msg
+	}
+	return SigningRoot(msg, Domain(t, p.GenesisHash, p.ForkVersion))
+}
@@ -1,6 +1,7 @@
 package params
 
 import (
+	"encoding/binary"
 	"fmt"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
@@ -22,6 +23,20 @@ func ProtocolID(net string) protocol.ID {
 	return protocol.ID("/ogen/" + net)
 }
 
+// ForkDigest returns the identifier of a chain and a version of its consensus rules. Nodes with a different
+// fork digest can't follow the same chain.
+func ForkDigest(genesisHash chainhash.Hash, forkVersion uint32) [4]byte {
+	buf := make([]byte, 36)
+	copy(buf[0:32], genesisHash[:])
+	binary.LittleEndian.PutUint32(buf[32:], forkVersion)
+
+	var digest [4]byte
+	h := chainhash.HashH(buf)
+	copy(digest[:], h[:4])
+
+	return digest
+}
+
 // AccountPrefixes are prefixes used for account bech32 encoding.
 type AccountPrefixes struct {
 	Public   string
@@ -39,12 +54,21 @@ type ChainParams struct {
 	Name string
 	// DefaultP2PPort is the default P2P port on which outbound/inbound connections are handled
 	DefaultP2PPort string
-	// GenesisHash is the hash of the genesis block
+	// GenesisHash is the hash that identifies the chain. It is set when the chain is loaded and signing
+	// domains commit to it.
 	GenesisHash chainhash.Hash
 	// AccountPrefixes are the prefixes for bech32 accounts generator.
 	AccountPrefixes AccountPrefixes
 	// NetMagic is a number to serve as an  ID for Olympus specific messages
 	NetMagic uint32
+	// ForkVersion is the version of the consensus rules followed by the network.
+	ForkVersion uint32
+	// DomainForkSlot is the first slot on which signatures are bound to a signing domain.
+	DomainForkSlot uint64
+	// DomainGraceSlots is the amount of slots after DomainForkSlot on which operations signed over the bare
+	// message are still accepted. Operations don't sign the slot they are included on, so they may be signed
+	// before the fork and included after it.
+	DomainGraceSlots uint64
 	// UnitsPerCoin is the amount of decimals used for coins.
 	UnitsPerCoin uint64
 	// RendevouzStrings are strings versioned for the DHT Peer relayer
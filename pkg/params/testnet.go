@@ -10,9 +10,11 @@ var merkleRootHashTestNet = [32]byte{127, 204, 15, 103, 123, 104, 167, 224, 239,
 
 // TestNet are chain parameters used for the testnet.
 var TestNet = ChainParams{
-	Name:           "testnet",
-	DefaultP2PPort: "25126",
-	NetMagic:       222999,
+	Name:             "testnet",
+	DefaultP2PPort:   "25126",
+	NetMagic:         222999,
+	DomainForkSlot:   86400, // 30 days after genesis
+	DomainGraceSlots: 2880,  // 1 day
 	AccountPrefixes: AccountPrefixes{
 		Public:   "tlpub",
 		Private:  "tlprv",
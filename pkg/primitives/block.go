@@ -1,6 +1,10 @@
 package primitives
 
 import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
 	"github.com/golang/snappy"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
 )
@@ -207,6 +211,52 @@ func merkleRootPartialExit(e []*PartialExit) chainhash.Hash {
 	return chainhash.HashH(append(h1[:], h2[:]...))
 }
 
+// CheckMerkleRoots checks the merkle roots of the block contents against the ones committed in the header.
+func (b *Block) CheckMerkleRoots() error {
+	voteMerkleRoot := b.VotesMerkleRoot()
+	depositMerkleRoot := b.DepositMerkleRoot()
+	exitMerkleRoot := b.ExitMerkleRoot()
+	partialExitsMerkleRoot := b.PartialExitsMerkleRoot()
+	txsMerkleRoot := b.TxsMerkleRoot()
+	voteSlashingMerkleRoot := b.VoteSlashingRoot()
+	proposerSlashingMerkleRoot := b.ProposerSlashingsRoot()
+	randaoSlashingMerkleRoot := b.RANDAOSlashingsRoot()
+
+	if !bytes.Equal(depositMerkleRoot[:], b.Header.DepositMerkleRoot[:]) {
+		return fmt.Errorf("expected deposit merkle root to be %s but got %s", hex.EncodeToString(depositMerkleRoot[:]), hex.EncodeToString(b.Header.DepositMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(exitMerkleRoot[:], b.Header.ExitMerkleRoot[:]) {
+		return fmt.Errorf("expected exit merkle root to be %s but got %s", hex.EncodeToString(exitMerkleRoot[:]), hex.EncodeToString(b.Header.ExitMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(voteMerkleRoot[:], b.Header.VoteMerkleRoot[:]) {
+		return fmt.Errorf("expected vote merkle root to be %s but got %s", hex.EncodeToString(voteMerkleRoot[:]), hex.EncodeToString(b.Header.VoteMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(partialExitsMerkleRoot[:], b.Header.PartialExitMerkleRoot[:]) {
+		return fmt.Errorf("expected partial exits merkle root to be %s but got %s", hex.EncodeToString(partialExitsMerkleRoot[:]), hex.EncodeToString(b.Header.PartialExitMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(txsMerkleRoot[:], b.Header.TxsMerkleRoot[:]) {
+		return fmt.Errorf("expected transaction merkle root to be %s but got %s", hex.EncodeToString(txsMerkleRoot[:]), hex.EncodeToString(b.Header.TxsMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(voteSlashingMerkleRoot[:], b.Header.VoteSlashingMerkleRoot[:]) {
+		return fmt.Errorf("expected vote slashing merkle root to be %s but got %s", hex.EncodeToString(voteSlashingMerkleRoot[:]), hex.EncodeToString(b.Header.VoteSlashingMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(proposerSlashingMerkleRoot[:], b.Header.ProposerSlashingMerkleRoot[:]) {
+		return fmt.Errorf("expected proposer slashing merkle root to be %s but got %s", hex.EncodeToString(proposerSlashingMerkleRoot[:]), hex.EncodeToString(b.Header.ProposerSlashingMerkleRoot[:]))
+	}
+
+	if !bytes.Equal(randaoSlashingMerkleRoot[:], b.Header.RANDAOSlashingMerkleRoot[:]) {
+		return fmt.Errorf("expected randao slashing merkle root to be %s but got %s", hex.EncodeToString(randaoSlashingMerkleRoot[:]), hex.EncodeToString(b.Header.RANDAOSlashingMerkleRoot[:]))
+	}
+
+	return nil
+}
+
 // GetTxs returns a slice with tx hashes
 func (b *Block) GetTxs() []string {
 	txs := make([]string, len(b.Txs))
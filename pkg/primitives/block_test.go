@@ -72,3 +72,23 @@ func TestBlocksMerkle(t *testing.T) {
 	assert.Equal(t, expectedTx[0], txs[0])
 	assert.Equal(t, expectedTx[1], txs[1])
 }
+
+func TestBlockCheckMerkleRoots(t *testing.T) {
+	b := testdata.FuzzBlock(1, true, true)[0]
+
+	assert.Error(t, b.CheckMerkleRoots())
+
+	b.Header.VoteMerkleRoot = b.VotesMerkleRoot()
+	b.Header.DepositMerkleRoot = b.DepositMerkleRoot()
+	b.Header.ExitMerkleRoot = b.ExitMerkleRoot()
+	b.Header.PartialExitMerkleRoot = b.PartialExitsMerkleRoot()
+	b.Header.TxsMerkleRoot = b.TxsMerkleRoot()
+	b.Header.VoteSlashingMerkleRoot = b.VoteSlashingRoot()
+	b.Header.ProposerSlashingMerkleRoot = b.ProposerSlashingsRoot()
+	b.Header.RANDAOSlashingMerkleRoot = b.RANDAOSlashingsRoot()
+
+	assert.NoError(t, b.CheckMerkleRoots())
+
+	b.Header.TxsMerkleRoot = [32]byte{}
+	assert.Error(t, b.CheckMerkleRoots())
+}
@@ -1,7 +1,10 @@
 package primitives
 
 import (
+	"fmt"
+
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/params"
 )
 
 // BlockHeader is the container of merkle roots for the blockchain
@@ -40,3 +43,13 @@ func (b *BlockHeader) Hash() chainhash.Hash {
 	by, _ := b.Marshal()
 	return chainhash.HashH(by)
 }
+
+// SigningMessage returns the message signed by the proposer of the block.
+func (b *BlockHeader) SigningMessage(p *params.ChainParams) chainhash.Hash {
+	return p.SigningMessage(params.DomainBlock, b.Hash(), b.Slot)
+}
+
+// RANDAOMessage returns the message signed for the RANDAO reveal of a slot.
+func RANDAOMessage(p *params.ChainParams, slot uint64) chainhash.Hash {
+	return p.SigningMessage(params.DomainRANDAO, chainhash.HashH([]byte(fmt.Sprintf("%d", slot))), slot)
+}
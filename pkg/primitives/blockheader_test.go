@@ -44,3 +44,24 @@ func TestBlockHeader(t *testing.T) {
 
 	assert.Equal(t, "43bde602976eec9ee187f5a1ad2fdb117e2052c87591592f9358602cdfdfdd86", d.Hash().String())
 }
+
+func TestBlockHeaderSigningMessage(t *testing.T) {
+	netParams := testdata.TestParams
+	netParams.DomainForkSlot = 10
+
+	before := primitives.BlockHeader{Slot: 9}
+	assert.Equal(t, before.Hash(), before.SigningMessage(&netParams))
+
+	after := primitives.BlockHeader{Slot: 10}
+	msg := after.SigningMessage(&netParams)
+	assert.NotEqual(t, after.Hash(), msg)
+	assert.NotEqual(t, primitives.RANDAOMessage(&netParams, 10), msg)
+
+	otherChain := netParams
+	otherChain.GenesisHash = [32]byte{1}
+	assert.NotEqual(t, msg, after.SigningMessage(&otherChain))
+
+	otherFork := netParams
+	otherFork.ForkVersion = 1
+	assert.NotEqual(t, msg, after.SigningMessage(&otherFork))
+}
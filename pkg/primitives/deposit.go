@@ -4,6 +4,7 @@ import (
 	"github.com/olympus-protocol/ogen/pkg/bls"
 	"github.com/olympus-protocol/ogen/pkg/bls/common"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/params"
 )
 
 // Deposit is a deposit a user can submit to queue as a validator.
@@ -44,6 +45,35 @@ func (d *Deposit) Hash() chainhash.Hash {
 	return chainhash.HashH(b)
 }
 
+// SigningMessage returns the message signed by the depositing key for a deposit included on a slot.
+func (d *Deposit) SigningMessage(p *params.ChainParams, slot uint64) (chainhash.Hash, error) {
+	b, err := d.Data.Marshal()
+	if err != nil {
+		return chainhash.Hash{}, err
+	}
+	return p.SigningMessage(params.DomainDeposit, chainhash.HashH(b), slot), nil
+}
+
+// VerifySig verifies the signature of the depositing key for a deposit included on a slot.
+func (d *Deposit) VerifySig(p *params.ChainParams, slot uint64) error {
+	b, err := d.Data.Marshal()
+	if err != nil {
+		return err
+	}
+	pub, err := d.GetPublicKey()
+	if err != nil {
+		return err
+	}
+	sig, err := d.GetSignature()
+	if err != nil {
+		return err
+	}
+	if !verifyAny(sig, pub, p.OperationSigningMessages(params.DomainDeposit, chainhash.HashH(b), slot)) {
+		return ErrorInvalidDepositSignature
+	}
+	return nil
+}
+
 // DepositData is the part of the deposit that is signed
 type DepositData struct {
 	// PublicKey is the key used for the validator.
@@ -75,3 +105,24 @@ func (d *DepositData) GetPublicKey() (common.PublicKey, error) {
 func (d *DepositData) GetSignature() (common.Signature, error) {
 	return bls.SignatureFromBytes(d.ProofOfPossession[:])
 }
+
+// ProofOfPossessionMessage returns the message signed by the validator key for a deposit included on a slot.
+func (d *DepositData) ProofOfPossessionMessage(p *params.ChainParams, slot uint64) chainhash.Hash {
+	return p.SigningMessage(params.DomainProofOfPossession, chainhash.HashH(d.PublicKey[:]), slot)
+}
+
+// VerifySig verifies the proof of possession of the validator key for a deposit included on a slot.
+func (d *DepositData) VerifySig(p *params.ChainParams, slot uint64) error {
+	pub, err := d.GetPublicKey()
+	if err != nil {
+		return err
+	}
+	sig, err := d.GetSignature()
+	if err != nil {
+		return err
+	}
+	if !verifyAny(sig, pub, p.OperationSigningMessages(params.DomainProofOfPossession, chainhash.HashH(d.PublicKey[:]), slot)) {
+		return ErrorInvalidProofOfPossession
+	}
+	return nil
+}
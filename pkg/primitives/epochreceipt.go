@@ -1,6 +1,10 @@
 package primitives
 
-import "fmt"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
 
 const (
 	RewardMatchedFromEpoch uint64 = iota
@@ -15,11 +19,65 @@ const (
 	PenaltyInactivityLeakNoVote
 )
 
+// EpochReceiptSize is the size of a serialized epoch receipt.
+const EpochReceiptSize = 8 * 4 // 32 bytes
+
+// ErrorEpochReceiptSize returned when the serialized receipts don't match the receipt size.
+var ErrorEpochReceiptSize = errors.New("epoch receipt wrong size")
+
 // EpochReceipt is a balance change carried our by an epoch transition.
 type EpochReceipt struct {
 	Type      uint64
 	Amount    int64
 	Validator uint64
+	Epoch     uint64
+}
+
+// Marshal encodes the data.
+func (e *EpochReceipt) Marshal() []byte {
+	b := make([]byte, EpochReceiptSize)
+	binary.LittleEndian.PutUint64(b[0:8], e.Type)
+	binary.LittleEndian.PutUint64(b[8:16], uint64(e.Amount))
+	binary.LittleEndian.PutUint64(b[16:24], e.Validator)
+	binary.LittleEndian.PutUint64(b[24:32], e.Epoch)
+	return b
+}
+
+// Unmarshal decodes the data.
+func (e *EpochReceipt) Unmarshal(b []byte) error {
+	if len(b) != EpochReceiptSize {
+		return ErrorEpochReceiptSize
+	}
+	e.Type = binary.LittleEndian.Uint64(b[0:8])
+	e.Amount = int64(binary.LittleEndian.Uint64(b[8:16]))
+	e.Validator = binary.LittleEndian.Uint64(b[16:24])
+	e.Epoch = binary.LittleEndian.Uint64(b[24:32])
+	return nil
+}
+
+// MarshalEpochReceipts encodes a list of receipts.
+func MarshalEpochReceipts(receipts []*EpochReceipt) []byte {
+	b := make([]byte, 0, len(receipts)*EpochReceiptSize)
+	for _, r := range receipts {
+		b = append(b, r.Marshal()...)
+	}
+	return b
+}
+
+// UnmarshalEpochReceipts decodes a list of receipts.
+func UnmarshalEpochReceipts(b []byte) ([]*EpochReceipt, error) {
+	if len(b)%EpochReceiptSize != 0 {
+		return nil, ErrorEpochReceiptSize
+	}
+	receipts := make([]*EpochReceipt, len(b)/EpochReceiptSize)
+	for i := range receipts {
+		r := new(EpochReceipt)
+		if err := r.Unmarshal(b[i*EpochReceiptSize : (i+1)*EpochReceiptSize]); err != nil {
+			return nil, err
+		}
+		receipts[i] = r
+	}
+	return receipts, nil
 }
 
 func (e EpochReceipt) TypeString() string {
@@ -43,3 +43,20 @@ func TestEpochReceipt(t *testing.T) {
 	e.Type = 10
 	assert.Equal(t, e.TypeString(), "invalid receipt type: 10", e.TypeString())
 }
+
+func TestEpochReceiptsEncoding(t *testing.T) {
+	receipts := []*primitives.EpochReceipt{
+		{Type: primitives.RewardMatchedFromEpoch, Amount: 100, Validator: 50, Epoch: 3},
+		{Type: primitives.PenaltyInactivityLeak, Amount: -250, Validator: 7, Epoch: 3},
+	}
+
+	b := primitives.MarshalEpochReceipts(receipts)
+	assert.Equal(t, len(receipts)*primitives.EpochReceiptSize, len(b))
+
+	decoded, err := primitives.UnmarshalEpochReceipts(b)
+	assert.NoError(t, err)
+	assert.Equal(t, receipts, decoded)
+
+	_, err = primitives.UnmarshalEpochReceipts(b[1:])
+	assert.Equal(t, primitives.ErrorEpochReceiptSize, err)
+}
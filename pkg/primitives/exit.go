@@ -4,6 +4,7 @@ import (
 	"github.com/olympus-protocol/ogen/pkg/bls"
 	"github.com/olympus-protocol/ogen/pkg/bls/common"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/params"
 )
 
 // MaxExitSize is the maximum amount of bytes an exit can contain.
@@ -46,3 +47,24 @@ func (e *Exit) Hash() chainhash.Hash {
 	b, _ := e.Marshal()
 	return chainhash.HashH(b)
 }
+
+// SigningMessage returns the message signed by the withdrawal key for an exit included on a slot.
+func (e *Exit) SigningMessage(p *params.ChainParams, slot uint64) chainhash.Hash {
+	return p.SigningMessage(params.DomainExit, chainhash.HashH(e.ValidatorPubkey[:]), slot)
+}
+
+// VerifySig verifies the withdrawal key signature of an exit included on a slot.
+func (e *Exit) VerifySig(p *params.ChainParams, slot uint64) error {
+	pub, err := e.GetWithdrawPubKey()
+	if err != nil {
+		return err
+	}
+	sig, err := e.GetSignature()
+	if err != nil {
+		return err
+	}
+	if !verifyAny(sig, pub, p.OperationSigningMessages(params.DomainExit, chainhash.HashH(e.ValidatorPubkey[:]), slot)) {
+		return ErrorInvalidExitSignature
+	}
+	return nil
+}
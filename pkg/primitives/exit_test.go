@@ -48,3 +48,33 @@ func TestExit(t *testing.T) {
 
 	assert.Equal(t, "da00eec87a40df65032972347339c93dd822ede60c596e37cacfe0d2745f9085", e.Hash().String())
 }
+
+func TestExitSigningGrace(t *testing.T) {
+	netParams := testdata.TestParams
+	netParams.DomainForkSlot = 10
+	netParams.DomainGraceSlots = 5
+
+	key, err := bls.RandKey()
+	assert.NoError(t, err)
+
+	exit := &primitives.Exit{}
+	copy(exit.ValidatorPubkey[:], key.PublicKey().Marshal())
+	copy(exit.WithdrawPubkey[:], key.PublicKey().Marshal())
+
+	// Signed before the fork, over the bare message.
+	msg := exit.SigningMessage(&netParams, 9)
+	copy(exit.Signature[:], key.Sign(msg[:]).Marshal())
+
+	assert.NoError(t, exit.VerifySig(&netParams, 9))
+	assert.NoError(t, exit.VerifySig(&netParams, 10))
+	assert.NoError(t, exit.VerifySig(&netParams, 14))
+	assert.Equal(t, primitives.ErrorInvalidExitSignature, exit.VerifySig(&netParams, 15))
+
+	// Signed after the fork, bound to the exit domain.
+	msg = exit.SigningMessage(&netParams, 10)
+	copy(exit.Signature[:], key.Sign(msg[:]).Marshal())
+
+	assert.Equal(t, primitives.ErrorInvalidExitSignature, exit.VerifySig(&netParams, 9))
+	assert.NoError(t, exit.VerifySig(&netParams, 10))
+	assert.NoError(t, exit.VerifySig(&netParams, 15))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/olympus-protocol/ogen/pkg/bls"
 	"github.com/olympus-protocol/ogen/pkg/bls/common"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/params"
 )
 
 // PartialExit claims a partial amount of a validator balance without removing it from the validator registry.
@@ -44,3 +45,24 @@ func (p *PartialExit) Hash() chainhash.Hash {
 	b, _ := p.Marshal()
 	return chainhash.HashH(b)
 }
+
+// SigningMessage returns the message signed by the withdrawal key for a partial exit included on a slot.
+func (p *PartialExit) SigningMessage(netParams *params.ChainParams, slot uint64) chainhash.Hash {
+	return netParams.SigningMessage(params.DomainPartialExit, chainhash.HashH(p.ValidatorPubkey[:]), slot)
+}
+
+// VerifySig verifies the withdrawal key signature of a partial exit included on a slot.
+func (p *PartialExit) VerifySig(netParams *params.ChainParams, slot uint64) error {
+	pub, err := p.GetWithdrawPubKey()
+	if err != nil {
+		return err
+	}
+	sig, err := p.GetSignature()
+	if err != nil {
+		return err
+	}
+	if !verifyAny(sig, pub, netParams.OperationSigningMessages(params.DomainPartialExit, chainhash.HashH(p.ValidatorPubkey[:]), slot)) {
+		return ErrorInvalidExitSignature
+	}
+	return nil
+}
@@ -6,11 +6,18 @@ import (
 
 	"github.com/olympus-protocol/ogen/pkg/bls"
 	"github.com/olympus-protocol/ogen/pkg/chainhash"
+	"github.com/olympus-protocol/ogen/pkg/params"
 )
 
 var (
 	// ErrorInvalidSignature returned when a tx signature is invalid.
 	ErrorInvalidSignature = errors.New("invalid tx signature")
+	// ErrorInvalidExitSignature returned when an exit or partial exit signature is invalid.
+	ErrorInvalidExitSignature = errors.New("exit signature is not valid")
+	// ErrorInvalidDepositSignature returned when a deposit signature is invalid.
+	ErrorInvalidDepositSignature = errors.New("deposit signature is not valid")
+	// ErrorInvalidProofOfPossession returned when the proof of possession of a deposit is invalid.
+	ErrorInvalidProofOfPossession = errors.New("proof-of-possession is not valid")
 )
 
 // Tx represents a transaction on the blockchain.
@@ -56,6 +63,11 @@ func (t Tx) SignatureMessage() chainhash.Hash {
 	return chainhash.HashH(b)
 }
 
+// SigningMessage returns the message signed for a transaction included on a slot.
+func (t Tx) SigningMessage(p *params.ChainParams, slot uint64) chainhash.Hash {
+	return p.SigningMessage(params.DomainTx, t.SignatureMessage(), slot)
+}
+
 // GetSignature returns the bls signature of the transaction.
 func (t Tx) GetSignature() (common.Signature, error) {
 	return bls.SignatureFromBytes(t.Signature[:])
@@ -66,10 +78,10 @@ func (t Tx) GetPublic() (common.PublicKey, error) {
 	return bls.PublicKeyFromBytes(t.FromPublicKey[:])
 }
 
-// VerifySig verifies the signatures is valid.
-func (t *Tx) VerifySig() error {
+// VerifySig verifies the signatures is valid for a transaction included on a slot.
+func (t *Tx) VerifySig(p *params.ChainParams, slot uint64) error {
 
-	sigMsg := t.SignatureMessage()
+	sigMsgs := p.OperationSigningMessages(params.DomainTx, t.SignatureMessage(), slot)
 
 	sig, err := t.GetSignature()
 	if err != nil {
@@ -82,10 +94,20 @@ func (t *Tx) VerifySig() error {
 		return err
 	}
 
-	valid := sig.Verify(pub, sigMsg[:])
+	valid := verifyAny(sig, pub, sigMsgs)
 
 	if !valid {
 		return ErrorInvalidSignature
 	}
 	return nil
 }
+
+// verifyAny returns true if the signature is valid for any of the messages.
+func verifyAny(sig common.Signature, pub common.PublicKey, msgs []chainhash.Hash) bool {
+	for _, msg := range msgs {
+		if sig.Verify(pub, msg[:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -23,7 +23,7 @@ func TestTx(t *testing.T) {
 
 		assert.Equal(t, c, desc)
 
-		assert.NoError(t, c.VerifySig())
+		assert.NoError(t, c.VerifySig(&testdata.TestParams, 0))
 	}
 
 	sigDecode, _ := hex.DecodeString("ae09507041b2ccb9e3b3f9cda71ffae3dc8b2c83f331ebdc98cc4269c56bd4db05706bf317c8877608bc751b36d9af380c5fea6bc804d2080940b3910acc8f222fc4b59166630d8a3b31eba539325c2c60aaaa0408e986241cb462fad8652bdc")
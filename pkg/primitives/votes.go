@@ -145,6 +145,11 @@ func (v *VoteData) Hash() chainhash.Hash {
 	return chainhash.HashH(b)
 }
 
+// SigningMessage returns the message signed by the validators voting for the vote data.
+func (v *VoteData) SigningMessage(p *params.ChainParams) chainhash.Hash {
+	return p.SigningMessage(params.DomainVote, v.Hash(), v.Slot)
+}
+
 // MultiValidatorVote is a vote signed by one or many validators.
 type MultiValidatorVote struct {
 	// Data defines the vote properties.
@@ -23,3 +23,11 @@ sszgen -path ./pkg/primitives/slashing.go -include ./pkg/primitives/votes.go,./p
 sszgen -path ./pkg/primitives/tx.go
 sszgen -path ./pkg/primitives/state.go -objs SerializableState -include ./pkg/primitives/coins.go,./pkg/primitives/validator.go,./pkg/primitives/votes.go
 sszgen -path ./pkg/primitives/blocknodedisk.go
+sszgen -path ./internal/chainarchive/archive.go -objs Header
+sszgen -path ./internal/mempool/persist.go -objs SerializablePool -include ./pkg/primitives/votes.go,./pkg/primitives/deposit.go,./pkg/primitives/exit.go,./pkg/primitives/partialexit.go,./pkg/primitives/tx.go,./pkg/primitives/slashing.go,./pkg/primitives/blockheader.go
+sszgen -path ./pkg/p2p/msg_getblocks_range.go
+sszgen -path ./pkg/p2p/msg_getblocks_root.go
+sszgen -path ./pkg/p2p/msg_blocks.go -include ./pkg/primitives/block.go,./pkg/primitives/blockheader.go,./pkg/primitives/votes.go,./pkg/primitives/tx.go,./pkg/primitives/deposit.go,./pkg/primitives/exit.go,./pkg/primitives/slashing.go,./pkg/primitives/partialexit.go
+sszgen -path ./pkg/p2p/msg_getheaders.go
+sszgen -path ./pkg/p2p/msg_headers.go -include ./pkg/primitives/blockheader.go
+sszgen -path ./pkg/p2p/msg_slashings.go -include ./pkg/primitives/blockheader.go,./pkg/primitives/votes.go,./pkg/primitives/slashing.go
@@ -300,7 +300,7 @@ func FuzzTx(n int) []*primitives.Tx {
 		k, _ := bls.RandKey()
 		pubBytes := k.PublicKey().Marshal()
 		copy(d.FromPublicKey[:], pubBytes)
-		msg := d.SignatureMessage()
+		msg := d.SigningMessage(&TestParams, 0)
 		sig := k.Sign(msg[:])
 		copy(d.Signature[:], sig.Marshal())
 		v = append(v, d)